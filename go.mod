@@ -3,6 +3,7 @@ module github.com/gucio321/HellSpawner
 go 1.23.3
 
 require (
+	github.com/AllenDang/cimgui-go v1.2.0
 	github.com/AllenDang/giu v0.11.1-0.20241204120139-3d9187d60fdb
 	github.com/OpenDiablo2/OpenDiablo2 v0.0.0-20210514222603-a688d660a0f7
 	github.com/OpenDiablo2/dialog v0.0.0-20201230220514-26162241209f
@@ -13,13 +14,13 @@ require (
 	github.com/kirsle/configdir v0.0.0-20170128060238-e45d2f54772f
 	github.com/ozankasikci/go-image-merge v0.2.3-0.20210426105355-ce64427c0c12
 	github.com/pkg/browser v0.0.0-20240102092130-5ac0b6a4141c
+	github.com/robertkrimen/otto v0.0.0-20200922221731-ef014fd054ac
 	github.com/russross/blackfriday v1.6.0
 	golang.org/x/image v0.22.0
 )
 
 require (
 	dmitri.shuralyov.com/gpu/mtl v0.0.0-20201218220906-28db891af037 // indirect
-	github.com/AllenDang/cimgui-go v1.2.0 // indirect
 	github.com/AllenDang/go-findfont v0.0.0-20200702051237-9f180485aeb8 // indirect
 	github.com/BurntSushi/toml v0.3.1 // indirect
 	github.com/BurntSushi/xgb v0.0.0-20160522181843-27f122750802 // indirect
@@ -67,7 +68,6 @@ require (
 	github.com/pkg/profile v1.5.0 // indirect
 	github.com/pmezard/go-difflib v1.0.0 // indirect
 	github.com/rivo/uniseg v0.2.0 // indirect
-	github.com/robertkrimen/otto v0.0.0-20200922221731-ef014fd054ac // indirect
 	github.com/rogpeppe/go-internal v1.12.0 // indirect
 	github.com/rs/xid v1.2.1 // indirect
 	github.com/rs/zerolog v1.21.0 // indirect
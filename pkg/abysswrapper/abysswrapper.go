@@ -1,90 +1,192 @@
 package abysswrapper
 
 import (
+	"bufio"
+	"errors"
 	"fmt"
-	"github.com/gucio321/HellSpawner/pkg/app/config"
 	"io"
 	"os/exec"
 	"sync"
+	"syscall"
 	"time"
+
+	"github.com/gucio321/HellSpawner/pkg/abysswrapper/hotreload"
 )
 
 const (
-	waitTime = 3
+	// DefaultStopTimeout is a time given to the engine to exit after SIGTERM, before it gets killed
+	DefaultStopTimeout = 5 * time.Second
+	outputPrefix       = "[Abyss Engine]"
+	noExitCode         = -1
 )
 
+// ErrAlreadyRunning is returned by Launch, when the engine is running already
+var ErrAlreadyRunning = errors.New("the engine is already running")
+
 // AbyssWrapper represents abyss wrapper
 type AbyssWrapper struct {
-	running bool
-	output  io.Writer
-	cmd     *exec.Cmd
-	mutex   sync.RWMutex
+	running  bool
+	exitCode int
+	output   io.Writer
+	cmd      *exec.Cmd
+	done     chan struct{}
+	ipc      *hotreload.Client
+	mutex    sync.RWMutex
+
+	// output is written from both, stdout and stderr goroutines
+	outputMutex sync.Mutex
 }
 
 // Create creates new Abyss Wrapper
 func Create() *AbyssWrapper {
-	result := &AbyssWrapper{}
-	result.mutex = sync.RWMutex{}
+	result := &AbyssWrapper{
+		exitCode: noExitCode,
+	}
 
 	return result
 }
 
-func (a *AbyssWrapper) Read(p []byte) (n int, err error) {
-	time.Sleep(time.Second * waitTime)
-	bytes := []byte("Hello from HellSpawner! " + time.Now().String() + "\n")
-	n = copy(p, bytes)
-	err = nil
+// Launch launches the engine. Its stdout and stderr are written to output line by line,
+// prefixed with a severity of each line. Only one engine can run at a time;
+// ErrAlreadyRunning is returned, if it is running already.
+func (a *AbyssWrapper) Launch(opts *LaunchOptions, output io.Writer) error {
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+
+	if a.running {
+		return ErrAlreadyRunning
+	}
 
-	return
-}
+	cmd := exec.Command(opts.EnginePath, opts.Args...) //nolint:gosec // is ok
+	cmd.Dir = opts.WorkDir
+	cmd.Env = opts.environment()
 
-func (a *AbyssWrapper) Write(p []byte) (n int, err error) {
-	n, err = a.output.Write(p)
+	stdout, err := cmd.StdoutPipe()
 	if err != nil {
-		return n, fmt.Errorf("error writing to output: %w", err)
+		return fmt.Errorf("error getting engine's stdout: %w", err)
+	}
+
+	stderr, err := cmd.StderrPipe()
+	if err != nil {
+		return fmt.Errorf("error getting engine's stderr: %w", err)
+	}
+
+	if err := cmd.Start(); err != nil {
+		return fmt.Errorf("error while running AbyssWrapper: %w", err)
+	}
+
+	a.cmd = cmd
+	a.output = output
+	a.running = true
+	a.exitCode = noExitCode
+	a.done = make(chan struct{})
+
+	wg := &sync.WaitGroup{}
+
+	//nolint:mnd // stdout and stderr
+	wg.Add(2)
+
+	go a.readLines(stdout, SeverityInfo, wg)
+	go a.readLines(stderr, SeverityError, wg)
+
+	go a.wait(cmd, wg, a.done)
+
+	if opts.IPCSocket != "" {
+		go a.connectIPC(opts.IPCSocket, a.done)
 	}
 
-	return n, nil
+	return nil
 }
 
-// Launch launches abyss wrapper
-func (a *AbyssWrapper) Launch(cfg *config.Config, output io.Writer) error {
-	a.mutex.RLock()
-	if a.running {
-		a.mutex.RUnlock()
+// readLines writes lines from r into the output. Lines without a severity marker
+// get the fallback severity.
+func (a *AbyssWrapper) readLines(r io.Reader, fallback Severity, wg *sync.WaitGroup) {
+	defer wg.Done()
 
-		return nil
+	scanner := bufio.NewScanner(r)
+
+	for scanner.Scan() {
+		line := scanner.Text()
+		a.writeLine(fmt.Sprintf("%s [%s] %s", outputPrefix, ParseSeverity(line, fallback), line))
 	}
-	a.mutex.RUnlock()
-	a.mutex.Lock()
 
-	a.output = output
-	a.cmd = exec.Command(cfg.AbyssEnginePath) //nolint:gosec // is ok
-	a.cmd.Stdout = a
-	a.cmd.Stderr = a
-	a.cmd.Stdin = a
+	if err := scanner.Err(); err != nil {
+		a.writeLine(fmt.Sprintf("%s error reading engine's output: %v", outputPrefix, err))
+	}
+}
 
-	if err := a.cmd.Start(); err != nil {
-		a.mutex.Unlock()
-		return fmt.Errorf("error while running AbyssWrapper: %w", err)
+func (a *AbyssWrapper) writeLine(line string) {
+	a.outputMutex.Lock()
+	defer a.outputMutex.Unlock()
+
+	if a.output == nil {
+		return
 	}
 
-	a.running = true
+	// there is nothing we can do if output doesn't work
+	_, _ = a.output.Write([]byte(line + "\n"))
+}
+
+// wait waits for the process to exit and reports its exit code
+func (a *AbyssWrapper) wait(cmd *exec.Cmd, wg *sync.WaitGroup, done chan struct{}) {
+	// all the output must be read before calling Wait
+	wg.Wait()
+
+	err := cmd.Wait()
+	exitCode := cmd.ProcessState.ExitCode()
 
+	if exitCode == noExitCode {
+		a.writeLine(fmt.Sprintf("%s process terminated: %v", outputPrefix, err))
+	} else {
+		a.writeLine(fmt.Sprintf("%s process exited with code %d", outputPrefix, exitCode))
+	}
+
+	a.mutex.Lock()
+	a.running = false
+	a.exitCode = exitCode
 	a.mutex.Unlock()
 
-	go func() {
-		_ = a.cmd.Wait()
+	close(done)
+}
+
+// Stop asks the engine to exit (SIGTERM) and kills it, if it is still running after timeout.
+// Stop returns after the process exits.
+func (a *AbyssWrapper) Stop(timeout time.Duration) error {
+	a.mutex.RLock()
 
-		a.mutex.Lock()
-		a.running = false
-		a.mutex.Unlock()
-	}()
+	if !a.running {
+		a.mutex.RUnlock()
+
+		return nil
+	}
+
+	cmd, done := a.cmd, a.done
+	a.mutex.RUnlock()
+
+	// signals other than kill aren't supported on windows
+	if err := cmd.Process.Signal(syscall.SIGTERM); err == nil {
+		select {
+		case <-done:
+			return nil
+		case <-time.After(timeout):
+		}
+	}
+
+	if err := a.Kill(); err != nil {
+		select {
+		case <-done: // process exited in the meantime
+			return nil
+		default:
+			return err
+		}
+	}
+
+	<-done
 
 	return nil
 }
 
-// Kill stops abyss wrapper
+// Kill stops abyss wrapper immediately
 func (a *AbyssWrapper) Kill() error {
 	a.mutex.RLock()
 	defer a.mutex.RUnlock()
@@ -107,3 +209,12 @@ func (a *AbyssWrapper) IsRunning() bool {
 
 	return a.running
 }
+
+// ExitCode returns exit code of the last engine's process.
+// Returns -1 if the process is still running, never ran or was terminated by a signal.
+func (a *AbyssWrapper) ExitCode() int {
+	a.mutex.RLock()
+	defer a.mutex.RUnlock()
+
+	return a.exitCode
+}
@@ -0,0 +1,304 @@
+package abysswrapper
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"sync"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/gucio321/HellSpawner/pkg/abysswrapper/hotreload"
+)
+
+// fakeEngineEnv makes the test binary behave like a fake engine (see fakeEngine)
+const fakeEngineEnv = "HELLSPAWNER_FAKE_ENGINE"
+
+const testTimeout = 10 * time.Second
+
+func TestMain(m *testing.M) {
+	if mode := os.Getenv(fakeEngineEnv); mode != "" {
+		os.Exit(fakeEngine(mode))
+	}
+
+	os.Exit(m.Run())
+}
+
+// fakeEngine is the fake engine's main function
+func fakeEngine(mode string) int {
+	switch mode {
+	case "env":
+		fmt.Println("project=" + os.Getenv(EnvProject))
+		fmt.Println("content=" + os.Getenv(EnvContent))
+		fmt.Println("mpqs=" + os.Getenv(EnvMPQs))
+		fmt.Println("args=" + strings.Join(os.Args[1:], ","))
+	case "log":
+		fmt.Println("[WARN] low memory")
+		fmt.Println("plain message")
+		fmt.Fprintln(os.Stderr, "something failed")
+	case "exit":
+		return 3
+	case "term":
+		c := make(chan os.Signal, 1)
+		signal.Notify(c, syscall.SIGTERM)
+		fmt.Println("ready")
+		<-c
+		fmt.Println("bye")
+	case "hotreload":
+		server, err := hotreload.Listen(os.Getenv(EnvIPC), hotreload.EchoHandler(func(path string) {
+			fmt.Println("changed " + path)
+		}))
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+
+			return 1
+		}
+
+		defer server.Close()
+
+		c := make(chan os.Signal, 1)
+		signal.Notify(c, syscall.SIGTERM)
+		<-c
+	case "ignore-term":
+		signal.Ignore(syscall.SIGTERM)
+		fmt.Println("ready")
+		time.Sleep(testTimeout)
+	}
+
+	return 0
+}
+
+// syncBuffer is a buffer safe for concurrent use
+type syncBuffer struct {
+	buf   bytes.Buffer
+	mutex sync.Mutex
+}
+
+func (b *syncBuffer) Write(p []byte) (int, error) {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	return b.buf.Write(p)
+}
+
+func (b *syncBuffer) String() string {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	return b.buf.String()
+}
+
+func launchFakeEngine(t *testing.T, mode string, opts *LaunchOptions) (*AbyssWrapper, *syncBuffer) {
+	t.Helper()
+
+	if opts == nil {
+		opts = &LaunchOptions{}
+	}
+
+	opts.EnginePath = os.Args[0]
+	opts.Env = append(opts.Env, fakeEngineEnv+"="+mode)
+
+	output := &syncBuffer{}
+	wrapper := Create()
+
+	if err := wrapper.Launch(opts, output); err != nil {
+		t.Fatalf("error launching fake engine: %v", err)
+	}
+
+	return wrapper, output
+}
+
+func waitFor(t *testing.T, condition func() bool) {
+	t.Helper()
+
+	deadline := time.Now().Add(testTimeout)
+
+	for !condition() {
+		if time.Now().After(deadline) {
+			t.Fatal("timed out")
+		}
+
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+func Test_AbyssWrapper_PassesProject(t *testing.T) {
+	wrapper, output := launchFakeEngine(t, "env", &LaunchOptions{
+		ProjectPath: "/projects/mod.hsp",
+		ContentPath: "/projects/content",
+		MPQs:        []string{"a.mpq", "b.mpq"},
+		Args:        []string{"-x", "-y"},
+	})
+
+	waitFor(t, func() bool { return !wrapper.IsRunning() })
+
+	expected := []string{
+		"project=/projects/mod.hsp",
+		"content=/projects/content",
+		"mpqs=a.mpq" + string(os.PathListSeparator) + "b.mpq",
+		"args=-x,-y",
+	}
+
+	for _, e := range expected {
+		if !strings.Contains(output.String(), e) {
+			t.Errorf("output doesn't contain %q:\n%s", e, output.String())
+		}
+	}
+}
+
+func Test_AbyssWrapper_ParsesSeverity(t *testing.T) {
+	wrapper, output := launchFakeEngine(t, "log", nil)
+
+	waitFor(t, func() bool { return !wrapper.IsRunning() })
+
+	expected := []string{
+		"[WARNING] [WARN] low memory",
+		"[INFO] plain message",
+		"[ERROR] something failed",
+	}
+
+	for _, e := range expected {
+		if !strings.Contains(output.String(), e) {
+			t.Errorf("output doesn't contain %q:\n%s", e, output.String())
+		}
+	}
+}
+
+func Test_AbyssWrapper_ReportsExitCode(t *testing.T) {
+	wrapper, output := launchFakeEngine(t, "exit", nil)
+
+	waitFor(t, func() bool { return !wrapper.IsRunning() })
+
+	if code := wrapper.ExitCode(); code != 3 {
+		t.Fatalf("unexpected exit code %d", code)
+	}
+
+	if !strings.Contains(output.String(), "exited with code 3") {
+		t.Fatalf("exit code not reported:\n%s", output.String())
+	}
+}
+
+func Test_AbyssWrapper_StopsGracefully(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("SIGTERM isn't supported on windows")
+	}
+
+	wrapper, output := launchFakeEngine(t, "term", nil)
+
+	waitFor(t, func() bool { return strings.Contains(output.String(), "ready") })
+
+	if err := wrapper.Stop(testTimeout); err != nil {
+		t.Fatal(err)
+	}
+
+	if !strings.Contains(output.String(), "bye") {
+		t.Fatalf("engine didn't exit gracefully:\n%s", output.String())
+	}
+
+	if code := wrapper.ExitCode(); code != 0 {
+		t.Fatalf("unexpected exit code %d", code)
+	}
+}
+
+func Test_AbyssWrapper_AlreadyRunning(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("SIGTERM isn't supported on windows")
+	}
+
+	wrapper, output := launchFakeEngine(t, "term", nil)
+
+	defer func() {
+		if err := wrapper.Stop(testTimeout); err != nil {
+			t.Error(err)
+		}
+	}()
+
+	waitFor(t, func() bool { return strings.Contains(output.String(), "ready") })
+
+	opts := &LaunchOptions{EnginePath: os.Args[0]}
+
+	if err := wrapper.Launch(opts, output); !errors.Is(err, ErrAlreadyRunning) {
+		t.Fatalf("expected %v, got %v", ErrAlreadyRunning, err)
+	}
+}
+
+func Test_AbyssWrapper_KillsAfterTimeout(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("SIGTERM isn't supported on windows")
+	}
+
+	wrapper, output := launchFakeEngine(t, "ignore-term", nil)
+
+	waitFor(t, func() bool { return strings.Contains(output.String(), "ready") })
+
+	start := time.Now()
+
+	if err := wrapper.Stop(100 * time.Millisecond); err != nil {
+		t.Fatal(err)
+	}
+
+	if time.Since(start) >= testTimeout {
+		t.Fatal("engine wasn't killed after timeout")
+	}
+
+	if wrapper.IsRunning() {
+		t.Fatal("engine is still running")
+	}
+
+	if code := wrapper.ExitCode(); code != noExitCode {
+		t.Fatalf("unexpected exit code %d", code)
+	}
+}
+
+func Test_AbyssWrapper_NotifyFileChanged(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("SIGTERM isn't supported on windows")
+	}
+
+	dir := t.TempDir()
+
+	wrapper, output := launchFakeEngine(t, "hotreload", &LaunchOptions{
+		IPCSocket: filepath.Join(dir, "engine.sock"),
+	})
+
+	defer func() {
+		if err := wrapper.Stop(testTimeout); err != nil {
+			t.Error(err)
+		}
+	}()
+
+	const path = `data\global\ui\panel\invchar6.dc6`
+
+	// wrapper connects when the engine's server is up
+	waitFor(t, func() bool { return wrapper.NotifyFileChanged(path) == nil })
+
+	waitFor(t, func() bool {
+		return strings.Contains(output.String(), "changed "+path) &&
+			strings.Contains(output.String(), "status: reloaded "+path)
+	})
+}
+
+func Test_ParseSeverity(t *testing.T) {
+	tests := []struct {
+		line     string
+		expected Severity
+	}{
+		{"[ERROR] something", SeverityError},
+		{"2021/05/14 22:26:03 warn: something", SeverityWarning},
+		{"12:00 DBG something", SeverityDebug},
+		{"fatal error", SeverityFatal},
+		{"nothing special here error", SeverityInfo},
+	}
+
+	for _, tt := range tests {
+		if s := ParseSeverity(tt.line, SeverityInfo); s != tt.expected {
+			t.Errorf("%q: expected %s, got %s", tt.line, tt.expected, s)
+		}
+	}
+}
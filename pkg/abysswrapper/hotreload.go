@@ -0,0 +1,80 @@
+package abysswrapper
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/gucio321/HellSpawner/pkg/abysswrapper/hotreload"
+)
+
+const (
+	ipcDialTimeout   = time.Second
+	ipcRetryInterval = 500 * time.Millisecond
+)
+
+// connectIPC connects to the engine's hot reload server. As the server starts
+// together with the engine, connecting is retried until the engine exits.
+func (a *AbyssWrapper) connectIPC(path string, done chan struct{}) {
+	for {
+		client, err := hotreload.Dial(path, ipcDialTimeout, a.onIPCMessage)
+		if err != nil {
+			select {
+			case <-done:
+				return
+			case <-time.After(ipcRetryInterval):
+				continue
+			}
+		}
+
+		a.mutex.Lock()
+		a.ipc = client
+		a.mutex.Unlock()
+
+		select {
+		case <-done:
+			_ = client.Close()
+		case <-client.Done():
+		}
+
+		a.mutex.Lock()
+		a.ipc = nil
+		a.mutex.Unlock()
+
+		select {
+		case <-done:
+			return
+		default: // connection was lost, but engine still runs
+		}
+	}
+}
+
+func (a *AbyssWrapper) onIPCMessage(msg *hotreload.Message) {
+	switch msg.Type {
+	case hotreload.MessageLog:
+		a.writeLine(fmt.Sprintf("%s [%s] %s", outputPrefix, ParseSeverity(msg.Level, SeverityInfo), msg.Text))
+	case hotreload.MessageStatus:
+		a.writeLine(fmt.Sprintf("%s [%s] status: %s", outputPrefix, SeverityInfo, msg.Text))
+	}
+}
+
+// NotifyFileChanged tells the running engine, that a file at game path given was changed.
+// Does nothing if engine isn't running.
+func (a *AbyssWrapper) NotifyFileChanged(gamePath string) error {
+	a.mutex.RLock()
+	defer a.mutex.RUnlock()
+
+	if !a.running {
+		return nil
+	}
+
+	if a.ipc == nil {
+		return errors.New("engine isn't connected to hot reload channel")
+	}
+
+	if err := a.ipc.NotifyFileChanged(gamePath); err != nil {
+		return fmt.Errorf("error notifying engine: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,54 @@
+package hotreload
+
+import (
+	"fmt"
+	"net"
+	"time"
+)
+
+// Client is HellSpawner's side of the protocol
+type Client struct {
+	*conn
+	done chan struct{}
+}
+
+// Dial connects to the engine listening at path. Every message received from the
+// engine is passed to handler (from another goroutine).
+func Dial(path string, timeout time.Duration, handler func(msg *Message)) (*Client, error) {
+	c, err := net.DialTimeout(network, path, timeout)
+	if err != nil {
+		return nil, fmt.Errorf("error connecting to %s: %w", path, err)
+	}
+
+	result := &Client{
+		conn: newConn(c),
+		done: make(chan struct{}),
+	}
+
+	go func() {
+		// receiving ends when connection is closed - by any side
+		_ = result.receive(handler)
+
+		close(result.done)
+	}()
+
+	return result, nil
+}
+
+// NotifyFileChanged tells the engine, that file at game path given was changed
+func (c *Client) NotifyFileChanged(gamePath string) error {
+	return c.send(&Message{
+		Type: MessageFileChanged,
+		Path: gamePath,
+	})
+}
+
+// Done is closed, when connection is closed
+func (c *Client) Done() <-chan struct{} {
+	return c.done
+}
+
+// Close closes the connection
+func (c *Client) Close() error {
+	return c.close()
+}
@@ -0,0 +1,68 @@
+package hotreload
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"net"
+	"sync"
+)
+
+// network used by both sides
+const network = "unix"
+
+// conn is a connection exchanging JSON lines
+type conn struct {
+	conn    net.Conn
+	encoder *json.Encoder
+	mutex   sync.Mutex
+}
+
+func newConn(c net.Conn) *conn {
+	return &conn{
+		conn:    c,
+		encoder: json.NewEncoder(c),
+	}
+}
+
+// send writes a message as a single line
+func (c *conn) send(msg *Message) error {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	// json.Encoder terminates every value with a new line
+	if err := c.encoder.Encode(msg); err != nil {
+		return fmt.Errorf("error sending message: %w", err)
+	}
+
+	return nil
+}
+
+// receive calls handler for every message read until connection is closed.
+// Lines, which aren't valid messages, are skipped.
+func (c *conn) receive(handler func(msg *Message)) error {
+	scanner := bufio.NewScanner(c.conn)
+
+	for scanner.Scan() {
+		msg := &Message{}
+		if err := json.Unmarshal(scanner.Bytes(), msg); err != nil {
+			continue
+		}
+
+		handler(msg)
+	}
+
+	if err := scanner.Err(); err != nil {
+		return fmt.Errorf("error reading messages: %w", err)
+	}
+
+	return nil
+}
+
+func (c *conn) close() error {
+	if err := c.conn.Close(); err != nil {
+		return fmt.Errorf("error closing connection: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,10 @@
+// Package hotreload implements a protocol used to notify a running engine about
+// changes of project's files.
+//
+// The engine listens on a unix socket, which path is passed to it in HELLSPAWNER_IPC
+// environment variable. HellSpawner connects to it and both sides exchange messages
+// encoded as JSON - one message per line. HellSpawner sends MessageFileChanged, the engine
+// can send MessageLog and MessageStatus back.
+//
+// Server is a reference implementation of the engine's side.
+package hotreload
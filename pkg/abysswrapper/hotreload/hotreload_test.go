@@ -0,0 +1,119 @@
+package hotreload
+
+import (
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+)
+
+const testTimeout = 5 * time.Second
+
+func Test_Client_NotifyFileChanged(t *testing.T) {
+	dir := t.TempDir()
+
+	socket := filepath.Join(dir, "test.sock")
+	changed := make(chan string, 1)
+
+	server, err := Listen(socket, EchoHandler(func(path string) {
+		changed <- path
+	}))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	received := make([]*Message, 0)
+	receivedMutex := sync.Mutex{}
+	statusReceived := make(chan struct{})
+
+	client, err := Dial(socket, testTimeout, func(msg *Message) {
+		receivedMutex.Lock()
+		defer receivedMutex.Unlock()
+
+		received = append(received, msg)
+
+		if msg.Type == MessageStatus {
+			close(statusReceived)
+		}
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	const path = `data\global\ui\panel\invchar6.dc6`
+
+	if err := client.NotifyFileChanged(path); err != nil {
+		t.Fatal(err)
+	}
+
+	select {
+	case p := <-changed:
+		if p != path {
+			t.Fatalf("unexpected path %s", p)
+		}
+	case <-time.After(testTimeout):
+		t.Fatal("server didn't receive notification")
+	}
+
+	select {
+	case <-statusReceived:
+	case <-time.After(testTimeout):
+		t.Fatal("client didn't receive status")
+	}
+
+	receivedMutex.Lock()
+	if len(received) != 2 || received[0].Type != MessageLog || received[1].Text != "reloaded "+path {
+		t.Fatalf("unexpected messages received: %v", received)
+	}
+	receivedMutex.Unlock()
+
+	if err := server.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	select {
+	case <-client.Done():
+	case <-time.After(testTimeout):
+		t.Fatal("client wasn't disconnected after closing server")
+	}
+}
+
+func Test_Server_Close(t *testing.T) {
+	dir := t.TempDir()
+
+	socket := filepath.Join(dir, "test.sock")
+
+	server, err := Listen(socket, EchoHandler(nil))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// some of the connections are accepted while the server is being closed
+	const numClients = 10
+
+	for i := 0; i < numClients; i++ {
+		client, err := Dial(socket, testTimeout, func(*Message) {})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		t.Cleanup(func() {
+			_ = client.Close()
+		})
+	}
+
+	closed := make(chan error, 1)
+
+	go func() {
+		closed <- server.Close()
+	}()
+
+	select {
+	case err := <-closed:
+		if err != nil {
+			t.Fatal(err)
+		}
+	case <-time.After(testTimeout):
+		t.Fatal("closing server didn't return")
+	}
+}
@@ -0,0 +1,25 @@
+package hotreload
+
+// MessageType represents a type of message
+type MessageType string
+
+// Message types
+const (
+	// MessageFileChanged is sent to the engine, when file at Path was changed.
+	// Path is game-relative (e.g. data\global\ui\panel\invchar6.dc6)
+	MessageFileChanged MessageType = "fileChanged"
+	// MessageLog is sent by the engine. It contains a log message (Text) with Level
+	// (e.g. info, warning, error)
+	MessageLog MessageType = "log"
+	// MessageStatus is sent by the engine to report its status (Text), e.g. that
+	// a file was reloaded
+	MessageStatus MessageType = "status"
+)
+
+// Message is a single message of the protocol
+type Message struct {
+	Type  MessageType `json:"type"`
+	Path  string      `json:"path,omitempty"`
+	Level string      `json:"level,omitempty"`
+	Text  string      `json:"text,omitempty"`
+}
@@ -0,0 +1,152 @@
+package hotreload
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"sync"
+)
+
+// Handler handles a message received by the server.
+// Returned messages are sent back to the client.
+type Handler func(msg *Message) []*Message
+
+// Server is a reference implementation of the engine's side of the protocol.
+// It can be used as a stand-in of the engine in tests.
+type Server struct {
+	listener net.Listener
+	handler  Handler
+	conns    map[*conn]bool
+	closed   bool
+	mutex    sync.Mutex
+	wg       sync.WaitGroup
+}
+
+// Listen creates a server listening at path
+func Listen(path string, handler Handler) (*Server, error) {
+	// remove a stale socket
+	if err := os.Remove(path); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return nil, fmt.Errorf("error removing %s: %w", path, err)
+	}
+
+	listener, err := net.Listen(network, path)
+	if err != nil {
+		return nil, fmt.Errorf("error listening at %s: %w", path, err)
+	}
+
+	result := &Server{
+		listener: listener,
+		handler:  handler,
+		conns:    make(map[*conn]bool),
+	}
+
+	result.wg.Add(1)
+
+	go result.serve()
+
+	return result, nil
+}
+
+// EchoHandler returns a handler, which answers every MessageFileChanged with
+// a status message and calls onChange (if not nil) with its path.
+func EchoHandler(onChange func(path string)) Handler {
+	return func(msg *Message) []*Message {
+		if msg.Type != MessageFileChanged {
+			return nil
+		}
+
+		if onChange != nil {
+			onChange(msg.Path)
+		}
+
+		return []*Message{
+			{Type: MessageLog, Level: "info", Text: "reloading " + msg.Path},
+			{Type: MessageStatus, Text: "reloaded " + msg.Path},
+		}
+	}
+}
+
+func (s *Server) serve() {
+	defer s.wg.Done()
+
+	for {
+		c, err := s.listener.Accept()
+		if err != nil {
+			return
+		}
+
+		// the connection is registered under the same lock, under which Close
+		// marks the server closed, so every connection is closed by one of them
+		s.mutex.Lock()
+		if s.closed {
+			s.mutex.Unlock()
+
+			_ = c.Close()
+
+			return
+		}
+
+		hc := newConn(c)
+		s.conns[hc] = true
+		s.wg.Add(1)
+		s.mutex.Unlock()
+
+		go s.handleConn(hc)
+	}
+}
+
+func (s *Server) handleConn(c *conn) {
+	defer s.wg.Done()
+
+	_ = c.receive(func(msg *Message) {
+		for _, reply := range s.handler(msg) {
+			if err := c.send(reply); err != nil {
+				return
+			}
+		}
+	})
+
+	s.mutex.Lock()
+	delete(s.conns, c)
+	s.mutex.Unlock()
+
+	_ = c.close()
+}
+
+// Broadcast sends a message to all connected clients
+func (s *Server) Broadcast(msg *Message) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	for c := range s.conns {
+		if err := c.send(msg); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Close stops the server and closes all the connections
+func (s *Server) Close() error {
+	s.mutex.Lock()
+	s.closed = true
+	s.mutex.Unlock()
+
+	err := s.listener.Close()
+
+	s.mutex.Lock()
+	for c := range s.conns {
+		_ = c.close()
+	}
+	s.mutex.Unlock()
+
+	s.wg.Wait()
+
+	if err != nil {
+		return fmt.Errorf("error closing server: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,56 @@
+package abysswrapper
+
+import (
+	"os"
+	"strings"
+)
+
+// Environment variables passed to the engine
+const (
+	EnvProject = "HELLSPAWNER_PROJECT"
+	EnvContent = "HELLSPAWNER_CONTENT"
+	EnvMPQs    = "HELLSPAWNER_MPQS"
+	EnvLocale  = "HELLSPAWNER_LOCALE"
+	EnvIPC     = "HELLSPAWNER_IPC"
+)
+
+// LaunchOptions describes how to launch the engine
+type LaunchOptions struct {
+	// EnginePath is a path to the engine's executable
+	EnginePath string
+	// Args are passed to the engine as command line arguments
+	Args []string
+	// WorkDir is engine's working directory (current directory if empty)
+	WorkDir string
+	// Env contains additional environment variables in form KEY=value
+	Env []string
+
+	// ProjectPath is a path to the project file (.hsp)
+	ProjectPath string
+	// ContentPath is a path to project's content directory
+	ContentPath string
+	// MPQs are absolute paths to project's auxiliary MPQs in load order
+	MPQs []string
+	// Locale is a name of the locale used
+	Locale string
+	// IPCSocket is a path of hot reload socket (see hotreload package).
+	// Hot reload is disabled if empty.
+	IPCSocket string
+}
+
+// environment returns environment of the engine's process.
+// Project's data are passed via HELLSPAWNER_* variables
+// (MPQs are separated by os.PathListSeparator).
+func (o *LaunchOptions) environment() []string {
+	result := os.Environ()
+
+	result = append(result,
+		EnvProject+"="+o.ProjectPath,
+		EnvContent+"="+o.ContentPath,
+		EnvMPQs+"="+strings.Join(o.MPQs, string(os.PathListSeparator)),
+		EnvLocale+"="+o.Locale,
+		EnvIPC+"="+o.IPCSocket,
+	)
+
+	return append(result, o.Env...)
+}
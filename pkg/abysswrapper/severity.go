@@ -0,0 +1,74 @@
+package abysswrapper
+
+import (
+	"strings"
+)
+
+// Severity represents a severity of engine's log message
+type Severity int
+
+// Severities
+const (
+	SeverityDebug Severity = iota
+	SeverityInfo
+	SeverityWarning
+	SeverityError
+	SeverityFatal
+)
+
+// number of leading fields of a log line, which are searched for a severity
+// (e.g. `2021/05/14 22:26:03 [WARN] message`)
+const severityFields = 3
+
+// String returns severity's string
+func (s Severity) String() string {
+	table := map[Severity]string{
+		SeverityDebug:   "DEBUG",
+		SeverityInfo:    "INFO",
+		SeverityWarning: "WARNING",
+		SeverityError:   "ERROR",
+		SeverityFatal:   "FATAL",
+	}
+
+	val, found := table[s]
+	if !found {
+		return "UNKNOWN"
+	}
+
+	return val
+}
+
+// ParseSeverity looks for a severity marker (e.g. `[ERROR]`, `warn:` or `INF`) in the
+// beginning of a log line. If there isn't any, fallback is returned.
+func ParseSeverity(line string, fallback Severity) Severity {
+	table := map[string]Severity{
+		"trace":   SeverityDebug,
+		"trc":     SeverityDebug,
+		"debug":   SeverityDebug,
+		"dbg":     SeverityDebug,
+		"info":    SeverityInfo,
+		"inf":     SeverityInfo,
+		"warn":    SeverityWarning,
+		"warning": SeverityWarning,
+		"wrn":     SeverityWarning,
+		"error":   SeverityError,
+		"err":     SeverityError,
+		"fatal":   SeverityFatal,
+		"ftl":     SeverityFatal,
+		"panic":   SeverityFatal,
+	}
+
+	fields := strings.Fields(line)
+	if len(fields) > severityFields {
+		fields = fields[:severityFields]
+	}
+
+	for _, field := range fields {
+		field = strings.ToLower(strings.Trim(field, "[]():|"))
+		if severity, found := table[field]; found {
+			return severity
+		}
+	}
+
+	return fallback
+}
@@ -17,14 +17,23 @@ import (
 
 	"github.com/gucio321/HellSpawner/pkg/abysswrapper"
 	"github.com/gucio321/HellSpawner/pkg/common"
+	"github.com/gucio321/HellSpawner/pkg/common/hscommand"
 	"github.com/gucio321/HellSpawner/pkg/common/hsfiletypes"
 	"github.com/gucio321/HellSpawner/pkg/common/hsproject"
+	"github.com/gucio321/HellSpawner/pkg/common/hsshortcut"
+	"github.com/gucio321/HellSpawner/pkg/common/hswatcher"
+	"github.com/gucio321/HellSpawner/pkg/plugin"
+	"github.com/gucio321/HellSpawner/pkg/window/diffwindow"
 	"github.com/gucio321/HellSpawner/pkg/window/editor"
 	"github.com/gucio321/HellSpawner/pkg/window/popup/aboutdialog"
+	"github.com/gucio321/HellSpawner/pkg/window/popup/commandpalette"
+	"github.com/gucio321/HellSpawner/pkg/window/popup/newproject"
 	"github.com/gucio321/HellSpawner/pkg/window/popup/preferences"
 	"github.com/gucio321/HellSpawner/pkg/window/popup/projectproperties"
 	"github.com/gucio321/HellSpawner/pkg/window/toolwindow/console"
+	"github.com/gucio321/HellSpawner/pkg/window/toolwindow/mpqcompare"
 	"github.com/gucio321/HellSpawner/pkg/window/toolwindow/mpqexplorer"
+	"github.com/gucio321/HellSpawner/pkg/window/toolwindow/problems"
 	"github.com/gucio321/HellSpawner/pkg/window/toolwindow/projectexplorer"
 )
 
@@ -39,6 +48,10 @@ const (
 	mpqExplorerDefaultY      = 30
 	consoleDefaultX          = 10
 	consoleDefaultY          = 500
+	mpqCompareDefaultX       = 60
+	mpqCompareDefaultY       = 60
+	problemsDefaultX         = 10
+	problemsDefaultY         = 500
 
 	samplesPerSecond = 22050
 	sampleDuration   = time.Second / 10
@@ -73,27 +86,64 @@ type App struct {
 	project      *hsproject.Project
 	config       *config.Config
 	abyssWrapper *abysswrapper.AbyssWrapper
-	logFile      *os.File
+	// runningEngine is a name of the engine launched by abyssWrapper
+	runningEngine string
+	logFile       *os.File
 
 	aboutDialog             *aboutdialog.AboutDialog
+	commandPalette          *commandpalette.CommandPalette
 	preferencesDialog       *preferences.Dialog
 	projectPropertiesDialog *projectproperties.Dialog
+	newProjectDialog        *newproject.Dialog
 
 	projectExplorer *projectexplorer.ProjectExplorer
 	mpqExplorer     *mpqexplorer.MPQExplorer
 	console         *console.Console
+	mpqCompare      *mpqcompare.MPQCompare
+	problems        *problems.Problems
+	diffWindows     []*diffwindow.DiffWindow
 
 	editors            []editor.Editor
 	editorConstructors map[hsfiletypes.FileType]editorConstructor
+	plugins            []*plugin.Plugin
+	// pluginFileTypes are file types registered by plugins
+	pluginFileTypes []hsfiletypes.FileType
 
 	editorManagerMutex sync.RWMutex
 	focusedEditor      editor.Editor
 
+	watcher *hswatcher.Watcher
+	// fileChanges are changes found by the watcher, which weren't processed yet
+	fileChanges []hswatcher.Event
+	// editorsToReload are IDs of editors, which user decided to reload
+	editorsToReload []string
+	// reloadPrompts contains IDs of editors, for which user is asked to reload them
+	reloadPrompts    map[string]bool
+	fileChangesMutex sync.Mutex
+	pendingEditors   []*pendingEditor
+	// openedAs are file types chosen by the user for files (by their unique IDs) in "Open As" menu
+	openedAs map[string]hsfiletypes.FileType
+
 	fontFixed         *g.FontInfo
 	fontFixedSmall    *g.FontInfo
 	diabloBoldFont    *g.FontInfo
 	diabloRegularFont *g.FontInfo
 
+	shortcuts *hsshortcut.Registry
+	// globalShortcuts are shortcuts currently registered in the master window
+	globalShortcuts []g.WindowShortcut
+
+	scripting scripting
+
+	tabs editorTabs
+	// workspaceName is a name of the workspace to save
+	workspaceName string
+	// openFileCommands caches "Open File" commands of the palette; nil if they need to be listed again
+	openFileCommands []hscommand.Command
+
+	// selectProblemsTab makes problems tab of the static layout selected in the next frame
+	selectProblemsTab bool
+
 	showUsage   bool
 	justStarted bool
 }
@@ -105,21 +155,37 @@ func Create() (*App, error) {
 		editors:            make([]editor.Editor, 0),
 		editorConstructors: make(map[hsfiletypes.FileType]editorConstructor),
 		abyssWrapper:       abysswrapper.Create(),
+		reloadPrompts:      make(map[string]bool),
+		openedAs:           make(map[string]hsfiletypes.FileType),
+		tabs:               newEditorTabs(),
 		justStarted:        true,
 	}
 
-	if shouldTerminate := result.parseArgs(); shouldTerminate {
+	shouldTerminate, err := result.parseArgs()
+	if err != nil {
+		return nil, err
+	}
+
+	if shouldTerminate {
 		return nil, nil
 	}
 
-	result.config = config.Load(*result.Flags.optionalConfigPath)
+	result.config, err = config.Load(*result.Flags.optionalConfigPath)
+	if err != nil {
+		logErr("Could not load config: %v", err)
+	}
 
 	return result, nil
 }
 
 // Run runs an app instance
 func (a *App) Run() (err error) {
-	defer a.Quit() // force-close and save everything (in case of crash)
+	// force-close and save everything (in case of crash)
+	defer func() {
+		a.Quit()
+		a.stopWatching()
+		a.closePlugins()
+	}()
 
 	// setting up the logging here, as opposed to inside of app.setup(),
 	// because of the deferred call to logfile.Close()
@@ -168,6 +234,10 @@ func (a *App) render() {
 		}
 	}
 
+	a.processFileChanges()
+	a.applyToolWindowPlacements()
+	a.markFocusedEditorEdited()
+
 	switch a.config.ViewMode {
 	case config.ViewModeLegacy:
 		a.renderLegacy()
@@ -191,17 +261,25 @@ func (a *App) renderStatic() {
 		renderWnd(a.preferencesDialog),
 		renderWnd(a.aboutDialog),
 		renderWnd(a.projectPropertiesDialog),
+		renderWnd(a.newProjectDialog),
 		g.SplitLayout(g.DirectionVertical, &a.config.StaticLayout.ProjectSplit,
 			a.projectExplorer.GetLayout(),
 			g.SplitLayout(g.DirectionVertical, &a.config.StaticLayout.MPQSplit,
 				g.SplitLayout(g.DirectionHorizontal, &a.config.StaticLayout.ConsoleSplit,
 					a.renderStaticEditors(),
-					a.console.GetLayout(),
+					a.renderStaticBottomPanel(),
 				).SplitRefType(g.SplitRefProc),
 				a.mpqExplorer.GetLayout(),
 			).SplitRefType(g.SplitRefProc),
 		).SplitRefType(g.SplitRefProc),
 	)
+
+	// compare tool and diffs have no place in the static layout, so they float over it
+	if a.mpqCompare.IsVisible() {
+		a.mpqCompare.Build()
+	}
+
+	a.renderDiffWindows()
 }
 
 func logErr(fmtErr string, args ...interface{}) {
@@ -209,7 +287,9 @@ func logErr(fmtErr string, args ...interface{}) {
 	dialog.Message(fmtErr, args...).Error()
 }
 
-func (a *App) createEditor(path *common.PathEntry, state []byte, x, y, w, h float32) {
+// createEditor creates an editor for the path given. If editor uses a palette, it is resolved
+// from palette's game path (see resolvePalette).
+func (a *App) createEditor(path *common.PathEntry, palette string, state []byte, x, y, w, h float32) {
 	data, err := path.GetFileBytes()
 	if err != nil {
 		const fmtErr = "Could not load file: %v"
@@ -219,13 +299,16 @@ func (a *App) createEditor(path *common.PathEntry, state []byte, x, y, w, h floa
 		return
 	}
 
-	fileType, err := hsfiletypes.GetFileTypeFromExtension(filepath.Ext(path.FullPath), &data)
-	if err != nil {
-		const fmtErr = "Error reading file type: %v"
+	fileType, found := a.openedAs[path.GetUniqueID()]
+	if !found {
+		fileType, err = hsfiletypes.GetFileTypeFromExtension(filepath.Ext(path.FullPath), &data)
+		if err != nil {
+			const fmtErr = "Error reading file type: %v"
 
-		logErr(fmtErr, err)
+			logErr(fmtErr, err)
 
-		return
+			return
+		}
 	}
 
 	if a.editorConstructors[fileType] == nil {
@@ -236,7 +319,7 @@ func (a *App) createEditor(path *common.PathEntry, state []byte, x, y, w, h floa
 		return
 	}
 
-	editor, err := a.editorConstructors[fileType](a.config, path, state, &data, x, y, a.project)
+	newEditor, err := a.editorConstructors[fileType](a.config, path, state, &data, x, y, a.project)
 	if err != nil {
 		const fmtErr = "Error creating editor: %v"
 
@@ -245,11 +328,19 @@ func (a *App) createEditor(path *common.PathEntry, state []byte, x, y, w, h floa
 		return
 	}
 
-	editor.Size(w, h)
+	if pe, ok := newEditor.(editor.PaletteEditor); ok {
+		pe.SetPalette(a.resolvePalette(path, palette))
+	}
+
+	newEditor.OnSave(a.onFileSaved)
+	newEditor.SetShortcuts(a.shortcuts)
+	newEditor.Size(w, h)
 
-	a.editors = append(a.editors, editor)
-	editor.Show()
-	editor.BringToFront()
+	a.editors = append(a.editors, newEditor)
+	a.tabs.panes[newEditor.GetID()] = a.tabs.activePane
+
+	newEditor.Show()
+	a.focusEditor(newEditor)
 }
 
 func (a *App) openEditor(path *common.PathEntry) {
@@ -258,7 +349,7 @@ func (a *App) openEditor(path *common.PathEntry) {
 	uniqueID := path.GetUniqueID()
 	for idx := range a.editors {
 		if a.editors[idx].GetID() == uniqueID {
-			a.editors[idx].BringToFront()
+			a.focusEditor(a.editors[idx])
 			a.editorManagerMutex.RUnlock()
 
 			return
@@ -275,7 +366,7 @@ func (a *App) openEditor(path *common.PathEntry) {
 	// width and height aren't saved, so we give 0 and
 	// editors without AutoResize flag sets w, h to default
 	a.editorManagerMutex.Lock()
-	a.createEditor(path, nil, editorWindowDefaultX+basePos.X, editorWindowDefaultY+basePos.Y, 0, 0)
+	a.createEditor(path, "", nil, editorWindowDefaultX+basePos.X, editorWindowDefaultY+basePos.Y, 0, 0)
 	a.editorManagerMutex.Unlock()
 }
 
@@ -291,6 +382,7 @@ func (a *App) loadProjectFromFile(file string) error {
 	}
 
 	a.project = project
+	a.openFileCommands = nil
 	a.config.AddToRecentProjects(file)
 	a.updateWindowTitle()
 
@@ -301,8 +393,11 @@ func (a *App) loadProjectFromFile(file string) error {
 
 	a.projectExplorer.SetProject(a.project)
 	a.mpqExplorer.SetProject(a.project)
+	a.mpqCompare.SetProject(a.project)
+	a.problems.SetProject(a.project)
 
 	a.CloseAllOpenWindows()
+	a.startWatching()
 
 	if state, ok := a.config.ProjectStates[a.project.GetProjectFilePath()]; ok {
 		a.RestoreAppState(state)
@@ -327,6 +422,23 @@ func (a *App) toggleMPQExplorer() {
 	a.mpqExplorer.ToggleVisibility()
 }
 
+func (a *App) toggleMPQCompare() {
+	a.mpqCompare.ToggleVisibility()
+}
+
+func (a *App) toggleProblems() {
+	a.problems.ToggleVisibility()
+}
+
+// onValidateProjectClicked shows the problems window and validates the project
+func (a *App) onValidateProjectClicked() {
+	a.problems.Show()
+	a.problems.BringToFront()
+	a.problems.Validate()
+
+	a.selectProblemsTab = true
+}
+
 func (a *App) onProjectPropertiesChanged(project *hsproject.Project) {
 	a.project = project
 	if err := a.project.Save(); err != nil {
@@ -334,6 +446,8 @@ func (a *App) onProjectPropertiesChanged(project *hsproject.Project) {
 	}
 
 	a.mpqExplorer.SetProject(a.project)
+	a.mpqCompare.SetProject(a.project)
+	a.problems.SetProject(a.project)
 	a.updateWindowTitle()
 
 	if err := a.reloadAuxiliaryMPQs(); err != nil {
@@ -347,6 +461,9 @@ func (a *App) onPreferencesChanged(cfg *config.Config) {
 		logErr("after changing preferences, %s", err)
 	}
 
+	a.setupShortcuts()
+	a.registerGlobalKeyboardShortcuts()
+
 	if a.project == nil {
 		return
 	}
@@ -361,11 +478,24 @@ func (a *App) reloadAuxiliaryMPQs() error {
 		return fmt.Errorf("could not reload aux mpq's in project, %w", err)
 	}
 
+	a.openFileCommands = nil
 	a.mpqExplorer.Reset()
+	a.mpqCompare.Reset()
 
 	return nil
 }
 
+// onFilesDropped imports files dropped onto the window into the project
+func (a *App) onFilesDropped(paths []string) {
+	if a.project == nil {
+		return
+	}
+
+	a.projectExplorer.Show()
+	a.projectExplorer.ImportFiles(paths)
+	a.ignoreFileChanges()
+}
+
 func (a *App) toggleProjectExplorer() {
 	a.projectExplorer.ToggleVisibility()
 }
@@ -382,8 +512,10 @@ func (a *App) closeActiveEditor() {
 
 func (a *App) closePopups() {
 	a.projectPropertiesDialog.Cleanup()
+	a.newProjectDialog.Cleanup()
 	a.aboutDialog.Cleanup()
 	a.preferencesDialog.Cleanup()
+	a.commandPalette.Cleanup()
 }
 
 func (a *App) toggleConsole() {
@@ -395,7 +527,12 @@ func (a *App) CloseAllOpenWindows() {
 	a.closePopups()
 	a.projectExplorer.Cleanup()
 	a.mpqExplorer.Cleanup()
+	a.mpqCompare.Cleanup()
+	a.problems.Cleanup()
 	a.focusedEditor = nil
+	a.pendingEditors = nil
+	a.openedAs = make(map[string]hsfiletypes.FileType)
+	a.diffWindows = nil
 
 	for _, editor := range a.editors {
 		editor.Cleanup()
@@ -421,7 +558,7 @@ func (a *App) Save() {
 // Quit quits the app
 func (a *App) Quit() {
 	if a.abyssWrapper.IsRunning() {
-		_ = a.abyssWrapper.Kill()
+		_ = a.abyssWrapper.Stop(abysswrapper.DefaultStopTimeout)
 	}
 
 	a.Save()
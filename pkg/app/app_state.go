@@ -7,7 +7,7 @@ import (
 	state "github.com/gucio321/HellSpawner/pkg/app/state"
 
 	"github.com/gucio321/HellSpawner/pkg/common"
-	"github.com/gucio321/HellSpawner/pkg/window/toolwindow"
+	"github.com/gucio321/HellSpawner/pkg/window/editor"
 )
 
 // State creates a new app state
@@ -18,40 +18,27 @@ func (a *App) State() state.AppState {
 		ToolWindows:   []state.ToolWindowState{},
 	}
 
-	for _, editor := range a.editors {
-		appState.EditorWindows = append(appState.EditorWindows, editor.State())
+	for _, e := range a.editors {
+		editorState := e.State()
+
+		if pe, ok := e.(editor.PaletteEditor); ok {
+			editorState.Palette = a.paletteGamePath(pe)
+		}
+
+		appState.EditorWindows = append(appState.EditorWindows, editorState)
 	}
 
-	appState.ToolWindows = append(
-		appState.ToolWindows,
-		a.mpqExplorer.State(),
-		a.projectExplorer.State(),
-		a.console.State(),
-	)
+	for _, tool := range a.toolWindows() {
+		appState.ToolWindows = append(appState.ToolWindows, tool.State())
+	}
 
 	return appState
 }
 
-// RestoreAppState restores an app state
+// RestoreAppState restores an app state. Editors are created by the render loop (see openPendingEditors),
+// because they modify app's editor list and tabs.
 func (a *App) RestoreAppState(appState state.AppState) {
-	for _, toolState := range appState.ToolWindows {
-		var tool toolwindow.ToolWindow
-
-		switch toolState.Type {
-		case state.ToolWindowTypeConsole:
-			tool = a.console
-		case state.ToolWindowTypeMPQExplorer:
-			tool = a.mpqExplorer
-		case state.ToolWindowTypeProjectExplorer:
-			tool = a.projectExplorer
-		default:
-			continue
-		}
-
-		tool.Pos(toolState.PosX, toolState.PosY)
-		tool.SetVisible(toolState.Visible)
-		tool.Size(toolState.Width, toolState.Height)
-	}
+	a.restoreToolWindows(appState.ToolWindows)
 
 	for _, editorState := range appState.EditorWindows {
 		var path common.PathEntry
@@ -62,6 +49,40 @@ func (a *App) RestoreAppState(appState state.AppState) {
 			continue
 		}
 
-		go a.createEditor(&path, editorState.Encoded, editorState.PosX, editorState.PosY, editorState.Width, editorState.Height)
+		a.pendingEditors = append(a.pendingEditors, &pendingEditor{
+			path:    &path,
+			palette: editorState.Palette,
+			state:   editorState,
+		})
 	}
 }
+
+// paletteGamePath returns a game path of editor's palette or state.NoPalette if editor doesn't use any
+func (a *App) paletteGamePath(pe editor.PaletteEditor) string {
+	palette := pe.Palette()
+	if palette == nil {
+		return state.NoPalette
+	}
+
+	return a.project.EntryGamePath(palette)
+}
+
+// resolvePalette returns palette for the file given. Palette is a game path of the palette,
+// state.NoPalette for no palette or empty string for a palette resolved using project's palette rules.
+// It reads project's MPQs, which aren't safe for concurrent use, so that it is called on the UI thread only.
+func (a *App) resolvePalette(path *common.PathEntry, palette string) *common.PathEntry {
+	switch palette {
+	case "":
+		return a.project.ResolvePalette(path)
+	case state.NoPalette:
+		return nil
+	}
+
+	if entry := a.project.GetFileFromGamePath(palette); entry != nil {
+		return entry
+	}
+
+	log.Printf("palette %s not found, using the default one", palette)
+
+	return a.project.ResolvePalette(path)
+}
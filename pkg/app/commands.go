@@ -0,0 +1,205 @@
+package app
+
+import (
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/gucio321/HellSpawner/pkg/app/config"
+	"github.com/gucio321/HellSpawner/pkg/common"
+	"github.com/gucio321/HellSpawner/pkg/common/hscommand"
+	"github.com/gucio321/HellSpawner/pkg/common/hsfiletypes"
+	"github.com/gucio321/HellSpawner/pkg/window"
+)
+
+// command categories
+const (
+	commandsFile      = "File"
+	commandsNewFile   = "New File"
+	commandsOpenFile  = "Open File"
+	commandsRecent    = "Open Recent"
+	commandsView      = "View"
+	commandsWorkspace = "Workspace"
+	commandsProject   = "Project"
+	commandsHelp      = "Help"
+)
+
+// newFileTypes are file types, which may be created from the command palette
+var newFileTypes = []hsfiletypes.FileType{
+	hsfiletypes.FileTypeFont,
+	hsfiletypes.FileTypeTBLFontTable,
+	hsfiletypes.FileTypeTBLStringTable,
+	hsfiletypes.FileTypeAnimationData,
+	hsfiletypes.FileTypeCOF,
+	hsfiletypes.FileTypePalette,
+	hsfiletypes.FileTypePL2,
+	hsfiletypes.FileTypeDS1,
+	hsfiletypes.FileTypeDT1,
+}
+
+func (a *App) onCommandPaletteClicked() {
+	a.commandPalette.Show(a.paletteCommands())
+}
+
+// actionCommand returns a command of the action given, labeled with its shortcut
+func (a *App) actionCommand(category, name, actionID string, run func()) hscommand.Command {
+	return hscommand.Command{
+		Category: category,
+		Name:     name,
+		Shortcut: a.shortcuts.Label(actionID),
+		Run:      run,
+	}
+}
+
+// paletteCommands returns all the commands available in the current state of the app
+func (a *App) paletteCommands() []hscommand.Command {
+	result := []hscommand.Command{
+		a.actionCommand(commandsFile, "New Project...", actionNewProject, a.onNewProjectClicked),
+		a.actionCommand(commandsFile, "Open Project...", actionOpenProject, a.onOpenProjectClicked),
+		a.actionCommand(commandsFile, "Preferences...", actionPreferences, a.onFilePreferencesClicked),
+		a.actionCommand(commandsFile, "Exit", actionExit, a.onExitClicked),
+		a.actionCommand(commandsView, "Toggle Console", actionConsole, a.toggleConsole),
+		{Category: commandsView, Name: "Legacy View Mode", Run: func() { a.config.ViewMode = config.ViewModeLegacy }},
+		{Category: commandsView, Name: "Static View Mode", Run: func() { a.config.ViewMode = config.ViewModeStatic }},
+		a.actionCommand(commandsHelp, "About HellSpawner...", actionAbout, a.onHelpAboutClicked),
+		{Category: commandsHelp, Name: "Report Bug on GitHub", Run: a.onReportBugClicked},
+	}
+
+	for _, path := range a.config.RecentProjects {
+		result = append(result, hscommand.Command{Category: commandsRecent, Name: path, Run: func() {
+			if err := a.loadProjectFromFile(path); err != nil {
+				logErr("could not open recent file %s", err)
+			}
+		}})
+	}
+
+	for idx := range a.config.Workspaces {
+		workspace := &a.config.Workspaces[idx]
+		result = append(result, hscommand.Command{Category: commandsWorkspace, Name: workspace.Name, Run: func() {
+			a.applyWorkspace(workspace)
+		}})
+	}
+
+	if a.project != nil {
+		result = append(result, a.projectCommands()...)
+		result = append(result, a.scriptCommands()...)
+	}
+
+	result = append(result, a.editorCommands()...)
+
+	if a.project != nil {
+		result = append(result, a.listOpenFileCommands()...)
+	}
+
+	return result
+}
+
+func (a *App) projectCommands() []hscommand.Command {
+	result := []hscommand.Command{
+		a.actionCommand(commandsFile, "Save Project", actionSave, a.Save),
+		{Category: commandsFile, Name: "Close Project", Run: a.onCloseProjectClicked},
+		a.actionCommand(commandsView, "Toggle Project Explorer", actionProjectExplorer, a.toggleProjectExplorer),
+		a.actionCommand(commandsView, "Toggle MPQ Explorer", actionMPQExplorer, a.toggleMPQExplorer),
+		{Category: commandsView, Name: "Toggle MPQ Compare", Run: a.toggleMPQCompare},
+		{Category: commandsView, Name: "Toggle Problems", Run: a.toggleProblems},
+		{Category: commandsProject, Name: "Properties...", Run: a.onProjectPropertiesClicked},
+		{Category: commandsProject, Name: "Validate Project", Run: a.onValidateProjectClicked},
+		{Category: commandsProject, Name: "Export MPQ...", Run: a.onProjectExportMPQClicked},
+	}
+
+	const abyssEngine = "Abyss Engine"
+
+	if a.abyssWrapper.IsRunning() {
+		result = append(result, hscommand.Command{
+			Category: commandsProject,
+			Name:     "Stop " + a.runningEngine,
+			Run:      func() { a.onAbyssEngineRunClicked(a.runningEngine) },
+		})
+	} else {
+		if a.config.AbyssEnginePath != "" {
+			result = append(result, hscommand.Command{
+				Category: commandsProject,
+				Name:     "Run in " + abyssEngine,
+				Run:      func() { a.onAbyssEngineRunClicked(abyssEngine) },
+			})
+		}
+
+		for idx := range a.project.LaunchProfiles {
+			profile := &a.project.LaunchProfiles[idx]
+			result = append(result, hscommand.Command{
+				Category: commandsProject,
+				Name:     "Run in " + profile.Name,
+				Run:      func() { a.onLaunchProfileClicked(profile) },
+			})
+		}
+	}
+
+	root := &common.PathEntry{
+		FullPath:    a.project.GetProjectFileContentPath(),
+		IsDirectory: true,
+		Source:      common.PathEntrySourceProject,
+	}
+
+	fileTypes := make([]hsfiletypes.FileType, 0, len(newFileTypes)+len(a.pluginFileTypes))
+	fileTypes = append(fileTypes, newFileTypes...)
+	fileTypes = append(fileTypes, a.creatableFileTypes(a.pluginFileTypes)...)
+
+	for _, fileType := range fileTypes {
+		name := fmt.Sprintf("%s (%s)", fileType, fileType.FileExtension())
+		result = append(result, hscommand.Command{Category: commandsNewFile, Name: name, Run: func() {
+			if err := a.project.CreateNewFile(fileType, root); err != nil {
+				log.Print(err)
+			}
+		}})
+	}
+
+	return result
+}
+
+// editorCommands returns commands of the focused editor
+func (a *App) editorCommands() []hscommand.Command {
+	e := a.focusedEditor
+	if e == nil || !e.IsVisible() {
+		return nil
+	}
+
+	// hidden part of the title (after ##) would be scored by the fuzzy matcher
+	category, _, _ := strings.Cut(e.GetWindowTitle(), "##")
+
+	result := []hscommand.Command{
+		a.actionCommand(category, "Save", actionSave, e.Save),
+		a.actionCommand(category, "Close", actionCloseEditor, func() { e.SetVisible(false) }),
+	}
+
+	if provider, ok := e.(window.CommandProvider); ok {
+		result = append(result, provider.Commands()...)
+	}
+
+	return result
+}
+
+// listOpenFileCommands returns commands opening files from the project and its MPQs.
+// Listing MPQs is expensive, so commands are cached until project's files change.
+func (a *App) listOpenFileCommands() []hscommand.Command {
+	if a.openFileCommands == nil {
+		a.openFileCommands = a.makeOpenFileCommands()
+	}
+
+	return a.openFileCommands
+}
+
+func (a *App) makeOpenFileCommands() []hscommand.Command {
+	files := a.project.FindFiles(func(string) bool { return true })
+	result := make([]hscommand.Command, len(files))
+
+	for idx, file := range files {
+		name := a.project.EntryGamePath(file)
+		if file.Source == common.PathEntrySourceMPQ {
+			name += " (MPQ)"
+		}
+
+		result[idx] = hscommand.Command{Category: commandsOpenFile, Name: name, Run: func() { a.openEditor(file) }}
+	}
+
+	return result
+}
@@ -23,6 +23,8 @@ const (
 
 const (
 	newFileMode = 0o644
+	// backupSuffix is appended to the path of a config, which couldn't be loaded
+	backupSuffix = ".bak"
 )
 
 const (
@@ -46,12 +48,24 @@ type Config struct {
 	BGColor                 color.RGBA                `json:"bgColor"`
 	ViewMode                ViewMode
 	StaticLayout            StaticLayout
+	Workspaces              []Workspace `json:"workspaces"`
+	ActiveWorkspace         string      `json:"activeWorkspace"`
+	// Shortcuts are user's keyboard shortcuts by action IDs (overriding the default ones)
+	Shortcuts map[string]string `json:"shortcuts"`
+	// PluginsPath is a directory of external plugins
+	PluginsPath string `json:"pluginsPath"`
+	// readOnly is true if the existing config couldn't be loaded nor backed up,
+	// so that saving defaults would lose user's settings
+	readOnly bool
 }
 
 type StaticLayout struct {
 	ProjectSplit float32
 	MPQSplit     float32
 	ConsoleSplit float32
+	// EditorSplit divides editors area, when SplitEditors is true
+	EditorSplit  float32
+	SplitEditors bool
 }
 
 // GetConfigPath returns default config path
@@ -65,31 +79,38 @@ func GetConfigPath() string {
 }
 
 func generateDefaultConfig(path string) *Config {
-	result := &Config{
+	result := defaultConfig(path)
+
+	if err := result.Save(); err != nil {
+		log.Printf("filed to save config: %s", err)
+	}
+
+	return result
+}
+
+func defaultConfig(path string) *Config {
+	return &Config{
 		Path:                    path,
 		RecentProjects:          []string{},
 		OpenMostRecentOnStartup: true,
 		ProjectStates:           make(map[string]state.AppState),
 		LoggingToFile:           false,
 		LogFilePath:             filepath.Join(filepath.Dir(path), "output.log"),
+		PluginsPath:             filepath.Join(filepath.Dir(path), "plugins"),
 		Locale:                  enum.LocaleEnglish,
 		BGColor:                 hsutil.Color(DefaultBGColor),
 		StaticLayout: StaticLayout{
 			ProjectSplit: projectExplorerDefaultW,
 			MPQSplit:     mpqExplorerDefaultW,
 			ConsoleSplit: 0.8,
+			EditorSplit:  0.5,
 		},
 	}
-
-	if err := result.Save(); err != nil {
-		log.Printf("filed to save config: %s", err)
-	}
-
-	return result
 }
 
-// Load loads config
-func Load(optionalPath string) *Config {
+// Load loads config. If the config exists, but cannot be loaded, default config and an error are returned.
+// The config is backed up in that case, so that saving the defaults doesn't lose user's settings.
+func Load(optionalPath string) (*Config, error) {
 	var configFile string
 	if optionalPath == "" {
 		configFile = GetConfigPath()
@@ -98,7 +119,7 @@ func Load(optionalPath string) *Config {
 	}
 
 	if _, err := os.Stat(configFile); os.IsNotExist(err) {
-		return generateDefaultConfig(configFile)
+		return generateDefaultConfig(configFile), nil
 	}
 
 	var err error
@@ -106,19 +127,42 @@ func Load(optionalPath string) *Config {
 	var data []byte
 
 	if data, err = os.ReadFile(filepath.Clean(configFile)); err != nil {
-		return generateDefaultConfig(configFile)
+		return loadFailed(configFile, fmt.Errorf("cannot read config %s: %w", configFile, err))
 	}
 
-	result := generateDefaultConfig(configFile)
+	result := defaultConfig(configFile)
 	if err = json.Unmarshal(data, &result); err != nil {
-		return generateDefaultConfig(configFile)
+		return loadFailed(configFile, fmt.Errorf("cannot parse config %s: %w", configFile, err))
 	}
 
-	return result
+	return result, nil
+}
+
+// loadFailed backs up the config, which couldn't be loaded, and returns the default one
+func loadFailed(path string, loadErr error) (*Config, error) {
+	result := defaultConfig(path)
+	backup := path + backupSuffix
+
+	data, err := os.ReadFile(filepath.Clean(path))
+	if err == nil {
+		err = os.WriteFile(backup, data, os.FileMode(newFileMode))
+	}
+
+	if err != nil {
+		result.readOnly = true
+
+		return result, fmt.Errorf("%w (using defaults, which won't be saved, as backing up failed: %v)", loadErr, err)
+	}
+
+	return result, fmt.Errorf("%w (using defaults, the config was backed up to %s)", loadErr, backup)
 }
 
 // Save saves a new config
 func (c *Config) Save() error {
+	if c.readOnly {
+		return fmt.Errorf("config %s couldn't be loaded, so it isn't overwritten", c.Path)
+	}
+
 	var err error
 
 	var data []byte
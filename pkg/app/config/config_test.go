@@ -1,6 +1,7 @@
 package config
 
 import (
+	"os"
 	"path/filepath"
 	"testing"
 )
@@ -74,3 +75,29 @@ func Test_Config_GetAuxMPQs(t *testing.T) {
 		}
 	}
 }
+
+func Test_Load_Invalid(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.json")
+	invalid := []byte(`{"recentProjects": [`)
+
+	if err := os.WriteFile(path, invalid, 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	config, err := Load(path)
+	if err == nil {
+		t.Fatal("expected error on loading invalid config")
+	}
+
+	if config == nil || len(config.RecentProjects) != 0 {
+		t.Fatalf("expected default config, got %+v", config)
+	}
+
+	if backup, err := os.ReadFile(path + backupSuffix); err != nil || string(backup) != string(invalid) {
+		t.Errorf("config wasn't backed up (%v)", err)
+	}
+
+	if data, err := os.ReadFile(path); err != nil || string(data) != string(invalid) {
+		t.Errorf("config was overwritten on load (%v)", err)
+	}
+}
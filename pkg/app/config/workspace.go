@@ -0,0 +1,100 @@
+package config
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/gucio321/HellSpawner/pkg/app/state"
+)
+
+// Workspace is a named layout of the application. Unlike state.AppState,
+// workspaces aren't bound to projects.
+type Workspace struct {
+	Name         string                  `json:"name"`
+	ViewMode     ViewMode                `json:"viewMode"`
+	StaticLayout StaticLayout            `json:"staticLayout"`
+	ToolWindows  []state.ToolWindowState `json:"toolWindows"`
+}
+
+// LoadWorkspace loads a workspace exported to the file given
+func LoadWorkspace(path string) (*Workspace, error) {
+	data, err := os.ReadFile(filepath.Clean(path))
+	if err != nil {
+		return nil, fmt.Errorf("cannot read workspace %s: %w", path, err)
+	}
+
+	result := &Workspace{}
+	if err := json.Unmarshal(data, result); err != nil {
+		return nil, fmt.Errorf("cannot unmarshal workspace %s: %w", path, err)
+	}
+
+	if strings.TrimSpace(result.Name) == "" {
+		return nil, fmt.Errorf("workspace %s has no name", path)
+	}
+
+	return result, nil
+}
+
+// Export writes the workspace to the file given
+func (w *Workspace) Export(path string) error {
+	data, err := json.MarshalIndent(w, "", "   ")
+	if err != nil {
+		return fmt.Errorf("cannot marshal workspace: %w", err)
+	}
+
+	if err := os.WriteFile(path, data, os.FileMode(newFileMode)); err != nil {
+		return fmt.Errorf("cannot write workspace to %s: %w", path, err)
+	}
+
+	return nil
+}
+
+// GetWorkspace returns a workspace of the name given (names are case-insensitive)
+func (c *Config) GetWorkspace(name string) (*Workspace, bool) {
+	for idx := range c.Workspaces {
+		if strings.EqualFold(c.Workspaces[idx].Name, name) {
+			return &c.Workspaces[idx], true
+		}
+	}
+
+	return nil, false
+}
+
+// SetWorkspace adds the workspace or replaces the one of the same name, and saves the config
+func (c *Config) SetWorkspace(workspace Workspace) error {
+	workspace.Name = strings.TrimSpace(workspace.Name)
+	if workspace.Name == "" {
+		return errors.New("workspace's name cannot be empty")
+	}
+
+	if existing, found := c.GetWorkspace(workspace.Name); found {
+		*existing = workspace
+	} else {
+		c.Workspaces = append(c.Workspaces, workspace)
+	}
+
+	return c.Save()
+}
+
+// RemoveWorkspace removes the workspace of the name given and saves the config
+func (c *Config) RemoveWorkspace(name string) error {
+	for idx := range c.Workspaces {
+		if !strings.EqualFold(c.Workspaces[idx].Name, name) {
+			continue
+		}
+
+		c.Workspaces = append(c.Workspaces[:idx], c.Workspaces[idx+1:]...)
+
+		if strings.EqualFold(c.ActiveWorkspace, name) {
+			c.ActiveWorkspace = ""
+		}
+
+		return c.Save()
+	}
+
+	return nil
+}
@@ -0,0 +1,77 @@
+package config
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/gucio321/HellSpawner/pkg/app/state"
+)
+
+func Test_Config_Workspaces(t *testing.T) {
+	dir := t.TempDir()
+
+	config := generateDefaultConfig(filepath.Join(dir, "config.json"))
+
+	mapEditing := Workspace{
+		Name:     "Map editing",
+		ViewMode: ViewModeStatic,
+		ToolWindows: []state.ToolWindowState{
+			{Type: state.ToolWindowTypeProjectExplorer, WindowState: state.WindowState{Visible: true, PosX: 10}},
+		},
+	}
+
+	if err := config.SetWorkspace(mapEditing); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := config.SetWorkspace(Workspace{Name: "Translation"}); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := config.SetWorkspace(Workspace{Name: " "}); err == nil {
+		t.Error("workspace without a name shouldn't be added")
+	}
+
+	// workspace of the same name is replaced
+	mapEditing.Name = "map editing"
+	mapEditing.StaticLayout.SplitEditors = true
+
+	if err := config.SetWorkspace(mapEditing); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(config.Workspaces) != 2 {
+		t.Fatalf("unexpected workspaces %v", config.Workspaces)
+	}
+
+	workspace, found := config.GetWorkspace("MAP EDITING")
+	if !found || !workspace.StaticLayout.SplitEditors {
+		t.Fatalf("unexpected workspace %v", workspace)
+	}
+
+	path := filepath.Join(dir, "workspace.json")
+
+	if err := workspace.Export(path); err != nil {
+		t.Fatal(err)
+	}
+
+	imported, err := LoadWorkspace(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if imported.Name != workspace.Name || imported.ViewMode != ViewModeStatic ||
+		len(imported.ToolWindows) != 1 || imported.ToolWindows[0] != workspace.ToolWindows[0] {
+		t.Errorf("unexpected imported workspace %v", imported)
+	}
+
+	config.ActiveWorkspace = "Translation"
+
+	if err := config.RemoveWorkspace("translation"); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(config.Workspaces) != 1 || config.ActiveWorkspace != "" {
+		t.Errorf("unexpected workspaces after removal %v (active %q)", config.Workspaces, config.ActiveWorkspace)
+	}
+}
@@ -0,0 +1,248 @@
+package app
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/OpenDiablo2/OpenDiablo2/d2common/d2fileformats/d2mpq"
+
+	"github.com/gucio321/HellSpawner/pkg/common/hsdiff"
+	"github.com/gucio321/HellSpawner/pkg/window/diffwindow"
+	"github.com/gucio321/HellSpawner/pkg/window/editor"
+)
+
+const (
+	diffWindowDefaultX = 100
+	diffWindowDefaultY = 100
+)
+
+// sourceFileDiff is a changed file of compared directories or archives
+type sourceFileDiff struct {
+	hsdiff.FileChange
+	Diff *hsdiff.FileDiff `json:"diff,omitempty"`
+}
+
+// sourcesDiff is a result of comparison of directories or archives
+type sourcesDiff struct {
+	A     string           `json:"a"`
+	B     string           `json:"b"`
+	Files []sourceFileDiff `json:"files"`
+}
+
+// runDiff compares two files, directories or MPQ archives given in command line
+// and writes the result to the standard output as JSON
+func runDiff(args []string) error {
+	const numArgs = 2
+
+	if len(args) != numArgs {
+		return errors.New("diff needs two files, directories or MPQ archives to compare")
+	}
+
+	var result any
+
+	infoA, errA := os.Stat(args[0])
+	infoB, errB := os.Stat(args[1])
+
+	switch {
+	case errA != nil:
+		return fmt.Errorf("cannot compare %s: %w", args[0], errA)
+	case errB != nil:
+		return fmt.Errorf("cannot compare %s: %w", args[1], errB)
+	case isDiffSource(args[0], infoA) || isDiffSource(args[1], infoB):
+		diff, err := diffSources(args[0], args[1])
+		if err != nil {
+			return err
+		}
+
+		result = diff
+	default:
+		diff, err := diffFiles(args[0], args[1])
+		if err != nil {
+			return err
+		}
+
+		result = diff
+	}
+
+	encoder := json.NewEncoder(os.Stdout)
+	encoder.SetIndent("", "  ")
+
+	if err := encoder.Encode(result); err != nil {
+		return fmt.Errorf("error encoding diff: %w", err)
+	}
+
+	return nil
+}
+
+// isDiffSource returns true if the path is a set of files (directory or MPQ archive)
+func isDiffSource(path string, info os.FileInfo) bool {
+	return info.IsDir() || strings.EqualFold(filepath.Ext(path), ".mpq")
+}
+
+func diffFiles(pathA, pathB string) (*hsdiff.FileDiff, error) {
+	a, err := os.ReadFile(filepath.Clean(pathA))
+	if err != nil {
+		return nil, fmt.Errorf("error reading %s: %w", pathA, err)
+	}
+
+	b, err := os.ReadFile(filepath.Clean(pathB))
+	if err != nil {
+		return nil, fmt.Errorf("error reading %s: %w", pathB, err)
+	}
+
+	return hsdiff.Diff(filepath.Base(pathB), a, b)
+}
+
+// openDiffSource opens a directory or an MPQ archive. The closer (nil for directories)
+// has to be closed when the source isn't used anymore.
+func openDiffSource(path string) (hsdiff.Source, io.Closer, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, nil, fmt.Errorf("cannot open %s: %w", path, err)
+	}
+
+	if info.IsDir() {
+		return hsdiff.NewDirSource(path, path), nil, nil
+	}
+
+	mpq, err := d2mpq.FromFile(path)
+	if err != nil {
+		return nil, nil, fmt.Errorf("error loading mpq %s: %w", path, err)
+	}
+
+	files, err := mpq.Listfile()
+	if err != nil {
+		closeDiffSource(mpq)
+
+		return nil, nil, fmt.Errorf("cannot list files of %s: %w", path, err)
+	}
+
+	return hsdiff.NewMPQSource(mpq, files), mpq, nil
+}
+
+func closeDiffSource(closer io.Closer) {
+	if closer == nil {
+		return
+	}
+
+	if err := closer.Close(); err != nil {
+		log.Print(err)
+	}
+}
+
+func diffSources(pathA, pathB string) (*sourcesDiff, error) {
+	a, closerA, err := openDiffSource(pathA)
+	if err != nil {
+		return nil, err
+	}
+
+	defer closeDiffSource(closerA)
+
+	b, closerB, err := openDiffSource(pathB)
+	if err != nil {
+		return nil, err
+	}
+
+	defer closeDiffSource(closerB)
+
+	changes, err := hsdiff.Compare(context.Background(), a, b, nil)
+	if err != nil {
+		return nil, fmt.Errorf("error comparing %s and %s: %w", pathA, pathB, err)
+	}
+
+	result := &sourcesDiff{
+		A:     pathA,
+		B:     pathB,
+		Files: make([]sourceFileDiff, len(changes)),
+	}
+
+	for i, change := range changes {
+		result.Files[i].FileChange = change
+
+		if change.Status != hsdiff.StatusChanged {
+			continue
+		}
+
+		dataA, err := a.ReadFile(change.PathA)
+		if err != nil {
+			return nil, err
+		}
+
+		dataB, err := b.ReadFile(change.PathB)
+		if err != nil {
+			return nil, err
+		}
+
+		diff, err := hsdiff.Diff(change.Path, dataA, dataB)
+		if err != nil {
+			return nil, fmt.Errorf("error comparing %s: %w", change.Path, err)
+		}
+
+		result.Files[i].Diff = diff
+	}
+
+	return result, nil
+}
+
+// onShowUnsavedChangesClicked opens a diff window of the focused editor's file and editor's data
+func (a *App) onShowUnsavedChangesClicked() {
+	e := a.focusedEditor
+	if e == nil {
+		return
+	}
+
+	saveable, ok := e.(editor.Saveable)
+	if !ok {
+		return
+	}
+
+	path := e.GetPath()
+
+	saved, err := path.GetFileBytes()
+	if err != nil {
+		logErr("Could not read %s: %v", path.FullPath, err)
+
+		return
+	}
+
+	diff, err := hsdiff.Diff(path.Name, saved, saveable.GenerateSaveData())
+	if err != nil {
+		logErr("Could not compare %s: %v", path.FullPath, err)
+
+		return
+	}
+
+	a.openDiffWindow("Changes of "+path.Name, path.GetUniqueID(), "Saved", "Editor", diff)
+}
+
+// openDiffWindow opens a new diff window; window of the same ID is replaced
+func (a *App) openDiffWindow(title, id, nameA, nameB string, diff *hsdiff.FileDiff) {
+	for _, w := range a.diffWindows {
+		if w.GetID() == id {
+			w.Cleanup()
+		}
+	}
+
+	a.diffWindows = append(a.diffWindows, diffwindow.Create(title, id, nameA, nameB, diff, diffWindowDefaultX, diffWindowDefaultY))
+}
+
+func (a *App) renderDiffWindows() {
+	idx := 0
+	for idx < len(a.diffWindows) {
+		if !a.diffWindows[idx].IsVisible() {
+			a.diffWindows = append(a.diffWindows[:idx], a.diffWindows[idx+1:]...)
+
+			continue
+		}
+
+		a.diffWindows[idx].Build()
+		idx++
+	}
+}
@@ -13,13 +13,15 @@ type Flags struct {
 	optionalConfigPath *string
 	bgColor            *string
 	logFile            *string
+	diff               *bool
 }
 
 // parse all of the command line args
-func (a *App) parseArgs() (shouldTerminate bool) {
+func (a *App) parseArgs() (shouldTerminate bool, err error) {
 	a.parseConfigArgs()
 	a.parseLogFileArgs()
 	a.parseBackgroundColorArgs()
+	a.parseDiffArgs()
 
 	// help args need to be parsed last, so that all other args
 	// can be parsed before a possible os.Exit() invoked by `-h` or `--help`
@@ -31,24 +33,29 @@ func (a *App) parseArgs() (shouldTerminate bool) {
 
 	if a.showUsage {
 		flag.Usage()
-		return true
+		return true, nil
 	}
 
-	return false
+	if *a.Flags.diff {
+		// headless mode: compare and quit
+		return true, runDiff(flag.Args())
+	}
+
+	return false, nil
 }
 
 func (a *App) parseHelpArgs() {
 	const (
 		short    = "h"
 		long     = "help"
-		fmtUsage = "usage: %s [<flags>]\n\nFlags:\n"
+		fmtUsage = "usage: %s [<flags>]\n       %s -diff <old> <new>\n\nFlags:\n"
 	)
 
 	flag.BoolVar(&a.showUsage, long, false, "Show help")
 	flag.BoolVar(&a.showUsage, short, false, "Show help (shorthand)")
 
 	flag.Usage = func() {
-		log.Printf(fmtUsage, os.Args[0])
+		log.Printf(fmtUsage, os.Args[0], os.Args[0])
 		flag.PrintDefaults()
 	}
 }
@@ -74,6 +81,16 @@ func (a *App) parseBackgroundColorArgs() {
 	a.Flags.bgColor = flag.String(name, defaultValue, desc)
 }
 
+func (a *App) parseDiffArgs() {
+	const (
+		name = "diff"
+		desc = "compare two files, directories or MPQ archives given as arguments\n" +
+			"and print differences as JSON (without starting the editor)."
+	)
+
+	a.Flags.diff = flag.Bool(name, false, desc)
+}
+
 func (a *App) parseLogFileArgs() {
 	const (
 		name = "log"
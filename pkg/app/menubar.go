@@ -5,7 +5,9 @@ import (
 	"fmt"
 	"log"
 	"os"
+	"path/filepath"
 	"runtime"
+	"strconv"
 	"strings"
 
 	g "github.com/AllenDang/giu"
@@ -13,9 +15,12 @@ import (
 	"github.com/gravestench/osinfo"
 	"github.com/pkg/browser"
 
+	"github.com/gucio321/HellSpawner/pkg/abysswrapper"
 	"github.com/gucio321/HellSpawner/pkg/app/config"
+	"github.com/gucio321/HellSpawner/pkg/common"
 	"github.com/gucio321/HellSpawner/pkg/common/hsproject"
 	"github.com/gucio321/HellSpawner/pkg/window"
+	"github.com/gucio321/HellSpawner/pkg/window/editor"
 )
 
 const (
@@ -29,39 +34,40 @@ func (a *App) fileMenu() *g.MenuWidget {
 	m := g.Menu("File")
 
 	mNew := g.Menu("New")
-	mNewProject := menuItem("MainMenuFileNew", "Project...", "Ctrl+Shift+N")
+	mNewProject := a.actionMenuItem("MainMenuFileNew", "Project...", actionNewProject)
 	mNewProject.OnClick(a.onNewProjectClicked)
 
 	mOpen := g.Menu("Open")
-	mOpenProject := menuItem("MainMenuFileOpen", "Project...", "Ctrl+Shift+O")
+	mOpenProject := a.actionMenuItem("MainMenuFileOpen", "Project...", actionOpenProject)
 	mOpenProject.OnClick(a.onOpenProjectClicked)
 
-	mSaveProject := menuItem("MainMenuFileSaveProject", "Save Project", "Ctrl+S")
+	mSaveProject := a.actionMenuItem("MainMenuFileSaveProject", "Save Project", actionSave)
 	mSaveProject.OnClick(a.Save)
 
 	mCloseProject := menuItem("MainMenuCloseProject", "Close Project", "")
 	mCloseProject.OnClick(a.onCloseProjectClicked).Enabled(a.project != nil)
 
-	mPreferences := menuItem("MainMenuFilePreferences", "Preferences...", "Alt+P")
+	_, saveable := a.focusedEditor.(editor.Saveable)
+	mUnsavedChanges := menuItem("MainMenuFile", "Show Unsaved Changes", "")
+	mUnsavedChanges.OnClick(a.onShowUnsavedChangesClicked).Enabled(saveable)
+
+	mPreferences := a.actionMenuItem("MainMenuFilePreferences", "Preferences...", actionPreferences)
 	mPreferences.OnClick(a.onFilePreferencesClicked)
 
-	mExit := menuItem("MainMenuFile", "Exit", "Alt+Q")
-	fnExit := func() {
-		a.Quit()
-		os.Exit(0)
-	}
+	mExit := a.actionMenuItem("MainMenuFile", "Exit", actionExit)
 
 	m.Layout(
 		mNew.Layout(mNewProject),
 		mOpen.Layout(mOpenProject),
 		a.openRecentProjectMenu(),
 		mSaveProject,
+		mUnsavedChanges,
 		g.Separator(),
 		mCloseProject,
 		g.Separator(),
 		mPreferences,
 		g.Separator(),
-		mExit.OnClick(fnExit),
+		mExit.OnClick(a.onExitClicked),
 	)
 
 	return m
@@ -111,17 +117,27 @@ func (a *App) viewMenu() *g.MenuWidget {
 	hasProject := a.project != nil
 
 	toolWindows := g.Menu("Tool Windows").Layout(
-		g.MenuItem("Project Explorer").Shortcut("Ctrl+Shift+P").
+		g.MenuItem("Project Explorer").Shortcut(a.shortcuts.Label(actionProjectExplorer)).
 			Selected(a.projectExplorer.Visible && hasProject).
 			Enabled(hasProject).
 			OnClick(a.toggleProjectExplorer),
 
-		g.MenuItem("MPQ Explorer").Shortcut("Ctrl+Shift+M").
+		g.MenuItem("MPQ Explorer").Shortcut(a.shortcuts.Label(actionMPQExplorer)).
 			Selected(a.mpqExplorer.Visible && hasProject).
 			Enabled(hasProject).
 			OnClick(a.toggleMPQExplorer),
 
-		g.MenuItem("Console").Shortcut("Ctrl+Shift+C").
+		g.MenuItem("MPQ Compare").
+			Selected(a.mpqCompare.Visible && hasProject).
+			Enabled(hasProject).
+			OnClick(a.toggleMPQCompare),
+
+		g.MenuItem("Problems").
+			Selected(a.problems.Visible && hasProject).
+			Enabled(hasProject).
+			OnClick(a.toggleProblems),
+
+		g.MenuItem("Console").Shortcut(a.shortcuts.Label(actionConsole)).
 			Selected(a.console.Visible).
 			OnClick(a.toggleConsole),
 	)
@@ -139,16 +155,34 @@ func (a *App) viewMenu() *g.MenuWidget {
 			}),
 	)
 
+	splitEditors := g.MenuItem("Split Editor Area").
+		Selected(a.config.StaticLayout.SplitEditors).
+		Enabled(a.config.ViewMode == config.ViewModeStatic).
+		OnClick(func() {
+			a.config.StaticLayout.SplitEditors = !a.config.StaticLayout.SplitEditors
+		})
+
+	commandPalette := g.MenuItem("Command Palette...").
+		Shortcut(a.shortcuts.Label(actionCommandPalette)).
+		OnClick(a.onCommandPaletteClicked)
+
 	items := []g.Widget{
+		commandPalette,
+		g.Separator(),
 		viewMode,
 		toolWindows,
+		splitEditors,
+		a.workspacesMenu(),
 	}
 
 	if len(a.editors) > 0 {
 		items = append(items, g.Separator())
 
 		for i := range a.editors {
-			editorItem := g.MenuItem(a.editors[i].GetWindowTitle()).OnClick(a.editors[i].BringToFront)
+			e := a.editors[i]
+			editorItem := g.MenuItem(e.GetWindowTitle()).OnClick(func() {
+				a.focusEditor(e)
+			})
 			items = append(items, editorItem)
 		}
 	}
@@ -158,39 +192,65 @@ func (a *App) viewMenu() *g.MenuWidget {
 
 func (a *App) projectMenu() *g.MenuWidget {
 	const (
-		runAbyssEngine  = "Run in Abyss Engine"
-		stopAbyssEngine = "Stop Abyss Engine"
+		abyssEngine     = "Abyss Engine"
+		runAbyssEngine  = "Run in " + abyssEngine
+		stopEngineLabel = "Stop %s"
 	)
 
 	projectOpened := a.project != nil
 	enginePathSet := a.config.AbyssEnginePath != ""
+	engineRunning := a.abyssWrapper.IsRunning()
 
 	label := runAbyssEngine
-	if a.abyssWrapper.IsRunning() {
-		label = stopAbyssEngine
+	if engineRunning {
+		label = fmt.Sprintf(stopEngineLabel, a.runningEngine)
 	}
 
 	projectMenu := g.Menu("Project")
 
 	projectMenuRun := menuItem("MainMenuProject", label, "").
-		Enabled(projectOpened && enginePathSet).
-		OnClick(a.onProjectRunClicked)
+		Enabled(projectOpened && (enginePathSet || engineRunning)).
+		OnClick(func() {
+			a.onAbyssEngineRunClicked(abyssEngine)
+		})
+
+	items := []g.Widget{projectMenuRun}
+
+	if projectOpened {
+		for idx := range a.project.LaunchProfiles {
+			profile := a.project.LaunchProfiles[idx]
+
+			items = append(items, menuItem("MainMenuProjectProfile"+strconv.Itoa(idx), "Run in "+profile.Name, "").
+				Enabled(!engineRunning).
+				OnClick(func() {
+					a.onLaunchProfileClicked(&profile)
+				}))
+		}
+	}
 
 	projectMenuProperties := menuItem("MainMenuProject", "Properties...", "").
 		Enabled(projectOpened).
 		OnClick(a.onProjectPropertiesClicked)
 
+	projectMenuValidate := menuItem("MainMenuProject", "Validate Project", "").
+		Enabled(projectOpened).
+		OnClick(a.onValidateProjectClicked)
+
 	projectMenuExportMPQ := menuItem("MainMenuProject", "Export MPQ...", "").
 		Enabled(projectOpened).
 		OnClick(a.onProjectExportMPQClicked)
 
-	return projectMenu.Layout(
-		projectMenuRun,
+	items = append(items,
 		g.Separator(),
 		projectMenuProperties,
 		g.Separator(),
+		projectMenuValidate,
 		projectMenuExportMPQ,
+		g.Separator(),
+		a.scriptsMenu(),
 	)
+
+	return projectMenu.Layout(items...)
 }
 
 func openURL(url string) {
@@ -207,7 +267,7 @@ func (a *App) onOpenURL(url string) func() {
 
 func (a *App) helpMenu() *g.MenuWidget {
 	menuHelp := g.Menu("Help")
-	menuHelpAbout := menuItem("MainMenuHelp", "About HellSpawner...", "F1").
+	menuHelpAbout := a.actionMenuItem("MainMenuHelp", "About HellSpawner...", actionAbout).
 		OnClick(a.onHelpAboutClicked)
 	menuHelpGithub := menuItem("MainMenuHelp", "GitHub repository", "").
 		OnClick(a.onOpenURL(githubURL))
@@ -233,14 +293,20 @@ func (a *App) helpMenu() *g.MenuWidget {
 }
 
 func (a *App) onNewProjectClicked() {
+	a.newProjectDialog.Show()
+}
+
+func (a *App) onNewProjectTemplateSelected(template *hsproject.Template) {
 	file, err := dialog.File().Filter("HellSpawner Project", "hsp").Save()
 	if err != nil || file == "" {
-		logErr("could not create new project, %s", err)
+		return
 	}
 
-	project, err := hsproject.CreateNew(file)
+	project, err := hsproject.CreateFromTemplate(file, template, a.config)
 	if err != nil {
 		logErr("could not create new project file, %s", err)
+
+		return
 	}
 
 	ppath := project.GetProjectFilePath()
@@ -279,35 +345,156 @@ func (a *App) onCloseProjectClicked() {
 		}
 	}
 
+	a.stopWatching()
 	a.project = nil
+	a.openFileCommands = nil
 
 	a.projectExplorer.SetProject(nil)
 	a.mpqExplorer.SetProject(nil)
+	a.mpqCompare.SetProject(nil)
+	a.problems.SetProject(nil)
 	a.CloseAllOpenWindows()
 	a.updateWindowTitle()
 }
 
+// onExitClicked closes the main window, so that Run can shut the app down
+func (a *App) onExitClicked() {
+	a.masterWindow.SetShouldClose(true)
+}
+
 func (a *App) onHelpAboutClicked() {
 	a.aboutDialog.Show()
 }
 
-func (a *App) onProjectRunClicked() {
+// onProjectRunClicked stops the running engine or launches a new one with the options given
+func (a *App) onProjectRunClicked(name string, options func() (*abysswrapper.LaunchOptions, error)) {
 	if a.abyssWrapper.IsRunning() {
-		if err := a.abyssWrapper.Kill(); err != nil {
+		// stopping may take a while, so don't block the UI
+		go func() {
+			if err := a.abyssWrapper.Stop(abysswrapper.DefaultStopTimeout); err != nil {
+				dialog.Message("%v", err).Error()
+			}
+		}()
+
+		return
+	}
+
+	a.console.Show()
+	a.runningEngine = name
+
+	// options may need to export the project first, so don't block the UI
+	go func() {
+		opts, err := options()
+		if err != nil {
 			dialog.Message("%v", err).Error()
+
+			return
+		}
+
+		if err := a.abyssWrapper.Launch(opts, a.console); err != nil {
+			dialog.Message("Error running %s: %v", name, err).Error()
+		}
+	}()
+}
+
+// onAbyssEngineRunClicked runs (or stops) the engine with the current project
+func (a *App) onAbyssEngineRunClicked(name string) {
+	a.onProjectRunClicked(name, func() (*abysswrapper.LaunchOptions, error) {
+		return a.abyssEngineLaunchOptions(), nil
+	})
+}
+
+func (a *App) onLaunchProfileClicked(profile *hsproject.LaunchProfile) {
+	a.onProjectRunClicked(profile.Name, func() (*abysswrapper.LaunchOptions, error) {
+		return a.launchProfileOptions(profile)
+	})
+}
+
+// launchProfileOptions runs profile's pre-launch step and returns options to launch it
+func (a *App) launchProfileOptions(profile *hsproject.LaunchProfile) (*abysswrapper.LaunchOptions, error) {
+	vars := a.project.LaunchVariables(a.config.Locale.String())
+
+	executable, args, env, workDir, err := profile.Command(vars)
+	if err != nil {
+		return nil, fmt.Errorf("invalid launch profile %s: %w", profile.Name, err)
+	}
+
+	switch profile.PreLaunch {
+	case hsproject.PreLaunchNone:
+	case hsproject.PreLaunchExportMPQ:
+		if err := a.exportMPQ(vars[hsproject.LaunchVarExportedMPQ]); err != nil {
+			return nil, err
 		}
+	}
 
+	opts := a.abyssEngineLaunchOptions()
+	opts.EnginePath = executable
+	opts.Args = args
+	opts.Env = env
+	opts.WorkDir = workDir
+
+	return opts, nil
+}
+
+// abyssEngineLaunchOptions returns options to launch the engine with the current project
+func (a *App) abyssEngineLaunchOptions() *abysswrapper.LaunchOptions {
+	mpqs := make([]string, len(a.project.AuxiliaryMPQs))
+	for idx, mpq := range a.project.AuxiliaryMPQs {
+		mpqs[idx] = filepath.Join(a.config.AuxiliaryMpqPath, mpq)
+	}
+
+	return &abysswrapper.LaunchOptions{
+		EnginePath:  a.config.AbyssEnginePath,
+		WorkDir:     filepath.Dir(a.config.AbyssEnginePath),
+		ProjectPath: a.project.GetProjectFilePath(),
+		ContentPath: a.project.GetProjectFileContentPath(),
+		MPQs:        mpqs,
+		Locale:      a.config.Locale.String(),
+		IPCSocket:   filepath.Join(os.TempDir(), fmt.Sprintf("hellspawner-%d.sock", os.Getpid())),
+	}
+}
+
+// onFileSaved notifies running engine about the file changed
+func (a *App) onFileSaved(path *common.PathEntry) {
+	delete(a.tabs.dirty, path.GetUniqueID())
+
+	if a.project == nil || !a.abyssWrapper.IsRunning() {
 		return
 	}
 
-	a.console.Show()
+	gamePath := a.project.EntryGamePath(path)
+	if gamePath == "" {
+		return
+	}
 
-	if err := a.abyssWrapper.Launch(a.config, a.console); err != nil {
-		dialog.Message("%v", err).Error()
+	if err := a.abyssWrapper.NotifyFileChanged(gamePath); err != nil {
+		log.Print(err)
 	}
 }
 
 func (a *App) onProjectExportMPQClicked() {
+	path, err := dialog.File().Filter("MPQ Archive", "mpq").SetStartDir(filepath.Dir(a.project.ExportedMPQPath())).Save()
+	if err != nil || path == "" {
+		return
+	}
+
+	go func() {
+		if err := a.exportMPQ(path); err != nil {
+			dialog.Message("%v", err).Error()
+		}
+	}()
+}
+
+// exportMPQ exports project's content into the MPQ given
+func (a *App) exportMPQ(path string) error {
+	count, err := a.project.ExportMPQ(path)
+	if err != nil {
+		return err
+	}
+
+	log.Printf("exported %d files to %s", count, path)
+
+	return nil
 }
 
 // NOTE: some characters in URLs cannot be dirrectly written, because they have
@@ -367,6 +554,13 @@ func (a *App) renderEditors() {
 		if !editor.IsVisible() {
 			editor.Cleanup()
 
+			// editor may refuse to close (e.g. when its changes couldn't be saved)
+			if editor.IsVisible() {
+				continue
+			}
+
+			a.onEditorClosed(editor)
+
 			a.editors = append(a.editors[:idx], a.editors[idx+1:]...)
 
 			continue
@@ -374,20 +568,7 @@ func (a *App) renderEditors() {
 
 		hadFocus := editor.HasFocus()
 
-		// common shortcut
-		editor.RegisterKeyboardShortcuts(
-			g.WindowShortcut{
-				Key:      g.KeyS,
-				Modifier: g.ModControl,
-				Callback: func() {
-					editor.Save()
-				},
-			},
-		)
-
-		editor.RegisterKeyboardShortcuts(
-			editor.KeyboardShortcuts()...,
-		)
+		a.registerEditorShortcuts(editor)
 
 		editor.Build()
 
@@ -403,10 +584,14 @@ func (a *App) renderWindows() {
 	windows := []window.Renderable{
 		a.projectExplorer,
 		a.mpqExplorer,
+		a.mpqCompare,
+		a.problems,
 		a.console,
 		a.preferencesDialog,
 		a.aboutDialog,
 		a.projectPropertiesDialog,
+		a.newProjectDialog,
+		a.commandPalette,
 	}
 
 	for _, tw := range windows {
@@ -414,6 +599,8 @@ func (a *App) renderWindows() {
 			tw.Build()
 		}
 	}
+
+	a.renderDiffWindows()
 }
 
 func makeMenuID(name, group, shortcut string) string {
@@ -441,3 +628,8 @@ func itemID(group, name, shortcut string) string {
 func menuItem(group, name, shortcut string) *g.MenuItemWidget {
 	return g.MenuItem(itemID(group, name, shortcut))
 }
+
+// actionMenuItem returns a menu item labeled with action's shortcut
+func (a *App) actionMenuItem(group, name, actionID string) *g.MenuItemWidget {
+	return menuItem(group, name, a.shortcuts.Label(actionID))
+}
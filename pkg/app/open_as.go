@@ -0,0 +1,55 @@
+package app
+
+import (
+	"sort"
+
+	"github.com/gucio321/HellSpawner/pkg/common"
+	"github.com/gucio321/HellSpawner/pkg/common/hsfiletypes"
+)
+
+// setupOpenAs adds "Open As" menus to the explorers. Must be called after all editors are registered.
+func (a *App) setupOpenAs() {
+	fileTypes := make([]hsfiletypes.FileType, 0, len(a.editorConstructors))
+
+	for fileType, constructor := range a.editorConstructors {
+		if constructor != nil {
+			fileTypes = append(fileTypes, fileType)
+		}
+	}
+
+	sort.Slice(fileTypes, func(i, j int) bool { return fileTypes[i] < fileTypes[j] })
+
+	a.projectExplorer.SetOpenAs(fileTypes, a.openEditorAs)
+	a.mpqExplorer.SetOpenAs(fileTypes, a.openEditorAs)
+}
+
+// openEditorAs opens the file with an editor of the file type given, ignoring what its content looks like.
+// The choice is remembered until the project is closed, so that the file is reloaded in the same editor.
+func (a *App) openEditorAs(path *common.PathEntry, fileType hsfiletypes.FileType) {
+	a.editorManagerMutex.Lock()
+	a.openedAs[path.GetUniqueID()] = fileType
+	e := a.findEditor(path.GetUniqueID())
+	a.editorManagerMutex.Unlock()
+
+	if e == nil {
+		a.openEditor(path)
+
+		return
+	}
+
+	// the file is already opened in another editor; widget's state of that editor is useless for the new one
+	editorState := e.State()
+	editorState.Encoded = nil
+
+	e.SetVisible(false)
+
+	if a.focusedEditor == e {
+		a.focusedEditor = nil
+	}
+
+	a.pendingEditors = append(a.pendingEditors, &pendingEditor{
+		path:   path,
+		state:  editorState,
+		frames: reopenDelayFrames,
+	})
+}
@@ -0,0 +1,60 @@
+package app
+
+import (
+	"log"
+
+	"github.com/gucio321/HellSpawner/pkg/common/hsfiletypes"
+	"github.com/gucio321/HellSpawner/pkg/plugin"
+)
+
+// setupPlugins loads compiled-in and external plugins and registers their file types and editors
+func (a *App) setupPlugins() {
+	external, errs := plugin.LoadDir(a.config.PluginsPath)
+	for _, err := range errs {
+		log.Print(err)
+	}
+
+	a.plugins = append(plugin.CompiledIn(), external...)
+
+	for _, p := range a.plugins {
+		for _, ft := range p.FileTypes {
+			fileType, err := hsfiletypes.Register(ft.Descriptor)
+			if err != nil {
+				log.Printf("plugin %s: %v", p.Name, err)
+
+				continue
+			}
+
+			if ft.Editor != nil {
+				a.editorConstructors[fileType] = editorConstructor(ft.Editor)
+			}
+
+			a.pluginFileTypes = append(a.pluginFileTypes, fileType)
+		}
+
+		log.Printf("loaded plugin %s", p.Name)
+	}
+
+	a.projectExplorer.AddNewFileTypes(a.creatableFileTypes(a.pluginFileTypes)...)
+}
+
+// creatableFileTypes returns these of file types given, which files may be created
+func (a *App) creatableFileTypes(fileTypes []hsfiletypes.FileType) []hsfiletypes.FileType {
+	result := make([]hsfiletypes.FileType, 0, len(fileTypes))
+
+	for _, fileType := range fileTypes {
+		if d, ok := hsfiletypes.Registered(fileType); ok && d.Template != nil {
+			result = append(result, fileType)
+		}
+	}
+
+	return result
+}
+
+func (a *App) closePlugins() {
+	for _, p := range a.plugins {
+		if err := p.Close(); err != nil {
+			log.Printf("plugin %s: %v", p.Name, err)
+		}
+	}
+}
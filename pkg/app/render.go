@@ -13,47 +13,20 @@ func renderWnd(d window.Renderable) g.Widget {
 	})
 }
 
-// TODO: selected tab doesn't work here
-func (a *App) renderStaticEditors() g.Widget {
-	tabs := make([]*g.TabItemWidget, 0)
-
-	idx := 0
-	for idx < len(a.editors) {
-		editor := a.editors[idx]
-		if !editor.IsVisible() {
-			editor.Cleanup()
-
-			a.editors = append(a.editors[:idx], a.editors[idx+1:]...)
-
-			continue
-		}
-
-		hadFocus := editor.HasFocus()
-
-		// common shortcut
-		editor.RegisterKeyboardShortcuts(
-			g.WindowShortcut{
-				Key:      g.KeyS,
-				Modifier: g.ModControl,
-				Callback: func() {
-					editor.Save()
-				},
-			},
-		)
-
-		editor.RegisterKeyboardShortcuts(
-			editor.KeyboardShortcuts()...,
-		)
-
-		tabs = append(tabs, g.TabItemf("Editor %d", idx).Layout(
-			editor.GetLayout(),
-		))
-
-		if !hadFocus && editor.HasFocus() {
-			a.focusedEditor = editor
-		}
+// renderStaticBottomPanel renders the console; problems (if visible) are docked next to it
+func (a *App) renderStaticBottomPanel() g.Widget {
+	if !a.problems.IsVisible() {
+		return a.console.GetLayout()
+	}
 
-		idx++
+	problemsTab := g.TabItem("Problems##StaticProblems").IsOpen(&a.problems.Visible).Layout(a.problems.GetLayout())
+	if a.selectProblemsTab {
+		problemsTab.Flags(g.TabItemFlagsSetSelected)
+		a.selectProblemsTab = false
 	}
-	return g.TabBar().TabItems(tabs...)
+
+	return g.TabBar().TabItems(
+		g.TabItem("Console##StaticConsole").Layout(a.console.GetLayout()),
+		problemsTab,
+	)
 }
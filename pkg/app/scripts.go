@@ -0,0 +1,135 @@
+package app
+
+import (
+	"fmt"
+	"log"
+	"sync/atomic"
+
+	g "github.com/AllenDang/giu"
+
+	"github.com/gucio321/HellSpawner/pkg/common/hscommand"
+	"github.com/gucio321/HellSpawner/pkg/common/hsproject"
+	"github.com/gucio321/HellSpawner/pkg/common/hsscript"
+)
+
+// scripting holds the state of the scripting console
+type scripting struct {
+	engine *hsscript.Engine
+	// project is the project, which engine was created for
+	project *hsproject.Project
+	running atomic.Bool
+}
+
+// scriptEngine returns the engine of the current project (nil if there is no project)
+func (a *App) scriptEngine() *hsscript.Engine {
+	if a.project == nil {
+		return nil
+	}
+
+	if a.scripting.project != a.project {
+		a.scripting.engine = hsscript.New(a.project, a.console)
+		a.scripting.project = a.project
+	}
+
+	return a.scripting.engine
+}
+
+// onConsoleInput runs code typed in the console
+func (a *App) onConsoleInput(code string) {
+	_, _ = fmt.Fprintf(a.console, "> %s\n", code)
+
+	a.runScript(func(engine *hsscript.Engine) (string, error) {
+		return engine.Run(code)
+	})
+}
+
+func (a *App) onRunScriptClicked(name string) {
+	log.Printf("running script %s", name)
+
+	a.runScript(func(engine *hsscript.Engine) (string, error) {
+		return engine.RunScript(name)
+	})
+}
+
+// runScript runs a script in the background and writes its result to the console
+func (a *App) runScript(run func(engine *hsscript.Engine) (string, error)) {
+	engine := a.scriptEngine()
+	if engine == nil {
+		log.Print("open a project to run scripts")
+
+		return
+	}
+
+	if !a.scripting.running.CompareAndSwap(false, true) {
+		log.Print("another script is running")
+
+		return
+	}
+
+	a.console.Show()
+
+	// MPQ handles can't be shared with the UI, so script gets its own ones
+	project, closeMPQs := a.project.Detached()
+
+	go func() {
+		defer a.scripting.running.Store(false)
+		defer closeMPQs()
+
+		engine.SetProject(project)
+		result, err := run(engine)
+
+		switch {
+		case err != nil:
+			log.Print(err)
+		case result != "":
+			_, _ = fmt.Fprintln(a.console, result)
+		}
+	}()
+}
+
+func (a *App) onStopScriptClicked() {
+	if engine := a.scriptEngine(); engine != nil {
+		engine.Interrupt()
+	}
+}
+
+func (a *App) scriptsMenu() *g.MenuWidget {
+	return g.Menu("Scripts").Enabled(a.project != nil).Layout(
+		g.Custom(func() {
+			scripts, err := a.project.Scripts()
+			if err != nil {
+				log.Print(err)
+			}
+
+			if len(scripts) == 0 {
+				g.MenuItem("No scripts in " + a.project.ScriptsPath()).Enabled(false).Build()
+			}
+
+			for _, name := range scripts {
+				g.MenuItem(name + "##MainMenuProjectScript").
+					Enabled(!a.scripting.running.Load()).
+					OnClick(func() { a.onRunScriptClicked(name) }).
+					Build()
+			}
+		}),
+		g.Separator(),
+		g.MenuItem("Stop Running Script").
+			Enabled(a.scripting.running.Load()).
+			OnClick(a.onStopScriptClicked),
+	)
+}
+
+// scriptCommands returns commands running project's scripts
+func (a *App) scriptCommands() []hscommand.Command {
+	scripts, err := a.project.Scripts()
+	if err != nil {
+		log.Print(err)
+	}
+
+	result := make([]hscommand.Command, len(scripts))
+	for idx, name := range scripts {
+		result[idx] = hscommand.Command{Category: "Run Script", Name: name, Run: func() { a.onRunScriptClicked(name) }}
+	}
+
+	return result
+}
@@ -14,6 +14,7 @@ import (
 
 	g "github.com/AllenDang/giu"
 
+	"github.com/gucio321/HellSpawner/pkg/window/editor/d2s"
 	"github.com/gucio321/HellSpawner/pkg/window/editor/ds1"
 	"github.com/gucio321/HellSpawner/pkg/window/editor/dt1"
 	"github.com/gucio321/HellSpawner/pkg/window/editor/fonttable"
@@ -25,23 +26,30 @@ import (
 	"github.com/gucio321/HellSpawner/pkg/common/hsutil"
 	"github.com/gucio321/HellSpawner/pkg/window/editor/animdata"
 	"github.com/gucio321/HellSpawner/pkg/window/editor/cof"
+	"github.com/gucio321/HellSpawner/pkg/window/editor/colormap"
 	"github.com/gucio321/HellSpawner/pkg/window/editor/dc6"
 	"github.com/gucio321/HellSpawner/pkg/window/editor/dcc"
+	"github.com/gucio321/HellSpawner/pkg/window/editor/excelbin"
 	"github.com/gucio321/HellSpawner/pkg/window/editor/font"
 	"github.com/gucio321/HellSpawner/pkg/window/editor/palette"
 	"github.com/gucio321/HellSpawner/pkg/window/editor/sound"
 	"github.com/gucio321/HellSpawner/pkg/window/editor/text"
 	"github.com/gucio321/HellSpawner/pkg/window/popup/aboutdialog"
+	"github.com/gucio321/HellSpawner/pkg/window/popup/commandpalette"
+	"github.com/gucio321/HellSpawner/pkg/window/popup/newproject"
 	"github.com/gucio321/HellSpawner/pkg/window/popup/preferences"
 	"github.com/gucio321/HellSpawner/pkg/window/popup/projectproperties"
 	"github.com/gucio321/HellSpawner/pkg/window/toolwindow/console"
+	"github.com/gucio321/HellSpawner/pkg/window/toolwindow/mpqcompare"
 	"github.com/gucio321/HellSpawner/pkg/window/toolwindow/mpqexplorer"
+	"github.com/gucio321/HellSpawner/pkg/window/toolwindow/problems"
 	"github.com/gucio321/HellSpawner/pkg/window/toolwindow/projectexplorer"
 )
 
 func (a *App) setup() (err error) {
 	a.setupConsole()
 	a.setupAutoSave()
+	a.setupShortcuts()
 	a.registerGlobalKeyboardShortcuts()
 	a.registerEditors()
 
@@ -60,6 +68,12 @@ func (a *App) setup() (err error) {
 		return err
 	}
 
+	a.setupPlugins()
+	a.setupOpenAs()
+
+	a.setupMPQCompare()
+	a.setupProblems()
+
 	err = a.setupDialogs()
 	if err != nil {
 		return err
@@ -74,6 +88,7 @@ func (a *App) setupMasterWindow() {
 
 	bgColor := a.determineBackgroundColor()
 	a.masterWindow.SetBgColor(bgColor)
+	a.masterWindow.SetDropCallback(a.onFilesDropped)
 }
 
 func (a *App) determineBackgroundColor() color.RGBA {
@@ -131,6 +146,9 @@ func (a *App) registerEditors() {
 	a.editorConstructors[hsfiletypes.FileTypeTBLStringTable] = stringtable.Create
 	a.editorConstructors[hsfiletypes.FileTypeTBLFontTable] = fonttable.Create
 	a.editorConstructors[hsfiletypes.FileTypeDS1] = ds1.Create
+	a.editorConstructors[hsfiletypes.FileTypeColormap] = colormap.Create
+	a.editorConstructors[hsfiletypes.FileTypeExcelBin] = excelbin.Create
+	a.editorConstructors[hsfiletypes.FileTypeD2S] = d2s.Create
 }
 
 func (a *App) setupMainMpqExplorer() error {
@@ -148,11 +166,24 @@ func (a *App) setupMainMpqExplorer() error {
 	return nil
 }
 
+func (a *App) setupMPQCompare() {
+	basePos := imgui.MainViewport().Pos()
+
+	a.mpqCompare = mpqcompare.Create(a.config, mpqCompareDefaultX+basePos.X, mpqCompareDefaultY+basePos.Y)
+}
+
+func (a *App) setupProblems() {
+	basePos := imgui.MainViewport().Pos()
+
+	a.problems = problems.Create(a.openEditor, problemsDefaultX+basePos.X, problemsDefaultY+basePos.Y)
+}
+
 func (a *App) setupProjectExplorer() error {
 	basePos := imgui.MainViewport().Pos()
 
 	window, err := projectexplorer.Create(
 		a.openEditor,
+		a.onProjectPathMoved,
 		projectExplorerDefaultX+basePos.X, projectExplorerDefaultY+basePos.Y,
 	)
 	if err != nil {
@@ -177,6 +208,7 @@ func (a *App) setupAudio() error {
 
 func (a *App) setupConsole() {
 	a.console = console.Create(a.fontFixed, consoleDefaultX, consoleDefaultY, a.logFile)
+	a.console.SetInputHandler(a.onConsoleInput)
 
 	log.SetFlags(log.Lshortfile)
 	log.SetOutput(a.console)
@@ -197,7 +229,9 @@ func (a *App) setupDialogs() error {
 
 	a.aboutDialog = about
 	a.projectPropertiesDialog = projectproperties.Create(a.onProjectPropertiesChanged)
-	a.preferencesDialog = preferences.Create(a.onPreferencesChanged, a.masterWindow.SetBgColor)
+	a.newProjectDialog = newproject.Create(a.onNewProjectTemplateSelected)
+	a.preferencesDialog = preferences.Create(a.onPreferencesChanged, a.masterWindow.SetBgColor, shortcutActions())
+	a.commandPalette = commandpalette.Create()
 
 	return nil
 }
@@ -230,21 +264,3 @@ func (a *App) setupFonts() {
 	a.diabloRegularFont = g.Context.FontAtlas.AddFontFromBytes("diablo regular", assets.FontDiabloRegular, diabloRegularFontSize)
 	a.diabloBoldFont = g.Context.FontAtlas.AddFontFromBytes("diablo bold", assets.FontDiabloBold, diabloBoldFontSize)
 }
-
-func (a *App) registerGlobalKeyboardShortcuts() {
-	a.masterWindow.RegisterKeyboardShortcuts(
-		g.WindowShortcut{Key: g.KeyN, Modifier: g.ModControl + g.ModShift, Callback: a.onNewProjectClicked},
-		g.WindowShortcut{Key: g.KeyO, Modifier: g.ModControl, Callback: a.onOpenProjectClicked},
-		g.WindowShortcut{Key: g.KeyS, Modifier: g.ModControl, Callback: a.Save},
-		g.WindowShortcut{Key: g.KeyP, Modifier: g.ModAlt, Callback: a.onFilePreferencesClicked},
-		g.WindowShortcut{Key: g.KeyQ, Modifier: g.ModAlt, Callback: a.Quit},
-		g.WindowShortcut{Key: g.KeyF1, Modifier: g.ModNone, Callback: a.onHelpAboutClicked},
-
-		g.WindowShortcut{Key: g.KeyW, Modifier: g.ModControl, Callback: a.closeActiveEditor},
-		g.WindowShortcut{Key: g.KeyEscape, Modifier: g.ModNone, Callback: func() { a.closePopups(); a.closeActiveEditor() }},
-
-		g.WindowShortcut{Key: g.KeyM, Modifier: g.ModControl + g.ModShift, Callback: a.toggleMPQExplorer},
-		g.WindowShortcut{Key: g.KeyP, Modifier: g.ModControl + g.ModShift, Callback: a.toggleProjectExplorer},
-		g.WindowShortcut{Key: g.KeyC, Modifier: g.ModControl + g.ModShift, Callback: a.toggleConsole},
-	)
-}
@@ -0,0 +1,202 @@
+package app
+
+import (
+	g "github.com/AllenDang/giu"
+
+	"github.com/gucio321/HellSpawner/pkg/common/hsshortcut"
+	"github.com/gucio321/HellSpawner/pkg/window/editor"
+	"github.com/gucio321/HellSpawner/pkg/window/editor/dt1"
+)
+
+// IDs of actions, which can be run by keyboard shortcuts
+const (
+	actionNewProject      = "file.newProject"
+	actionOpenProject     = "file.openProject"
+	actionSave            = editor.ActionSave
+	actionPreferences     = "file.preferences"
+	actionExit            = "file.exit"
+	actionAbout           = "help.about"
+	actionCloseEditor     = "window.closeEditor"
+	actionClose           = "window.close"
+	actionMPQExplorer     = "view.mpqExplorer"
+	actionProjectExplorer = "view.projectExplorer"
+	actionConsole         = "view.console"
+	actionCommandPalette  = "view.commandPalette"
+)
+
+// shortcutActions returns all the actions with their default shortcuts
+func shortcutActions() []hsshortcut.Action {
+	return []hsshortcut.Action{
+		{ID: actionNewProject, Name: "New Project", Default: "Ctrl+Shift+N"},
+		{ID: actionOpenProject, Name: "Open Project", Default: "Ctrl+O"},
+		{ID: actionSave, Name: "Save", Default: "Ctrl+S"},
+		{ID: actionPreferences, Name: "Preferences", Default: "Alt+P"},
+		{ID: actionExit, Name: "Exit", Default: "Alt+Q"},
+		{ID: actionAbout, Name: "About HellSpawner", Default: "F1"},
+		{ID: actionCloseEditor, Name: "Close Editor", Default: "Ctrl+W"},
+		{ID: actionClose, Name: "Close Popups and Editor", Default: "Escape"},
+		{ID: actionMPQExplorer, Name: "Toggle MPQ Explorer", Default: "Ctrl+Shift+M"},
+		{ID: actionProjectExplorer, Name: "Toggle Project Explorer", Default: "Ctrl+Shift+P"},
+		{ID: actionConsole, Name: "Toggle Console", Default: "Ctrl+Shift+C"},
+		{ID: actionCommandPalette, Name: "Command Palette", Default: "Ctrl+Shift+A"},
+	}
+}
+
+// editorShortcutActions returns actions of editors' own shortcuts (see editor.ShortcutProvider)
+func editorShortcutActions() []hsshortcut.Action {
+	return dt1.ShortcutActions()
+}
+
+// actionCallbacks returns functions run by actions
+func (a *App) actionCallbacks() map[string]func() {
+	return map[string]func(){
+		actionNewProject:      a.onNewProjectClicked,
+		actionOpenProject:     a.onOpenProjectClicked,
+		actionSave:            a.Save,
+		actionPreferences:     a.onFilePreferencesClicked,
+		actionExit:            a.Quit,
+		actionAbout:           a.onHelpAboutClicked,
+		actionCloseEditor:     a.closeActiveEditor,
+		actionClose:           func() { a.closePopups(); a.closeActiveEditor() },
+		actionMPQExplorer:     a.toggleMPQExplorer,
+		actionProjectExplorer: a.toggleProjectExplorer,
+		actionConsole:         a.toggleConsole,
+		actionCommandPalette:  a.onCommandPaletteClicked,
+	}
+}
+
+var giuKeys = func() map[string]g.Key {
+	result := map[string]g.Key{
+		"Escape":    g.KeyEscape,
+		"Enter":     g.KeyEnter,
+		"Tab":       g.KeyTab,
+		"Space":     g.KeySpace,
+		"Backspace": g.KeyBackspace,
+		"Insert":    g.KeyInsert,
+		"Delete":    g.KeyDelete,
+		"Home":      g.KeyHome,
+		"End":       g.KeyEnd,
+		"PageUp":    g.KeyPageUp,
+		"PageDown":  g.KeyPageDown,
+		"Up":        g.KeyUp,
+		"Down":      g.KeyDown,
+		"Left":      g.KeyLeft,
+		"Right":     g.KeyRight,
+		"Minus":     g.KeyMinus,
+		"Equal":     g.KeyEqual,
+		"Comma":     g.KeyComma,
+		"Period":    g.KeyPeriod,
+		"Slash":     g.KeySlash,
+	}
+
+	letters := []g.Key{
+		g.KeyA, g.KeyB, g.KeyC, g.KeyD, g.KeyE, g.KeyF, g.KeyG, g.KeyH, g.KeyI,
+		g.KeyJ, g.KeyK, g.KeyL, g.KeyM, g.KeyN, g.KeyO, g.KeyP, g.KeyQ, g.KeyR,
+		g.KeyS, g.KeyT, g.KeyU, g.KeyV, g.KeyW, g.KeyX, g.KeyY, g.KeyZ,
+	}
+
+	for i, key := range letters {
+		result[string(rune('A'+i))] = key
+	}
+
+	digits := []g.Key{g.Key0, g.Key1, g.Key2, g.Key3, g.Key4, g.Key5, g.Key6, g.Key7, g.Key8, g.Key9}
+	for i, key := range digits {
+		result[string(rune('0'+i))] = key
+	}
+
+	functionKeys := map[string]g.Key{
+		"F1": g.KeyF1, "F2": g.KeyF2, "F3": g.KeyF3, "F4": g.KeyF4,
+		"F5": g.KeyF5, "F6": g.KeyF6, "F7": g.KeyF7, "F8": g.KeyF8,
+		"F9": g.KeyF9, "F10": g.KeyF10, "F11": g.KeyF11, "F12": g.KeyF12,
+	}
+
+	for name, key := range functionKeys {
+		result[name] = key
+	}
+
+	return result
+}()
+
+// giuShortcut converts shortcut into giu's key and modifier
+func giuShortcut(s hsshortcut.Shortcut) (key g.Key, mod g.Modifier, ok bool) {
+	key, ok = giuKeys[s.Key]
+	if !ok {
+		return 0, 0, false
+	}
+
+	mod = g.ModNone
+
+	if s.Ctrl {
+		mod += g.ModControl
+	}
+
+	if s.Shift {
+		mod += g.ModShift
+	}
+
+	if s.Alt {
+		mod += g.ModAlt
+	}
+
+	return key, mod, true
+}
+
+// windowShortcut returns a shortcut running callback given, bound to action's keys
+func (a *App) windowShortcut(actionID string, callback func()) (g.WindowShortcut, bool) {
+	key, mod, ok := giuShortcut(a.shortcuts.Shortcut(actionID))
+	if !ok {
+		return g.WindowShortcut{}, false
+	}
+
+	return g.WindowShortcut{Key: key, Modifier: mod, Callback: callback}, true
+}
+
+func (a *App) setupShortcuts() {
+	registry := hsshortcut.NewRegistry(append(shortcutActions(), editorShortcutActions()...), a.config.Shortcuts)
+
+	// editors keep the registry, so it is updated in place
+	if a.shortcuts != nil {
+		*a.shortcuts = *registry
+
+		return
+	}
+
+	a.shortcuts = registry
+}
+
+// registerEditorShortcuts registers shortcuts of the editor's actions (save and editor's own ones)
+func (a *App) registerEditorShortcuts(e editor.Editor) {
+	if s, ok := a.windowShortcut(actionSave, e.Save); ok {
+		e.RegisterKeyboardShortcuts(s)
+	}
+
+	provider, ok := e.(editor.ShortcutProvider)
+	if !ok {
+		return
+	}
+
+	for id, callback := range provider.ShortcutCallbacks() {
+		if s, ok := a.windowShortcut(id, callback); ok {
+			e.RegisterKeyboardShortcuts(s)
+		}
+	}
+}
+
+func (a *App) registerGlobalKeyboardShortcuts() {
+	// giu doesn't allow to unregister global shortcuts, but a nil callback disables them
+	for _, s := range a.globalShortcuts {
+		s.Callback = nil
+		a.masterWindow.RegisterKeyboardShortcuts(s)
+	}
+
+	a.globalShortcuts = a.globalShortcuts[:0]
+	callbacks := a.actionCallbacks()
+
+	for _, action := range a.shortcuts.Actions() {
+		if s, ok := a.windowShortcut(action.ID, callbacks[action.ID]); ok {
+			a.globalShortcuts = append(a.globalShortcuts, s)
+		}
+	}
+
+	a.masterWindow.RegisterKeyboardShortcuts(a.globalShortcuts...)
+}
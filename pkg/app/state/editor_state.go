@@ -1,8 +1,14 @@
 package state
 
+// NoPalette is a palette of an editor, which displays images without palette (in grayscale)
+const NoPalette = "<none>"
+
 // EditorState holds information about the state of an open editor
 type EditorState struct {
 	WindowState
 	Path    []byte `json:"path"` // this gets exported as raw JSON to prevent an import loop
 	Encoded []byte `json:"state"`
+	// Palette is a game path of editor's palette (NoPalette if none used).
+	// Empty palette is resolved using project's palette rules
+	Palette string `json:"palettePath,omitempty"`
 }
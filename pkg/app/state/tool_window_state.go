@@ -8,6 +8,8 @@ const (
 	ToolWindowTypeMPQExplorer     = ToolWindowType("MPQ Explorer")
 	ToolWindowTypeProjectExplorer = ToolWindowType("Project Explorer")
 	ToolWindowTypeConsole         = ToolWindowType("Console")
+	ToolWindowTypeMPQCompare      = ToolWindowType("MPQ Compare")
+	ToolWindowTypeProblems        = ToolWindowType("Problems")
 )
 
 // ToolWindowState holds information about tool windows (e.g. MPQ Explorer)
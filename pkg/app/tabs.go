@@ -0,0 +1,251 @@
+package app
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/AllenDang/cimgui-go/imgui"
+	g "github.com/AllenDang/giu"
+
+	"github.com/gucio321/HellSpawner/pkg/window/editor"
+)
+
+const (
+	numEditorPanes = 2
+	// dirtyCheckInterval limits how often edited editors are compared with their files
+	dirtyCheckInterval = time.Second
+)
+
+// editorTabs holds a state of static mode's document area
+type editorTabs struct {
+	// panes assigns editors (by their IDs) to panes of the split view
+	panes map[string]int
+	// activePane is a pane, which user interacted with the last time
+	activePane int
+	// toSelect is an ID of the editor, which tab gets selected in the next frame
+	toSelect string
+	dirty    map[string]dirtyState
+}
+
+type dirtyState struct {
+	dirty   bool
+	checked time.Time
+	// edited is the last time, user interacted with the editor. Edits may be applied
+	// after the editor was checked in the same frame, so that it is checked again an interval later
+	edited time.Time
+}
+
+func newEditorTabs() editorTabs {
+	return editorTabs{
+		panes: make(map[string]int),
+		dirty: make(map[string]dirtyState),
+	}
+}
+
+// focusEditor brings the editor to front; in static mode, its tab gets selected
+func (a *App) focusEditor(e editor.Editor) {
+	e.BringToFront()
+	a.tabs.toSelect = e.GetID()
+}
+
+// onEditorClosed forgets editor's tab
+func (a *App) onEditorClosed(e editor.Editor) {
+	delete(a.tabs.panes, e.GetID())
+	delete(a.tabs.dirty, e.GetID())
+
+	if a.focusedEditor == e {
+		a.focusedEditor = nil
+	}
+}
+
+// editorPane returns a pane of the editor
+func (a *App) editorPane(e editor.Editor) int {
+	if !a.config.StaticLayout.SplitEditors {
+		return 0
+	}
+
+	return a.tabs.panes[e.GetID()]
+}
+
+// moveEditorToPane moves the editor to the pane given and splits the document area if necessary
+func (a *App) moveEditorToPane(e editor.Editor, pane int) {
+	a.config.StaticLayout.SplitEditors = true
+	a.tabs.panes[e.GetID()] = pane
+	a.tabs.activePane = pane
+	a.tabs.toSelect = e.GetID()
+}
+
+// markFocusedEditorEdited marks the focused editor as possibly edited, if user interacts with the app.
+// Editors' data are changed only by user's input, so that unchanged editors aren't compared with their files.
+func (a *App) markFocusedEditorEdited() {
+	if a.focusedEditor == nil || !(imgui.IsAnyItemActive() || imgui.IsAnyMouseDown()) {
+		return
+	}
+
+	if s, found := a.tabs.dirty[a.focusedEditor.GetID()]; found {
+		s.edited = time.Now()
+		a.tabs.dirty[a.focusedEditor.GetID()] = s
+	}
+}
+
+// isEditorDirty returns true if the editor has unsaved changes.
+// Save data are generated only when the editor wasn't checked yet or was edited recently.
+func (a *App) isEditorDirty(e editor.Editor) bool {
+	type changesChecker interface {
+		HasChanges(editor.Saveable) bool
+	}
+
+	saveable, ok := e.(editor.Saveable)
+	if !ok {
+		return false
+	}
+
+	checker, ok := e.(changesChecker)
+	if !ok {
+		return false
+	}
+
+	s, found := a.tabs.dirty[e.GetID()]
+	if found && (time.Since(s.checked) < dirtyCheckInterval || s.checked.After(s.edited.Add(dirtyCheckInterval))) {
+		return s.dirty
+	}
+
+	s.dirty = checker.HasChanges(saveable)
+	s.checked = time.Now()
+	a.tabs.dirty[e.GetID()] = s
+
+	return s.dirty
+}
+
+// tabLabel returns a label of editor's tab; ID of the tab doesn't depend on the dirty marker
+func (a *App) tabLabel(e editor.Editor) string {
+	name, _, _ := strings.Cut(e.GetWindowTitle(), "##")
+
+	if a.isEditorDirty(e) {
+		name += "*"
+	}
+
+	return name + "###" + e.GetID()
+}
+
+// renderStaticEditors renders editors as tabs of the document area (split into two panes, if enabled)
+func (a *App) renderStaticEditors() g.Widget {
+	idx := 0
+	for idx < len(a.editors) {
+		e := a.editors[idx]
+		if !e.IsVisible() {
+			e.Cleanup()
+
+			// editor may refuse to close (e.g. when its changes couldn't be saved)
+			if e.IsVisible() {
+				continue
+			}
+
+			a.onEditorClosed(e)
+
+			a.editors = append(a.editors[:idx], a.editors[idx+1:]...)
+
+			continue
+		}
+
+		a.registerEditorShortcuts(e)
+
+		idx++
+	}
+
+	if !a.config.StaticLayout.SplitEditors {
+		a.tabs.activePane = 0
+
+		return a.renderEditorPane(0)
+	}
+
+	return g.SplitLayout(g.DirectionVertical, &a.config.StaticLayout.EditorSplit,
+		a.renderEditorPane(0),
+		a.renderEditorPane(1),
+	).SplitRefType(g.SplitRefProc)
+}
+
+func (a *App) renderEditorPane(pane int) g.Widget {
+	tabs := make([]*g.TabItemWidget, 0)
+	closed := make(map[editor.Editor]*bool)
+
+	for _, e := range a.editors {
+		if a.editorPane(e) != pane {
+			continue
+		}
+
+		open := true
+		closed[e] = &open
+
+		tab := g.TabItem(a.tabLabel(e)).IsOpen(&open).Layout(
+			g.ContextMenu().ID(g.ID(fmt.Sprintf("EditorTabContextMenu%s", e.GetID()))).
+				Layout(a.makeTabContextMenu(e, pane)...),
+			g.Custom(func() {
+				a.onTabSelected(e, pane)
+			}),
+			e.GetLayout(),
+		)
+
+		if a.tabs.toSelect == e.GetID() {
+			tab.Flags(g.TabItemFlagsSetSelected)
+			a.tabs.toSelect = ""
+		}
+
+		tabs = append(tabs, tab)
+	}
+
+	return g.Child().ID(g.ID(fmt.Sprintf("EditorPane%d", pane))).Border(false).Layout(
+		g.TabBar().ID(g.ID(fmt.Sprintf("EditorTabs%d", pane))).
+			Flags(g.TabBarFlagsReorderable|g.TabBarFlagsAutoSelectNewTabs|g.TabBarFlagsFittingPolicyScroll).
+			TabItems(tabs...),
+		g.Custom(func() {
+			// tab's close button was clicked
+			for e, open := range closed {
+				if !*open {
+					e.SetVisible(false)
+				}
+			}
+		}),
+	)
+}
+
+// onTabSelected is called every frame for selected tabs; the selected tab of the active pane
+// becomes the focused editor (contributing its menu to the main menu)
+func (a *App) onTabSelected(e editor.Editor, pane int) {
+	if g.IsWindowFocused(g.FocusedFlags(g.FocusedFlagsChildWindows)) {
+		a.tabs.activePane = pane
+	}
+
+	if pane == a.tabs.activePane {
+		a.focusedEditor = e
+	}
+}
+
+func (a *App) makeTabContextMenu(e editor.Editor, pane int) []g.Widget {
+	otherPane := (pane + 1) % numEditorPanes
+	moveLabel := "Move to Right Pane"
+
+	if otherPane < pane {
+		moveLabel = "Move to Left Pane"
+	}
+
+	return []g.Widget{
+		g.MenuItem("Save##EditorTabSave").OnClick(e.Save),
+		g.Separator(),
+		g.MenuItem("Close##EditorTabClose").OnClick(func() {
+			e.SetVisible(false)
+		}),
+		g.MenuItem("Close Others##EditorTabCloseOthers").OnClick(func() {
+			for _, other := range a.editors {
+				if other != e && a.editorPane(other) == pane {
+					other.SetVisible(false)
+				}
+			}
+		}),
+		g.Separator(),
+		g.MenuItem(moveLabel + "##EditorTabMove").OnClick(func() {
+			a.moveEditorToPane(e, otherPane)
+		}),
+	}
+}
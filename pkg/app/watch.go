@@ -0,0 +1,253 @@
+package app
+
+import (
+	"bytes"
+	"encoding/json"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/OpenDiablo2/dialog"
+
+	"github.com/gucio321/HellSpawner/pkg/app/state"
+	"github.com/gucio321/HellSpawner/pkg/common"
+	"github.com/gucio321/HellSpawner/pkg/common/hswatcher"
+	"github.com/gucio321/HellSpawner/pkg/window/editor"
+)
+
+// reopenDelayFrames is a number of frames between closing and reopening of the reloaded editor.
+// Giu needs to release widget's state of the closed editor, before the new one is created.
+const reopenDelayFrames = 3
+
+// pendingEditor is an editor, which will be opened (or reopened) by the render loop after a number of frames
+type pendingEditor struct {
+	path    *common.PathEntry
+	palette string
+	state   state.EditorState
+	frames  int
+}
+
+// startWatching starts watching project's content for changes done by external tools
+func (a *App) startWatching() {
+	a.stopWatching()
+
+	a.watcher = a.project.Watch()
+	a.watcher.Start(a.onFilesChanged)
+}
+
+func (a *App) stopWatching() {
+	if a.watcher == nil {
+		return
+	}
+
+	a.watcher.Stop()
+	a.watcher = nil
+}
+
+// ignoreFileChanges consumes changes done by HellSpawner itself (e.g. in project explorer),
+// so that they aren't reported to editors
+func (a *App) ignoreFileChanges() {
+	if a.watcher == nil || a.project == nil {
+		return
+	}
+
+	a.project.ApplyFileChanges(a.watcher.Poll())
+}
+
+// onProjectPathMoved updates editors of the files moved (or renamed) in project explorer
+func (a *App) onProjectPathMoved(oldPath, newPath string) {
+	a.ignoreFileChanges()
+
+	for _, e := range a.editors {
+		path := e.GetPath()
+		if path.Source != common.PathEntrySourceProject {
+			continue
+		}
+
+		rel, err := filepath.Rel(oldPath, path.FullPath)
+		if err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+			continue
+		}
+
+		newEntry := *path
+		newEntry.FullPath = filepath.Join(newPath, rel)
+		newEntry.Name = filepath.Base(newEntry.FullPath)
+		newEntry.OldName = ""
+		newEntry.IsRenaming = false
+
+		oldID := e.GetID()
+		e.SetPath(&newEntry)
+
+		if pane, found := a.tabs.panes[oldID]; found {
+			delete(a.tabs.panes, oldID)
+			a.tabs.panes[e.GetID()] = pane
+		}
+	}
+}
+
+// onFilesChanged is called by the watcher (from its goroutine)
+func (a *App) onFilesChanged(events []hswatcher.Event) {
+	a.fileChangesMutex.Lock()
+	defer a.fileChangesMutex.Unlock()
+
+	a.fileChanges = append(a.fileChanges, events...)
+}
+
+// processFileChanges applies changes found by the watcher. Called every frame.
+func (a *App) processFileChanges() {
+	a.fileChangesMutex.Lock()
+	events, reloads := a.fileChanges, a.editorsToReload
+	a.fileChanges, a.editorsToReload = nil, nil
+	a.fileChangesMutex.Unlock()
+
+	for _, id := range reloads {
+		if e := a.findEditor(id); e != nil {
+			a.reloadEditor(e)
+		}
+	}
+
+	a.openPendingEditors()
+
+	if len(events) == 0 || a.project == nil {
+		return
+	}
+
+	a.project.ApplyFileChanges(events)
+
+	for _, event := range events {
+		if event.Op != hswatcher.OpWrite {
+			// list of project's files changed
+			a.openFileCommands = nil
+		}
+
+		path := &common.PathEntry{FullPath: event.Path, Source: common.PathEntrySourceProject}
+
+		e := a.findEditor(path.GetUniqueID())
+		if e == nil {
+			continue
+		}
+
+		switch event.Op {
+		case hswatcher.OpWrite:
+			a.onEditorFileChanged(e, event.Path)
+		case hswatcher.OpRemove:
+			log.Printf("%s was removed; its editor keeps the data, but it cannot be saved", event.Path)
+		case hswatcher.OpCreate:
+			// editor's file was re-created (e.g. saved by a tool, which replaces files)
+			a.onEditorFileChanged(e, event.Path)
+		}
+	}
+}
+
+func (a *App) findEditor(id string) editor.Editor {
+	for _, e := range a.editors {
+		if e.GetID() == id && e.IsVisible() {
+			return e
+		}
+	}
+
+	return nil
+}
+
+// onEditorFileChanged reloads the editor or asks whether to reload it, if the editor may have some changes
+func (a *App) onEditorFileChanged(e editor.Editor, path string) {
+	saveable, ok := e.(editor.Saveable)
+	if !ok {
+		// editor doesn't change its file, so there is nothing to lose
+		a.reloadEditor(e)
+
+		return
+	}
+
+	data, err := os.ReadFile(path) //nolint:gosec // path comes from the watcher
+	if err != nil {
+		log.Print(err)
+
+		return
+	}
+
+	if bytes.Equal(saveable.GenerateSaveData(), data) {
+		// saved by the editor itself or nothing changed
+		return
+	}
+
+	id := e.GetID()
+
+	a.fileChangesMutex.Lock()
+	if a.reloadPrompts[id] {
+		a.fileChangesMutex.Unlock()
+
+		return
+	}
+
+	a.reloadPrompts[id] = true
+	a.fileChangesMutex.Unlock()
+
+	// don't block the UI while the message is shown
+	go func() {
+		reload := dialog.Message(
+			"%s was changed outside of HellSpawner.\n"+
+				"Do you want to reload it? (Editor's changes will be lost)", path,
+		).Title("File changed").YesNo()
+
+		a.fileChangesMutex.Lock()
+		defer a.fileChangesMutex.Unlock()
+
+		delete(a.reloadPrompts, id)
+
+		if reload {
+			a.editorsToReload = append(a.editorsToReload, id)
+		}
+	}()
+}
+
+// reloadEditor closes the editor and reopens it with the same window and widget state
+func (a *App) reloadEditor(e editor.Editor) {
+	editorState := e.State()
+
+	var path *common.PathEntry
+	if err := json.Unmarshal(editorState.Path, &path); err != nil {
+		log.Print("failed to reload editor: ", err)
+
+		return
+	}
+
+	var palette string
+	if pe, ok := e.(editor.PaletteEditor); ok {
+		palette = a.paletteGamePath(pe)
+	}
+
+	// the render loop will call Cleanup when it notices that this editor isn't visible
+	e.SetVisible(false)
+
+	if a.focusedEditor == e {
+		a.focusedEditor = nil
+	}
+
+	a.pendingEditors = append(a.pendingEditors, &pendingEditor{
+		path:    path,
+		palette: palette,
+		state:   editorState,
+		frames:  reopenDelayFrames,
+	})
+}
+
+func (a *App) openPendingEditors() {
+	idx := 0
+	for idx < len(a.pendingEditors) {
+		p := a.pendingEditors[idx]
+
+		if p.frames--; p.frames > 0 {
+			idx++
+
+			continue
+		}
+
+		a.pendingEditors = append(a.pendingEditors[:idx], a.pendingEditors[idx+1:]...)
+
+		a.editorManagerMutex.Lock()
+		a.createEditor(p.path, p.palette, p.state.Encoded, p.state.PosX, p.state.PosY, p.state.Width, p.state.Height)
+		a.editorManagerMutex.Unlock()
+	}
+}
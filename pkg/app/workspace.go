@@ -0,0 +1,189 @@
+package app
+
+import (
+	"log"
+	"strconv"
+	"strings"
+
+	g "github.com/AllenDang/giu"
+	"github.com/OpenDiablo2/dialog"
+
+	"github.com/gucio321/HellSpawner/pkg/app/config"
+	"github.com/gucio321/HellSpawner/pkg/app/state"
+	"github.com/gucio321/HellSpawner/pkg/window/toolwindow"
+)
+
+const workspaceNameW = 200
+
+// toolWindows returns all the tool windows of the app
+func (a *App) toolWindows() []toolwindow.ToolWindow {
+	return []toolwindow.ToolWindow{
+		a.mpqExplorer,
+		a.mpqCompare,
+		a.problems,
+		a.projectExplorer,
+		a.console,
+	}
+}
+
+// restoreToolWindows restores positions, sizes and visibility of tool windows
+func (a *App) restoreToolWindows(states []state.ToolWindowState) {
+	for _, toolState := range states {
+		var tool toolwindow.ToolWindow
+
+		switch toolState.Type {
+		case state.ToolWindowTypeConsole:
+			tool = a.console
+		case state.ToolWindowTypeMPQExplorer:
+			tool = a.mpqExplorer
+		case state.ToolWindowTypeMPQCompare:
+			tool = a.mpqCompare
+		case state.ToolWindowTypeProblems:
+			tool = a.problems
+		case state.ToolWindowTypeProjectExplorer:
+			tool = a.projectExplorer
+		default:
+			continue
+		}
+
+		tool.SetVisible(toolState.Visible)
+		tool.Place(toolState.PosX, toolState.PosY, toolState.Width, toolState.Height)
+	}
+}
+
+// applyToolWindowPlacements moves tool windows restored by restoreToolWindows
+func (a *App) applyToolWindowPlacements() {
+	for _, tool := range a.toolWindows() {
+		tool.ApplyPlacement()
+	}
+}
+
+// currentWorkspace captures the current layout of the app as a workspace of the name given
+func (a *App) currentWorkspace(name string) config.Workspace {
+	result := config.Workspace{
+		Name:         name,
+		ViewMode:     a.config.ViewMode,
+		StaticLayout: a.config.StaticLayout,
+		ToolWindows:  make([]state.ToolWindowState, 0),
+	}
+
+	for _, tool := range a.toolWindows() {
+		result.ToolWindows = append(result.ToolWindows, tool.State())
+	}
+
+	return result
+}
+
+// applyWorkspace changes the layout of the app to the workspace given
+func (a *App) applyWorkspace(workspace *config.Workspace) {
+	a.config.ViewMode = workspace.ViewMode
+	a.config.StaticLayout = workspace.StaticLayout
+	a.config.ActiveWorkspace = workspace.Name
+
+	a.restoreToolWindows(workspace.ToolWindows)
+
+	if err := a.config.Save(); err != nil {
+		log.Printf("failed to save config: %v", err)
+	}
+}
+
+func (a *App) onSaveWorkspaceClicked() {
+	name := strings.TrimSpace(a.workspaceName)
+	previous := a.config.ActiveWorkspace
+
+	// SetWorkspace saves the config, so the workspace has to be set active before
+	a.config.ActiveWorkspace = name
+
+	if err := a.config.SetWorkspace(a.currentWorkspace(name)); err != nil {
+		a.config.ActiveWorkspace = previous
+
+		logErr("Could not save workspace: %v", err)
+
+		return
+	}
+
+	a.workspaceName = ""
+}
+
+func (a *App) onImportWorkspaceClicked() {
+	path, err := dialog.File().Title("Import workspace").Filter("Workspace", "json").Load()
+	if err != nil || path == "" {
+		return
+	}
+
+	workspace, err := config.LoadWorkspace(path)
+	if err != nil {
+		logErr("Could not import workspace: %v", err)
+
+		return
+	}
+
+	workspace.Name = strings.TrimSpace(workspace.Name)
+
+	if err := a.config.SetWorkspace(*workspace); err != nil {
+		logErr("Could not import workspace: %v", err)
+
+		return
+	}
+
+	a.applyWorkspace(workspace)
+}
+
+func (a *App) onExportWorkspaceClicked(workspace *config.Workspace) {
+	path, err := dialog.File().Title("Export workspace").Filter("Workspace", "json").Save()
+	if err != nil || path == "" {
+		return
+	}
+
+	if err := workspace.Export(path); err != nil {
+		logErr("Could not export workspace: %v", err)
+	}
+}
+
+func (a *App) onRemoveWorkspaceClicked(name string) {
+	if err := a.config.RemoveWorkspace(name); err != nil {
+		logErr("Could not remove workspace: %v", err)
+	}
+}
+
+func (a *App) workspacesMenu() *g.MenuWidget {
+	apply := make([]g.Widget, 0, len(a.config.Workspaces))
+	export := make([]g.Widget, 0, len(a.config.Workspaces))
+	remove := make([]g.Widget, 0, len(a.config.Workspaces))
+
+	for idx := range a.config.Workspaces {
+		workspace := &a.config.Workspaces[idx]
+		id := "##Workspace" + strconv.Itoa(idx)
+
+		apply = append(apply, g.MenuItem(workspace.Name+id).
+			Selected(workspace.Name == a.config.ActiveWorkspace).
+			OnClick(func() {
+				a.applyWorkspace(workspace)
+			}))
+
+		export = append(export, g.MenuItem(workspace.Name+id+"Export").OnClick(func() {
+			a.onExportWorkspaceClicked(workspace)
+		}))
+
+		name := workspace.Name
+		remove = append(remove, g.MenuItem(workspace.Name+id+"Remove").OnClick(func() {
+			a.onRemoveWorkspaceClicked(name)
+		}))
+	}
+
+	hasWorkspaces := len(a.config.Workspaces) > 0
+
+	apply = append(apply,
+		g.Separator(),
+		g.Menu("Save Current As").Layout(
+			g.InputText(&a.workspaceName).Hint("e.g. Map editing").Size(workspaceNameW),
+			g.Button("Save##SaveWorkspace").Disabled(a.workspaceName == "").OnClick(a.onSaveWorkspaceClicked),
+		),
+		g.Menu("Remove").Enabled(hasWorkspaces).Layout(remove...),
+		g.Separator(),
+		g.MenuItem("Import...##ImportWorkspace").OnClick(a.onImportWorkspaceClicked),
+		g.Menu("Export").Enabled(hasWorkspaces).Layout(export...),
+	)
+
+	return g.Menu("Workspaces").Layout(apply...)
+}
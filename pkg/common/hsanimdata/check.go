@@ -0,0 +1,167 @@
+package hsanimdata
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/OpenDiablo2/OpenDiablo2/d2common/d2fileformats/d2animdata"
+	"github.com/OpenDiablo2/OpenDiablo2/d2common/d2fileformats/d2cof"
+)
+
+// IssueType represents a type of an inconsistency
+type IssueType int
+
+// Issue types
+const (
+	// IssueMissingRecord means that there is a COF file, but no animation data record for it
+	IssueMissingRecord IssueType = iota
+	// IssueOrphanRecord means that there is a record, but no COF file for it
+	IssueOrphanRecord
+	// IssueFramesMismatch means that frames per direction differs
+	IssueFramesMismatch
+	// IssueSpeedMismatch means that animation speed differs
+	IssueSpeedMismatch
+)
+
+// String returns issue type's string
+func (i IssueType) String() string {
+	table := map[IssueType]string{
+		IssueMissingRecord:  "missing record",
+		IssueOrphanRecord:   "orphan record",
+		IssueFramesMismatch: "frames mismatch",
+		IssueSpeedMismatch:  "speed mismatch",
+	}
+
+	val, found := table[i]
+	if !found {
+		return "unknown"
+	}
+
+	return val
+}
+
+// Issue represents a single inconsistency between animation data and a COF file
+type Issue struct {
+	Type IssueType
+	// Name is a record's (and COF's) name, e.g. ZMNUHTH
+	Name string
+	// RecordIdx is an index of the record in records with this name
+	RecordIdx int
+	Expected  int
+	Actual    int
+
+	d2  *d2animdata.AnimationData
+	cof *d2cof.COF
+}
+
+// String returns a human-readable description of the issue
+func (i *Issue) String() string {
+	switch i.Type {
+	case IssueMissingRecord:
+		return fmt.Sprintf("%s: COF exists, but there is no animation data record", i.Name)
+	case IssueOrphanRecord:
+		return fmt.Sprintf("%s: no COF file found for this record", i.Name)
+	case IssueFramesMismatch:
+		return fmt.Sprintf("%s (record %d): frames per direction is %d, but COF has %d",
+			i.Name, i.RecordIdx, i.Actual, i.Expected)
+	case IssueSpeedMismatch:
+		return fmt.Sprintf("%s (record %d): speed is %d, but COF has %d",
+			i.Name, i.RecordIdx, i.Actual, i.Expected)
+	}
+
+	return i.Name + ": " + i.Type.String()
+}
+
+// CanFix returns true if the issue can be fixed automatically
+func (i *Issue) CanFix() bool {
+	return i.Type != IssueOrphanRecord
+}
+
+// Fix updates animation data record, so that it matches the COF file
+func (i *Issue) Fix() error {
+	switch i.Type {
+	case IssueMissingRecord:
+		// the entry may already exist, but without any records
+		record, err := PushRecord(i.d2, i.Name)
+		if err != nil {
+			return err
+		}
+
+		//nolint:gosec // frames count is a byte in COF
+		record.SetFramesPerDirection(uint32(i.cof.FramesPerDirection))
+
+		if i.cof.Speed != 0 {
+			//nolint:gosec // speed is a byte in COF
+			record.SetSpeed(uint16(i.cof.Speed))
+		}
+	case IssueFramesMismatch:
+		//nolint:gosec // frames count is a byte in COF
+		i.d2.GetRecords(i.Name)[i.RecordIdx].SetFramesPerDirection(uint32(i.Expected))
+	case IssueSpeedMismatch:
+		//nolint:gosec // speed is a byte in COF
+		i.d2.GetRecords(i.Name)[i.RecordIdx].SetSpeed(uint16(i.Expected))
+	default:
+		return fmt.Errorf("%s cannot be fixed automatically", i.Type)
+	}
+
+	return nil
+}
+
+// Check compares every record of animation data with COF files given (mapped by upper-case names).
+// COF's speed is compared only if it is set (non-zero) in the COF file.
+func Check(d2 *d2animdata.AnimationData, cofs map[string]*d2cof.COF) []*Issue {
+	result := make([]*Issue, 0)
+
+	names := d2.GetRecordNames()
+	sort.Strings(names)
+
+	for _, name := range names {
+		cof, found := cofs[name]
+		if !found {
+			result = append(result, &Issue{Type: IssueOrphanRecord, Name: name, d2: d2})
+
+			continue
+		}
+
+		for idx, record := range d2.GetRecords(name) {
+			if record.FramesPerDirection() != cof.FramesPerDirection {
+				result = append(result, &Issue{
+					Type:      IssueFramesMismatch,
+					Name:      name,
+					RecordIdx: idx,
+					Expected:  cof.FramesPerDirection,
+					Actual:    record.FramesPerDirection(),
+					d2:        d2,
+					cof:       cof,
+				})
+			}
+
+			if cof.Speed != 0 && record.Speed() != cof.Speed {
+				result = append(result, &Issue{
+					Type:      IssueSpeedMismatch,
+					Name:      name,
+					RecordIdx: idx,
+					Expected:  cof.Speed,
+					Actual:    record.Speed(),
+					d2:        d2,
+					cof:       cof,
+				})
+			}
+		}
+	}
+
+	cofNames := make([]string, 0, len(cofs))
+	for name := range cofs {
+		cofNames = append(cofNames, name)
+	}
+
+	sort.Strings(cofNames)
+
+	for _, name := range cofNames {
+		if len(d2.GetRecords(name)) == 0 {
+			result = append(result, &Issue{Type: IssueMissingRecord, Name: name, d2: d2, cof: cofs[name]})
+		}
+	}
+
+	return result
+}
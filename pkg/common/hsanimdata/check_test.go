@@ -0,0 +1,66 @@
+package hsanimdata
+
+import (
+	"testing"
+
+	"github.com/OpenDiablo2/OpenDiablo2/d2common/d2fileformats/d2animdata"
+	"github.com/OpenDiablo2/OpenDiablo2/d2common/d2fileformats/d2cof"
+)
+
+const testRecordName = "ZMNUHTH"
+
+func Test_CheckAndFix(t *testing.T) {
+	tests := []struct {
+		name string
+		// records are frames per direction of records pushed to animation data.
+		// nil means no entry, empty slice means an entry without records
+		records []uint32
+		issue   IssueType
+	}{
+		{name: "missing record", records: nil, issue: IssueMissingRecord},
+		{name: "empty entry", records: []uint32{}, issue: IssueMissingRecord},
+		{name: "frames mismatch", records: []uint32{8}, issue: IssueFramesMismatch},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d2, err := d2animdata.Load(make([]byte, numBlocks*blockHeader))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if tt.records != nil {
+				if err := d2.AddEntry(testRecordName); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			for _, frames := range tt.records {
+				record, err := PushRecord(d2, testRecordName)
+				if err != nil {
+					t.Fatal(err)
+				}
+
+				record.SetFramesPerDirection(frames)
+			}
+
+			cofs := map[string]*d2cof.COF{testRecordName: {FramesPerDirection: 12}}
+
+			issues := Check(d2, cofs)
+			if len(issues) != 1 || issues[0].Type != tt.issue {
+				t.Fatalf("expected a single %s issue, got %v", tt.issue, issues)
+			}
+
+			if err := issues[0].Fix(); err != nil {
+				t.Fatal(err)
+			}
+
+			if issues := Check(d2, cofs); len(issues) != 0 {
+				t.Errorf("issues left after fixing: %v", issues)
+			}
+
+			// would panic, if record's events weren't initialized
+			d2.GetRecord(testRecordName).SetEvent(0, d2animdata.AnimationEventAttack)
+		})
+	}
+}
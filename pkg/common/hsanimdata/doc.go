@@ -0,0 +1,3 @@
+// Package hsanimdata contains tools for checking consistency of animation data (.d2)
+// with the COF files it describes
+package hsanimdata
@@ -0,0 +1,50 @@
+package hsanimdata
+
+import (
+	"fmt"
+
+	"github.com/OpenDiablo2/OpenDiablo2/d2common/d2fileformats/d2animdata"
+)
+
+const (
+	numBlocks     = 256
+	maxNameLength = 7 // 8 bytes of name with the null terminator
+	recordSize    = 8 + 4 + 2 + 2 + 144
+	blockHeader   = 4
+)
+
+// PushRecord adds a new record to the entry named name (the entry is created if it doesn't exist).
+// Unlike d2animdata.AnimationData.PushRecord, record's events map is initialized,
+// so that events can be set on it.
+func PushRecord(d2 *d2animdata.AnimationData, name string) (*d2animdata.AnimationDataRecord, error) {
+	blank, err := blankRecord(name)
+	if err != nil {
+		return nil, err
+	}
+
+	d2.PushRecord(name)
+
+	record := d2.GetRecord(name)
+	*record = *blank
+
+	return record, nil
+}
+
+// blankRecord loads animation data containing only a record named name.
+// It is the only way of creating a record with events map outside of d2animdata.
+func blankRecord(name string) (*d2animdata.AnimationDataRecord, error) {
+	if name == "" || len(name) > maxNameLength {
+		return nil, fmt.Errorf("invalid record name %q (expected 1 to %d characters)", name, maxNameLength)
+	}
+
+	data := make([]byte, numBlocks*blockHeader+recordSize)
+	data[0] = 1 // number of records in the first block
+	copy(data[blockHeader:], name)
+
+	d2, err := d2animdata.Load(data)
+	if err != nil {
+		return nil, fmt.Errorf("error creating record %s: %w", name, err)
+	}
+
+	return d2.GetRecord(name), nil
+}
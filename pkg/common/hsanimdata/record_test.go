@@ -0,0 +1,39 @@
+package hsanimdata
+
+import (
+	"testing"
+
+	"github.com/OpenDiablo2/OpenDiablo2/d2common/d2fileformats/d2animdata"
+)
+
+func Test_PushRecord(t *testing.T) {
+	blank, err := blankRecord("ZMNUHTH")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if blank.Events() == nil {
+		t.Fatal("events of blank record should be initialized")
+	}
+
+	data, err := d2animdata.Load(make([]byte, numBlocks*blockHeader))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	record, err := PushRecord(data, "ZMNUHTH")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// would panic, if events map wasn't initialized
+	record.SetEvent(1, d2animdata.AnimationEventAttack)
+
+	if len(data.GetRecords("ZMNUHTH")) != 1 || data.GetRecord("ZMNUHTH").Event(1) != d2animdata.AnimationEventAttack {
+		t.Error("record wasn't added to animation data")
+	}
+
+	if _, err := PushRecord(data, "TOOLONGNAME"); err == nil {
+		t.Error("record with too long name shouldn't be added")
+	}
+}
@@ -0,0 +1,241 @@
+package hscof
+
+import (
+	"errors"
+	"fmt"
+	"path"
+	"sort"
+	"strings"
+
+	"github.com/OpenDiablo2/OpenDiablo2/d2common/d2enum"
+	"github.com/OpenDiablo2/OpenDiablo2/d2common/d2fileformats/d2cof"
+)
+
+const (
+	dccSignature     = 0x74
+	dccHeaderSize    = 7
+	dccSignatureIdx  = 0
+	dccDirectionsIdx = 2
+	dccFramesIdx     = 3
+	byteWidth        = 8
+)
+
+const (
+	dccExtension    = ".dcc"
+	layerCodeLen    = 2
+	gamePathDepth   = 6 // data\global\<kind>\<token>\<layer>\<file>
+	kindPathIdx     = 2
+	tokenPathIdx    = 3
+	layerPathIdx    = 4
+	fileNamePathIdx = 5
+)
+
+const (
+	// maxCOFCount is the maximal number of directions and frames per direction,
+	// COF's header stores them as single bytes
+	maxCOFCount = 255
+	// DefaultSpeed is the animation speed of a new COF
+	DefaultSpeed = 128
+	// DefaultShadow is the shadow flag of a new COF's layers (layers cast a shadow)
+	DefaultShadow = 1
+)
+
+const (
+	animationNameModeIdx = 2
+	animationNameWCIdx   = 4
+)
+
+// AnimationKinds returns directories of data\global, which contain composite (COF + DCC layers) animations
+func AnimationKinds() []string {
+	return []string{"monsters", "chars", "objects"}
+}
+
+// SplitAnimationName splits animation name (as used in COF names and animation data records)
+// into token, mode and weapon class, e.g. ZMNUHTH gives ZM, NU and HTH.
+func SplitAnimationName(name string) (token, mode, weaponClass string, ok bool) {
+	if len(name) <= animationNameWCIdx {
+		return "", "", "", false
+	}
+
+	return name[:animationNameModeIdx], name[animationNameModeIdx:animationNameWCIdx], name[animationNameWCIdx:], true
+}
+
+// DefaultDrawOrder returns an order, in which layers are drawn by default
+// (from the first to the last one)
+func DefaultDrawOrder() []d2enum.CompositeType {
+	return []d2enum.CompositeType{
+		d2enum.CompositeTypeLegs,
+		d2enum.CompositeTypeTorso,
+		d2enum.CompositeTypeRightArm,
+		d2enum.CompositeTypeLeftArm,
+		d2enum.CompositeTypeHead,
+		d2enum.CompositeTypeRightHand,
+		d2enum.CompositeTypeLeftHand,
+		d2enum.CompositeTypeShield,
+		d2enum.CompositeTypeSpecial1,
+		d2enum.CompositeTypeSpecial2,
+		d2enum.CompositeTypeSpecial3,
+		d2enum.CompositeTypeSpecial4,
+		d2enum.CompositeTypeSpecial5,
+		d2enum.CompositeTypeSpecial6,
+		d2enum.CompositeTypeSpecial7,
+		d2enum.CompositeTypeSpecial8,
+	}
+}
+
+// Layer describes a single DCC layer of a new COF
+type Layer struct {
+	Type               d2enum.CompositeType
+	Path               string
+	Directions         int
+	FramesPerDirection int
+}
+
+// ReadDCCHeader reads number of directions and frames per direction from DCC's header.
+// It doesn't decode the whole file.
+func ReadDCCHeader(data []byte) (directions, framesPerDirection int, err error) {
+	if len(data) < dccHeaderSize {
+		return 0, 0, errors.New("DCC file is too short")
+	}
+
+	if data[dccSignatureIdx] != dccSignature {
+		return 0, 0, fmt.Errorf("unexpected DCC signature %#x", data[dccSignatureIdx])
+	}
+
+	directions = int(data[dccDirectionsIdx])
+
+	for i := 3; i >= 0; i-- {
+		framesPerDirection = framesPerDirection<<byteWidth | int(data[dccFramesIdx+i])
+	}
+
+	return directions, framesPerDirection, nil
+}
+
+// CompositeTypeFromCode returns composite type for the given layer code (e.g. HD, TR)
+func CompositeTypeFromCode(code string) (d2enum.CompositeType, bool) {
+	code = strings.ToUpper(code)
+
+	for t := d2enum.CompositeTypeHead; t < d2enum.CompositeTypeMax; t++ {
+		if t.String() == code {
+			return t, true
+		}
+	}
+
+	return 0, false
+}
+
+// MatchDCCPath checks if the given game path is a DCC of the given animation, e.g.
+// data\global\monsters\zm\hd\zmhdlitnuhth.dcc matches token ZM, mode NU and weapon class HTH.
+// Returns the layer type, the DCC belongs to.
+func MatchDCCPath(gamePath, kind, token, mode, weaponClass string) (d2enum.CompositeType, bool) {
+	parts := strings.Split(strings.ToLower(strings.ReplaceAll(gamePath, `\`, "/")), "/")
+	if len(parts) != gamePathDepth {
+		return 0, false
+	}
+
+	kind, token = strings.ToLower(kind), strings.ToLower(token)
+	mode, weaponClass = strings.ToLower(mode), strings.ToLower(weaponClass)
+
+	if parts[kindPathIdx] != kind || parts[tokenPathIdx] != token {
+		return 0, false
+	}
+
+	layerCode := parts[layerPathIdx]
+	if len(layerCode) != layerCodeLen {
+		return 0, false
+	}
+
+	layerType, ok := CompositeTypeFromCode(layerCode)
+	if !ok {
+		return 0, false
+	}
+
+	name := parts[fileNamePathIdx]
+	if path.Ext(name) != dccExtension {
+		return 0, false
+	}
+
+	name = strings.TrimSuffix(name, dccExtension)
+	if !strings.HasPrefix(name, token+layerCode) || !strings.HasSuffix(name, mode+weaponClass) {
+		return 0, false
+	}
+
+	return layerType, true
+}
+
+// New creates a new COF with one layer per DCC layer given.
+// All the layers must have the same number of directions and frames per direction.
+func New(layers []Layer, weaponClass d2enum.WeaponClass) (*d2cof.COF, error) {
+	if len(layers) == 0 {
+		return nil, errors.New("no layers given")
+	}
+
+	sorted := make([]Layer, len(layers))
+	copy(sorted, layers)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].Type < sorted[j].Type })
+
+	directions, frames := sorted[0].Directions, sorted[0].FramesPerDirection
+
+	for _, l := range sorted {
+		if l.Directions != directions || l.FramesPerDirection != frames {
+			return nil, fmt.Errorf("layer %s has %d directions with %d frames, but %d directions with %d frames expected",
+				l.Type, l.Directions, l.FramesPerDirection, directions, frames)
+		}
+	}
+
+	if directions > maxCOFCount || frames > maxCOFCount {
+		return nil, fmt.Errorf("%d directions with %d frames don't fit in a COF (at most %d of each allowed)",
+			directions, frames, maxCOFCount)
+	}
+
+	cof := d2cof.New()
+	cof.Speed = DefaultSpeed
+	cof.NumberOfDirections = directions
+	cof.FramesPerDirection = frames
+	cof.NumberOfLayers = len(sorted)
+	cof.AnimationFrames = make([]d2enum.AnimationFrame, frames)
+
+	for idx, l := range sorted {
+		cof.CofLayers = append(cof.CofLayers, d2cof.CofLayer{
+			Type:        l.Type,
+			Shadow:      DefaultShadow,
+			Selectable:  true,
+			DrawEffect:  d2enum.DrawEffectNone,
+			WeaponClass: weaponClass,
+		})
+
+		cof.CompositeLayers[l.Type] = idx
+	}
+
+	cof.Priority = DefaultPriority(sorted, directions, frames)
+
+	return cof, nil
+}
+
+// DefaultPriority creates a priority table, where every frame draws layers in the default order
+func DefaultPriority(layers []Layer, directions, frames int) [][][]d2enum.CompositeType {
+	order := make([]d2enum.CompositeType, 0, len(layers))
+
+	for _, t := range DefaultDrawOrder() {
+		for _, l := range layers {
+			if l.Type == t {
+				order = append(order, t)
+
+				break
+			}
+		}
+	}
+
+	result := make([][][]d2enum.CompositeType, directions)
+
+	for dir := range result {
+		result[dir] = make([][]d2enum.CompositeType, frames)
+
+		for frame := range result[dir] {
+			result[dir][frame] = make([]d2enum.CompositeType, len(order))
+			copy(result[dir][frame], order)
+		}
+	}
+
+	return result
+}
@@ -0,0 +1,111 @@
+package hscof
+
+import (
+	"testing"
+
+	"github.com/OpenDiablo2/OpenDiablo2/d2common/d2enum"
+)
+
+func Test_ReadDCCHeader(t *testing.T) {
+	tests := []struct {
+		name       string
+		data       []byte
+		directions int
+		frames     int
+		wantErr    bool
+	}{
+		{name: "valid", data: []byte{0x74, 6, 8, 12, 0, 0, 0}, directions: 8, frames: 12},
+		{name: "multi-byte frame count", data: []byte{0x74, 6, 16, 0x2c, 0x01, 0, 0, 0xff}, directions: 16, frames: 300},
+		{name: "too short", data: []byte{0x74, 6, 8, 12}, wantErr: true},
+		{name: "bad signature", data: []byte{0x75, 6, 8, 12, 0, 0, 0}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			directions, frames, err := ReadDCCHeader(tt.data)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if directions != tt.directions || frames != tt.frames {
+				t.Fatalf("got %d directions with %d frames, expected %d with %d", directions, frames, tt.directions, tt.frames)
+			}
+		})
+	}
+}
+
+func Test_MatchDCCPath(t *testing.T) {
+	tests := []struct {
+		name      string
+		path      string
+		layerType d2enum.CompositeType
+		ok        bool
+	}{
+		{name: "match", path: `data\global\monsters\zm\hd\zmhdlitnuhth.dcc`, layerType: d2enum.CompositeTypeHead, ok: true},
+		{name: "match upper case", path: `DATA\GLOBAL\MONSTERS\ZM\TR\ZMTRLITNUHTH.DCC`, layerType: d2enum.CompositeTypeTorso, ok: true},
+		{name: "wrong token", path: `data\global\monsters\sk\hd\skhdlitnuhth.dcc`},
+		{name: "wrong mode", path: `data\global\monsters\zm\hd\zmhdlitwlhth.dcc`},
+		{name: "wrong weapon class", path: `data\global\monsters\zm\hd\zmhdlitnu1hs.dcc`},
+		{name: "wrong kind", path: `data\global\chars\zm\hd\zmhdlitnuhth.dcc`},
+		{name: "wrong depth", path: `data\global\monsters\zm\zmhdlitnuhth.dcc`},
+		{name: "wrong extension", path: `data\global\monsters\zm\hd\zmhdlitnuhth.dc6`},
+		{name: "invalid layer code", path: `data\global\monsters\zm\xx\zmxxlitnuhth.dcc`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			layerType, ok := MatchDCCPath(tt.path, "monsters", "ZM", "NU", "HTH")
+			if ok != tt.ok {
+				t.Fatalf("expected match %t, got %t", tt.ok, ok)
+			}
+
+			if ok && layerType != tt.layerType {
+				t.Fatalf("expected layer %s, got %s", tt.layerType, layerType)
+			}
+		})
+	}
+}
+
+func Test_New(t *testing.T) {
+	layers := []Layer{
+		{Type: d2enum.CompositeTypeTorso, Directions: 8, FramesPerDirection: 12},
+		{Type: d2enum.CompositeTypeHead, Directions: 8, FramesPerDirection: 12},
+	}
+
+	cof, err := New(layers, d2enum.WeaponClassHandToHand)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if cof.NumberOfLayers != 2 || cof.NumberOfDirections != 8 || cof.FramesPerDirection != 12 {
+		t.Fatalf("unexpected COF header: %d layers, %d directions, %d frames",
+			cof.NumberOfLayers, cof.NumberOfDirections, cof.FramesPerDirection)
+	}
+
+	if cof.Speed != DefaultSpeed {
+		t.Fatalf("expected speed %d, got %d", DefaultSpeed, cof.Speed)
+	}
+
+	if cof.CofLayers[0].Type != d2enum.CompositeTypeHead || cof.CofLayers[0].Shadow != DefaultShadow {
+		t.Fatalf("unexpected first layer %+v", cof.CofLayers[0])
+	}
+}
+
+func Test_New_TooManyFrames(t *testing.T) {
+	layers := []Layer{{Type: d2enum.CompositeTypeHead, Directions: 8, FramesPerDirection: maxCOFCount + 1}}
+
+	if _, err := New(layers, d2enum.WeaponClassHandToHand); err == nil {
+		t.Fatal("expected an error for a frame count not fitting a COF")
+	}
+}
+
+func Test_New_MismatchedLayers(t *testing.T) {
+	layers := []Layer{
+		{Type: d2enum.CompositeTypeHead, Directions: 8, FramesPerDirection: 12},
+		{Type: d2enum.CompositeTypeTorso, Directions: 8, FramesPerDirection: 10},
+	}
+
+	if _, err := New(layers, d2enum.WeaponClassHandToHand); err == nil {
+		t.Fatal("expected an error for layers with different frame counts")
+	}
+}
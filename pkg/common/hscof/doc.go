@@ -0,0 +1,2 @@
+// Package hscof contains helpers for creating COF files from a set of DCC layers
+package hscof
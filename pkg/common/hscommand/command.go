@@ -0,0 +1,123 @@
+package hscommand
+
+import (
+	"sort"
+	"strings"
+	"unicode"
+)
+
+// scores of a fuzzy match
+const (
+	scoreMatch       = 1
+	scoreConsecutive = 5
+	scoreWordStart   = 8
+	scorePrefix      = 10
+	penaltyGap       = 1
+)
+
+// Command is an action which can be run from the command palette
+type Command struct {
+	// Category groups commands (e.g. "File", "View" or editor's name)
+	Category string
+	Name     string
+	// Shortcut is a label of command's keyboard shortcut (if any)
+	Shortcut string
+	Run      func()
+}
+
+// Title returns command's title as shown in the palette
+func (c *Command) Title() string {
+	if c.Category == "" {
+		return c.Name
+	}
+
+	return c.Category + ": " + c.Name
+}
+
+// Match checks whether all characters of the query appear in text in the same order
+// (case-insensitive). The higher score, the better match.
+func Match(query, text string) (score int, ok bool) {
+	q := []rune(strings.ToLower(strings.TrimSpace(query)))
+	t := []rune(strings.ToLower(text))
+
+	if len(q) == 0 {
+		return 0, true
+	}
+
+	// matching is greedy, so try all the places where the query may start and take the best one
+	for start := range t {
+		if t[start] != q[0] {
+			continue
+		}
+
+		if s, found := matchFrom(q, t, start); found && (!ok || s > score) {
+			score, ok = s, true
+		}
+	}
+
+	return score, ok
+}
+
+func matchFrom(q, t []rune, start int) (score int, ok bool) {
+	qIdx, last := 0, -1
+
+	for tIdx := start; tIdx < len(t) && qIdx < len(q); tIdx++ {
+		if t[tIdx] != q[qIdx] {
+			continue
+		}
+
+		score += scoreMatch
+
+		switch {
+		case tIdx == 0:
+			score += scorePrefix
+		case !unicode.IsLetter(t[tIdx-1]) && !unicode.IsDigit(t[tIdx-1]):
+			score += scoreWordStart
+		}
+
+		if last >= 0 {
+			if tIdx == last+1 {
+				score += scoreConsecutive
+			} else {
+				score -= penaltyGap
+			}
+		}
+
+		last = tIdx
+		qIdx++
+	}
+
+	return score, qIdx == len(q)
+}
+
+// Search returns commands matching the query, the best matches first.
+// Commands with equal scores keep their order. Limit <= 0 means no limit.
+func Search(commands []Command, query string, limit int) []Command {
+	type scored struct {
+		command Command
+		score   int
+	}
+
+	matches := make([]scored, 0, len(commands))
+
+	for _, command := range commands {
+		if score, ok := Match(query, command.Title()); ok {
+			matches = append(matches, scored{command, score})
+		}
+	}
+
+	sort.SliceStable(matches, func(i, j int) bool {
+		return matches[i].score > matches[j].score
+	})
+
+	if limit > 0 && len(matches) > limit {
+		matches = matches[:limit]
+	}
+
+	result := make([]Command, len(matches))
+	for idx := range matches {
+		result[idx] = matches[idx].command
+	}
+
+	return result
+}
@@ -0,0 +1,47 @@
+package hscommand
+
+import (
+	"testing"
+)
+
+func Test_Match(t *testing.T) {
+	tests := []struct {
+		query, text string
+		ok          bool
+	}{
+		{"", "anything", true},
+		{"tgc", "View: Toggle Console", true},
+		{"CONSOLE", "View: Toggle Console", true},
+		{"xyz", "View: Toggle Console", false},
+		{"elosnoc", "View: Toggle Console", false},
+	}
+
+	for _, test := range tests {
+		if _, ok := Match(test.query, test.text); ok != test.ok {
+			t.Errorf("Match(%q, %q): expected %v", test.query, test.text, test.ok)
+		}
+	}
+}
+
+func Test_Search(t *testing.T) {
+	commands := []Command{
+		{Category: "File", Name: "Open Project"},
+		{Category: "View", Name: "Toggle Console"},
+		{Category: "Open File", Name: "data/global/excel/Cubemain.txt"},
+		{Category: "View", Name: "Toggle Project Explorer"},
+	}
+
+	result := Search(commands, "tog con", 0)
+	if len(result) != 1 || result[0].Name != "Toggle Console" {
+		t.Fatalf("unexpected result: %v", result)
+	}
+
+	result = Search(commands, "proj", 0)
+	if len(result) != 2 || result[0].Name != "Open Project" {
+		t.Fatalf("word start match should win: %v", result)
+	}
+
+	if result = Search(commands, "", 2); len(result) != 2 || result[0].Name != "Open Project" {
+		t.Fatalf("empty query should keep the order and respect limit: %v", result)
+	}
+}
@@ -0,0 +1,3 @@
+// Package hscommand contains commands shown in the command palette and
+// a fuzzy search over them.
+package hscommand
@@ -0,0 +1,39 @@
+package hsd2s
+
+import "errors"
+
+var errUnexpectedEnd = errors.New("unexpected end of data")
+
+// bitReader reads little-endian bit fields (least significant bit first)
+type bitReader struct {
+	data     []byte
+	position int // in bits
+}
+
+func (r *bitReader) read(bits int) (uint32, error) {
+	if r.position+bits > len(r.data)*byteSize {
+		return 0, errUnexpectedEnd
+	}
+
+	var result uint32
+
+	for i := 0; i < bits; i++ {
+		pos := r.position + i
+		bit := (r.data[pos/byteSize] >> (pos % byteSize)) & 1
+		result |= uint32(bit) << i
+	}
+
+	r.position += bits
+
+	return result, nil
+}
+
+// align moves to the beginning of the next byte (if not at a beginning already)
+func (r *bitReader) align() {
+	r.position = (r.position + byteSize - 1) / byteSize * byteSize
+}
+
+// offset returns a number of the byte being read
+func (r *bitReader) offset() int {
+	return r.position / byteSize
+}
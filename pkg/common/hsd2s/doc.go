@@ -0,0 +1,6 @@
+// Package hsd2s reads character saves (.d2s) of Diablo II 1.09 - 1.14.
+// Header, statistics and skills are decoded completely; only basic fields
+// (code, location) of items are read. Simple items are of a fixed size, but
+// the length of the other ones depends on properties defined in excel tables,
+// so that items following them are found by their markers (best effort).
+package hsd2s
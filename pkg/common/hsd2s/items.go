@@ -0,0 +1,241 @@
+package hsd2s
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+)
+
+const (
+	itemMarker = "JM"
+	// minItemSize is a size of the simplest (compact) item. Simple items (except of ears) are always
+	// of this size, size of the other ones depends on their properties.
+	minItemSize = 14
+
+	itemIdentifiedBit   = 20
+	itemSocketedBit     = 27
+	itemEarBit          = 32
+	itemSimpleBit       = 37
+	itemEtherealBit     = 38
+	itemPersonalizedBit = 40
+	itemRunewordBit     = 42
+	itemLocationBit     = 58
+	itemSlotBit         = 61
+	itemXBit            = 65
+	itemYBit            = 69
+	itemPanelBit        = 73
+	itemCodeBit         = 76
+
+	locationBits = 3
+	slotBits     = 4
+	positionBits = 4
+	panelBits    = 3
+	codeSize     = 4
+)
+
+// Location tells where the item is
+type Location byte
+
+// item locations
+const (
+	LocationStored   Location = 0
+	LocationEquipped Location = 1
+	LocationBelt     Location = 2
+	LocationCursor   Location = 4
+	LocationSocketed Location = 6
+)
+
+func (l Location) String() string {
+	switch l {
+	case LocationStored:
+		return "stored"
+	case LocationEquipped:
+		return "equipped"
+	case LocationBelt:
+		return "belt"
+	case LocationCursor:
+		return "cursor"
+	case LocationSocketed:
+		return "socketed"
+	}
+
+	return fmt.Sprintf("unknown location (%d)", l)
+}
+
+// Panel is a storage of stored items
+type Panel byte
+
+// storages
+const (
+	PanelNone      Panel = 0
+	PanelInventory Panel = 1
+	PanelCube      Panel = 4
+	PanelStash     Panel = 5
+)
+
+func (p Panel) String() string {
+	switch p {
+	case PanelNone:
+		return ""
+	case PanelInventory:
+		return "inventory"
+	case PanelCube:
+		return "cube"
+	case PanelStash:
+		return "stash"
+	}
+
+	return fmt.Sprintf("unknown panel (%d)", p)
+}
+
+// Item is an item of the character (incl. items inserted into sockets)
+type Item struct {
+	// Code is a code of the item from armor.txt, weapons.txt or misc.txt (empty for ears)
+	Code     string
+	Location Location
+	// Slot is a body location of equipped items
+	Slot byte
+	// X and Y is a position in the panel or in the belt
+	X, Y  int
+	Panel Panel
+
+	Ear          bool
+	Simple       bool
+	Identified   bool
+	Socketed     bool
+	Ethereal     bool
+	Personalized bool
+	Runeword     bool
+	// Offset is a position of the item in its section
+	Offset int
+	// Approximate is true if the item follows an item of unknown size, so that it was found
+	// by searching for its marker. Such items may be misread (the marker may be a part of another item).
+	Approximate bool
+}
+
+// findItems reads character's items from the item list (starting with "JM" and number of items).
+// Items inserted into sockets aren't counted, but they follow their parents.
+// Simple items are of a fixed size, so that the next item is read right after them; size of the other
+// items depends on excel tables, so that the next item is looked for by its marker (see Item.Approximate).
+func findItems(data []byte) []Item {
+	const headerSize = 4
+
+	result := make([]Item, 0)
+
+	if len(data) < headerSize || string(data[:2]) != itemMarker {
+		return result
+	}
+
+	count := int(binary.LittleEndian.Uint16(data[2:]))
+	found := 0
+	// exact is true if position of the next item is known
+	exact := true
+
+	for pos := headerSize; pos < len(data); {
+		if !bytes.HasPrefix(data[pos:], []byte(itemMarker)) {
+			idx := bytes.Index(data[pos:], []byte(itemMarker))
+			if idx < 0 {
+				break
+			}
+
+			pos += idx
+			exact = false
+		}
+
+		item, ok := readItem(data[pos:])
+		if !ok {
+			pos += len(itemMarker)
+			exact = false
+
+			continue
+		}
+
+		if item.Location != LocationSocketed {
+			if found == count {
+				// next list (e.g. corpse's items)
+				break
+			}
+
+			found++
+		}
+
+		item.Offset = pos
+		item.Approximate = !exact
+		result = append(result, item)
+		pos += minItemSize
+
+		if !item.Simple || item.Ear {
+			exact = false
+		}
+	}
+
+	return result
+}
+
+// readItem reads basic fields of the item. It returns false if data doesn't look like an item.
+func readItem(data []byte) (item Item, ok bool) {
+	if len(data) < minItemSize {
+		return item, false
+	}
+
+	r := &bitReader{data: data}
+
+	field := func(offset, bits int) uint32 {
+		r.position = offset
+		value, _ := r.read(bits) // size is checked above
+
+		return value
+	}
+
+	flag := func(offset int) bool {
+		return field(offset, 1) != 0
+	}
+
+	item = Item{
+		Identified:   flag(itemIdentifiedBit),
+		Socketed:     flag(itemSocketedBit),
+		Ear:          flag(itemEarBit),
+		Simple:       flag(itemSimpleBit),
+		Ethereal:     flag(itemEtherealBit),
+		Personalized: flag(itemPersonalizedBit),
+		Runeword:     flag(itemRunewordBit),
+		Location:     Location(field(itemLocationBit, locationBits)),
+		Slot:         byte(field(itemSlotBit, slotBits)),
+		X:            int(field(itemXBit, positionBits)),
+		Y:            int(field(itemYBit, positionBits)),
+		Panel:        Panel(field(itemPanelBit, panelBits)),
+	}
+
+	switch item.Location {
+	case LocationStored, LocationEquipped, LocationBelt, LocationCursor, LocationSocketed:
+	default:
+		return item, false
+	}
+
+	if item.Ear {
+		return item, true
+	}
+
+	code := make([]byte, codeSize)
+	for i := range code {
+		code[i] = byte(field(itemCodeBit+i*byteSize, byteSize))
+	}
+
+	if !isCodeChar(code[0]) {
+		return item, false
+	}
+
+	for _, c := range code {
+		if !isCodeChar(c) && c != ' ' {
+			return item, false
+		}
+	}
+
+	item.Code = string(bytes.TrimRight(code, " "))
+
+	return item, true
+}
+
+func isCodeChar(c byte) bool {
+	return (c >= 'a' && c <= 'z') || (c >= '0' && c <= '9')
+}
@@ -0,0 +1,271 @@
+package hsd2s
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"time"
+)
+
+const (
+	byteSize = 8
+
+	magic = 0xaa55aa55
+	// versions supported (1.09 and 1.10 - 1.14)
+	version109 = 92
+	version110 = 96
+
+	headerSize      = 765
+	checksumOffset  = 0x0c
+	nameOffset      = 0x14
+	nameSize        = 16
+	statusOffset    = 0x24
+	progressOffset  = 0x25
+	classOffset     = 0x28
+	levelOffset     = 0x2b
+	lastPlayOffset  = 0x30
+	difficultyStart = 0xa8
+	mercDeadOffset  = 0xb1
+	mercIDOffset    = 0xb3
+	mercNameOffset  = 0xb7
+	mercTypeOffset  = 0xb9
+	mercExpOffset   = 0xbb
+
+	numSkills    = 30
+	statIDBits   = 9
+	statsEnd     = 0x1ff
+	numDiffs     = 3
+	activeDiff   = 0x80
+	actMask      = 0x07
+	statusHC     = 0x04
+	statusDied   = 0x08
+	statusExp    = 0x20
+	statusLadder = 0x40
+)
+
+// Class is a character class
+type Class byte
+
+// character classes
+const (
+	ClassAmazon Class = iota
+	ClassSorceress
+	ClassNecromancer
+	ClassPaladin
+	ClassBarbarian
+	ClassDruid
+	ClassAssassin
+)
+
+func (c Class) String() string {
+	names := []string{"Amazon", "Sorceress", "Necromancer", "Paladin", "Barbarian", "Druid", "Assassin"}
+	if int(c) < len(names) {
+		return names[c]
+	}
+
+	return fmt.Sprintf("Unknown class (%d)", c)
+}
+
+// firstSkill returns ID (in skills.txt) of the first skill of the class
+func (c Class) firstSkill() int {
+	offsets := []int{6, 36, 66, 96, 126, 221, 251}
+	if int(c) < len(offsets) {
+		return offsets[c]
+	}
+
+	return 0
+}
+
+// Mercenary is a hireling of the character
+type Mercenary struct {
+	Dead       bool
+	ID         uint32
+	NameID     uint16
+	Type       uint16
+	Experience uint32
+}
+
+// Skill is a level of character's skill
+type Skill struct {
+	// ID is an index of the skill in skills.txt
+	ID    int
+	Level byte
+}
+
+// Save is a character save
+type Save struct {
+	Version       uint32
+	ChecksumValid bool
+	Name          string
+	Class         Class
+	Level         byte
+	Hardcore      bool
+	Died          bool
+	Expansion     bool
+	Ladder        bool
+	// Progression is a number of acts completed (incl. all the difficulties)
+	Progression byte
+	LastPlayed  time.Time
+	// Difficulty is an index of last played difficulty and Act is an act (starting from 1) in that difficulty
+	Difficulty int
+	Act        int
+	Mercenary  Mercenary
+	Stats      []Stat
+	Skills     []Skill
+	Items      []Item
+}
+
+// Load reads a character save
+func Load(data []byte) (*Save, error) {
+	if len(data) < headerSize+2 || binary.LittleEndian.Uint32(data) != magic {
+		return nil, errors.New("not a character save")
+	}
+
+	result := &Save{Version: binary.LittleEndian.Uint32(data[4:])}
+
+	if result.Version != version109 && result.Version != version110 {
+		return nil, fmt.Errorf("unsupported save version %d (only saves of 1.09 - 1.14 are supported)", result.Version)
+	}
+
+	result.ChecksumValid = Checksum(data) == binary.LittleEndian.Uint32(data[checksumOffset:])
+	result.readHeader(data)
+
+	r := &bitReader{data: data, position: headerSize * byteSize}
+
+	if err := result.readStats(r); err != nil {
+		return nil, fmt.Errorf("reading statistics: %w", err)
+	}
+
+	if err := result.readSkills(r); err != nil {
+		return nil, fmt.Errorf("reading skills: %w", err)
+	}
+
+	result.Items = findItems(data[r.offset():])
+
+	return result, nil
+}
+
+func (s *Save) readHeader(data []byte) {
+	s.Name = string(bytes.TrimRight(data[nameOffset:nameOffset+nameSize], "\x00"))
+
+	status := data[statusOffset]
+	s.Hardcore = status&statusHC != 0
+	s.Died = status&statusDied != 0
+	s.Expansion = status&statusExp != 0
+	s.Ladder = status&statusLadder != 0
+
+	s.Progression = data[progressOffset]
+	s.Class = Class(data[classOffset])
+	s.Level = data[levelOffset]
+	s.LastPlayed = time.Unix(int64(binary.LittleEndian.Uint32(data[lastPlayOffset:])), 0)
+
+	for i, d := range data[difficultyStart : difficultyStart+numDiffs] {
+		if d&activeDiff != 0 {
+			s.Difficulty, s.Act = i, int(d&actMask)+1
+		}
+	}
+
+	s.Mercenary = Mercenary{
+		Dead:       binary.LittleEndian.Uint16(data[mercDeadOffset:]) != 0,
+		ID:         binary.LittleEndian.Uint32(data[mercIDOffset:]),
+		NameID:     binary.LittleEndian.Uint16(data[mercNameOffset:]),
+		Type:       binary.LittleEndian.Uint16(data[mercTypeOffset:]),
+		Experience: binary.LittleEndian.Uint32(data[mercExpOffset:]),
+	}
+}
+
+// expectMarker checks a 2-byte section marker at reader's position
+func expectMarker(r *bitReader, marker string) error {
+	offset := r.offset()
+	if offset+len(marker) > len(r.data) || string(r.data[offset:offset+len(marker)]) != marker {
+		return fmt.Errorf("section %q not found at offset %d", marker, offset)
+	}
+
+	r.position += len(marker) * byteSize
+
+	return nil
+}
+
+func (s *Save) readStats(r *bitReader) error {
+	if err := expectMarker(r, "gf"); err != nil {
+		return err
+	}
+
+	for {
+		id, err := r.read(statIDBits)
+		if err != nil {
+			return err
+		}
+
+		if id == statsEnd {
+			break
+		}
+
+		info, found := statInfos[id]
+		if !found {
+			return fmt.Errorf("unknown statistic %d", id)
+		}
+
+		value, err := r.read(info.bits)
+		if err != nil {
+			return err
+		}
+
+		if info.fixedPoint {
+			value >>= byteSize
+		}
+
+		s.Stats = append(s.Stats, Stat{ID: int(id), Value: value})
+	}
+
+	r.align()
+
+	return nil
+}
+
+func (s *Save) readSkills(r *bitReader) error {
+	if err := expectMarker(r, "if"); err != nil {
+		return err
+	}
+
+	offset := r.offset()
+	if offset+numSkills > len(r.data) {
+		return errUnexpectedEnd
+	}
+
+	s.Skills = make([]Skill, numSkills)
+	for i := range s.Skills {
+		s.Skills[i] = Skill{ID: s.Class.firstSkill() + i, Level: r.data[offset+i]}
+	}
+
+	r.position += numSkills * byteSize
+
+	return nil
+}
+
+// Stat returns a value of the statistic given (0 if the character doesn't have it)
+func (s *Save) Stat(id int) uint32 {
+	for _, stat := range s.Stats {
+		if stat.ID == id {
+			return stat.Value
+		}
+	}
+
+	return 0
+}
+
+// Checksum calculates checksum of the save (as if its checksum field was zeroed)
+func Checksum(data []byte) uint32 {
+	var sum uint32
+
+	for i, b := range data {
+		if i >= checksumOffset && i < checksumOffset+4 {
+			b = 0
+		}
+
+		sum = (sum<<1 | sum>>31) + uint32(b)
+	}
+
+	return sum
+}
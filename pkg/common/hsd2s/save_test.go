@@ -0,0 +1,170 @@
+package hsd2s
+
+import (
+	"encoding/binary"
+	"testing"
+)
+
+// bitWriter is the opposite of bitReader
+type bitWriter struct {
+	data     []byte
+	position int
+}
+
+func (w *bitWriter) write(value uint32, bits int) {
+	for i := 0; i < bits; i++ {
+		if w.position/byteSize >= len(w.data) {
+			w.data = append(w.data, 0)
+		}
+
+		w.data[w.position/byteSize] |= byte((value>>i)&1) << (w.position % byteSize)
+		w.position++
+	}
+}
+
+func (w *bitWriter) bytes(b ...byte) {
+	for _, v := range b {
+		w.write(uint32(v), byteSize)
+	}
+}
+
+func (w *bitWriter) align() {
+	for w.position%byteSize != 0 {
+		w.write(0, 1)
+	}
+}
+
+// testItem creates an item of the minimal size; extended items have no properties
+func testItem(simple bool, location Location, x uint32, code string) []byte {
+	item := &bitWriter{data: make([]byte, minItemSize)}
+	item.bytes('J', 'M')
+
+	if simple {
+		item.position = itemSimpleBit
+		item.write(1, 1)
+	}
+
+	item.position = itemLocationBit
+	item.write(uint32(location), locationBits)
+	item.position = itemXBit
+	item.write(x, positionBits)
+	item.position = itemCodeBit
+	item.bytes([]byte(code)...)
+
+	return item.data
+}
+
+func testSave() []byte {
+	w := &bitWriter{data: make([]byte, headerSize)}
+	w.position = headerSize * byteSize
+
+	binary.LittleEndian.PutUint32(w.data, magic)
+	binary.LittleEndian.PutUint32(w.data[4:], version110)
+	copy(w.data[nameOffset:], "Tester")
+	w.data[statusOffset] = statusHC | statusExp
+	w.data[classOffset] = byte(ClassBarbarian)
+	w.data[levelOffset] = 10
+	w.data[difficultyStart+1] = activeDiff | 2
+
+	w.bytes('g', 'f')
+
+	for _, stat := range []struct{ id, value uint32 }{
+		{StatStrength, 30},
+		{StatLife, 50 << byteSize},
+		{StatExperience, 1000},
+	} {
+		w.write(stat.id, statIDBits)
+		w.write(stat.value, statInfos[stat.id].bits)
+	}
+
+	w.write(statsEnd, statIDBits)
+	w.align()
+
+	w.bytes('i', 'f')
+
+	skills := make([]byte, numSkills)
+	skills[3] = 5
+	w.bytes(skills...)
+
+	w.bytes('J', 'M', 1, 0)
+
+	// minor healing potion in the belt
+	w.bytes(testItem(true, LocationBelt, 3, "hp1 ")...)
+
+	// corpse's items
+	w.bytes('J', 'M', 0, 0)
+
+	binary.LittleEndian.PutUint32(w.data[8:], uint32(len(w.data)))
+	binary.LittleEndian.PutUint32(w.data[checksumOffset:], Checksum(w.data))
+
+	return w.data
+}
+
+func Test_Load(t *testing.T) {
+	save, err := Load(testSave())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !save.ChecksumValid || save.Name != "Tester" || save.Class != ClassBarbarian || save.Level != 10 ||
+		!save.Hardcore || !save.Expansion || save.Died || save.Difficulty != 1 || save.Act != 3 {
+		t.Errorf("unexpected header %+v", save)
+	}
+
+	if save.Stat(StatStrength) != 30 || save.Stat(StatLife) != 50 || save.Stat(StatExperience) != 1000 {
+		t.Errorf("unexpected statistics %v", save.Stats)
+	}
+
+	if len(save.Skills) != numSkills || save.Skills[3].Level != 5 || save.Skills[3].ID != 129 {
+		t.Errorf("unexpected skills %v", save.Skills)
+	}
+
+	if len(save.Items) != 1 {
+		t.Fatalf("unexpected items %+v", save.Items)
+	}
+
+	if item := save.Items[0]; item.Code != "hp1" || item.Location != LocationBelt || item.X != 3 || !item.Simple {
+		t.Errorf("unexpected item %+v", item)
+	}
+
+	data := testSave()
+	data[nameOffset] = 'X'
+
+	if save, err := Load(data); err != nil || save.ChecksumValid {
+		t.Errorf("checksum of modified save should be invalid (%v)", err)
+	}
+
+	binary.LittleEndian.PutUint32(data[4:], 97)
+
+	if _, err := Load(data); err == nil {
+		t.Error("saves of unsupported versions shouldn't be loaded")
+	}
+}
+
+func Test_findItems(t *testing.T) {
+	data := []byte{'J', 'M', 3, 0}
+	data = append(data, testItem(true, LocationStored, 1, "hp1 ")...)
+	// extended items are longer than simple ones
+	data = append(data, testItem(false, LocationStored, 2, "cap ")...)
+	data = append(data, 0, 0, 0)
+	data = append(data, testItem(true, LocationStored, 3, "mp1 ")...)
+
+	items := findItems(data)
+	if len(items) != 3 {
+		t.Fatalf("unexpected items %+v", items)
+	}
+
+	for i, expected := range []struct {
+		code        string
+		offset      int
+		approximate bool
+	}{
+		{"hp1", 4, false},
+		{"cap", 4 + minItemSize, false},
+		{"mp1", 4 + 2*minItemSize + 3, true},
+	} {
+		if item := items[i]; item.Code != expected.code || item.Offset != expected.offset || item.Approximate != expected.approximate {
+			t.Errorf("unexpected item %d: %+v", i, item)
+		}
+	}
+}
@@ -0,0 +1,69 @@
+package hsd2s
+
+import "fmt"
+
+// Stat is a value of character's statistic
+type Stat struct {
+	// ID is an index of the statistic in itemstatcost.txt
+	ID    int
+	Value uint32
+}
+
+// Name returns a name of the statistic (as in itemstatcost.txt)
+func (s Stat) Name() string {
+	if info, found := statInfos[uint32(s.ID)]; found { //nolint:gosec // IDs are 9-bit
+		return info.name
+	}
+
+	return fmt.Sprintf("stat %d", s.ID)
+}
+
+// statistics
+const (
+	StatStrength = iota
+	StatEnergy
+	StatDexterity
+	StatVitality
+	StatStatPoints
+	StatSkillPoints
+	StatLife
+	StatMaxLife
+	StatMana
+	StatMaxMana
+	StatStamina
+	StatMaxStamina
+	StatLevel
+	StatExperience
+	StatGold
+	StatGoldBank
+)
+
+type statInfo struct {
+	name string
+	// bits is a number of bits of the value (CSvBits column of itemstatcost.txt)
+	bits int
+	// fixedPoint values have 8 bits of a fraction
+	fixedPoint bool
+}
+
+// statInfos are statistics stored in saves
+//
+//nolint:mnd // sizes of values
+var statInfos = map[uint32]statInfo{
+	StatStrength:    {"strength", 10, false},
+	StatEnergy:      {"energy", 10, false},
+	StatDexterity:   {"dexterity", 10, false},
+	StatVitality:    {"vitality", 10, false},
+	StatStatPoints:  {"statpts", 10, false},
+	StatSkillPoints: {"newskills", 8, false},
+	StatLife:        {"hitpoints", 21, true},
+	StatMaxLife:     {"maxhp", 21, true},
+	StatMana:        {"mana", 21, true},
+	StatMaxMana:     {"maxmana", 21, true},
+	StatStamina:     {"stamina", 21, true},
+	StatMaxStamina:  {"maxstamina", 21, true},
+	StatLevel:       {"level", 7, false},
+	StatExperience:  {"experience", 32, false},
+	StatGold:        {"gold", 25, false},
+	StatGoldBank:    {"goldbank", 25, false},
+}
@@ -0,0 +1,124 @@
+package hsdiff
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"sort"
+)
+
+// FileChange is a file, which differs between two sources
+type FileChange struct {
+	// Path is a normalized path of the file
+	Path   string `json:"path"`
+	Status Status `json:"status"`
+	// PathA and PathB are paths of the file in the compared sources
+	// (empty if the file doesn't exist in the source)
+	PathA string `json:"pathA,omitempty"`
+	PathB string `json:"pathB,omitempty"`
+	SizeA int    `json:"sizeA"`
+	SizeB int    `json:"sizeB"`
+}
+
+// ProgressFunc is called after each compared file
+type ProgressFunc func(done, total int)
+
+// Compare lists files added, removed and changed in source b relative to source a.
+// Files existing in both sources are changed, if they have different size or content.
+// Progress may be nil.
+func Compare(ctx context.Context, a, b Source, progress ProgressFunc) ([]FileChange, error) {
+	filesA, err := a.Files()
+	if err != nil {
+		return nil, fmt.Errorf("error listing files of %s: %w", a.Name(), err)
+	}
+
+	filesB, err := b.Files()
+	if err != nil {
+		return nil, fmt.Errorf("error listing files of %s: %w", b.Name(), err)
+	}
+
+	paths := make([]string, 0, len(filesA)+len(filesB))
+
+	for path := range filesA {
+		paths = append(paths, path)
+	}
+
+	for path := range filesB {
+		if _, found := filesA[path]; !found {
+			paths = append(paths, path)
+		}
+	}
+
+	sort.Strings(paths)
+
+	result := make([]FileChange, 0)
+
+	for idx, path := range paths {
+		if err := ctx.Err(); err != nil {
+			return result, fmt.Errorf("comparison canceled: %w", err)
+		}
+
+		change, err := compareFile(a, b, path, filesA[path], filesB[path])
+		if err != nil {
+			return result, err
+		}
+
+		if change.Status != StatusUnchanged {
+			result = append(result, change)
+		}
+
+		if progress != nil {
+			progress(idx+1, len(paths))
+		}
+	}
+
+	return result, nil
+}
+
+func compareFile(a, b Source, path, pathA, pathB string) (FileChange, error) {
+	result := FileChange{
+		Path:  path,
+		PathA: pathA,
+		PathB: pathB,
+	}
+
+	var err error
+
+	if pathA != "" {
+		if result.SizeA, err = a.FileSize(pathA); err != nil {
+			return result, err
+		}
+	}
+
+	if pathB != "" {
+		if result.SizeB, err = b.FileSize(pathB); err != nil {
+			return result, err
+		}
+	}
+
+	switch {
+	case pathA == "":
+		result.Status = StatusAdded
+	case pathB == "":
+		result.Status = StatusRemoved
+	case result.SizeA != result.SizeB:
+		result.Status = StatusChanged
+	default:
+		// contents are read only if they may be the same
+		dataA, err := a.ReadFile(pathA)
+		if err != nil {
+			return result, err
+		}
+
+		dataB, err := b.ReadFile(pathB)
+		if err != nil {
+			return result, err
+		}
+
+		if !bytes.Equal(dataA, dataB) {
+			result.Status = StatusChanged
+		}
+	}
+
+	return result, nil
+}
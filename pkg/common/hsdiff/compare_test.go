@@ -0,0 +1,79 @@
+package hsdiff
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func writeFiles(t *testing.T, root string, files map[string]string) {
+	t.Helper()
+
+	for name, data := range files {
+		path := filepath.Join(root, filepath.FromSlash(name))
+
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+
+		if err := os.WriteFile(path, []byte(data), 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func Test_Compare(t *testing.T) {
+	dirA, dirB := t.TempDir(), t.TempDir()
+
+	writeFiles(t, dirA, map[string]string{
+		"data/global/excel/armor.txt":   "armor",
+		"data/global/excel/weapons.txt": "weapons",
+		"data/local/removed.tbl":        "removed",
+		"same.txt":                      "same",
+	})
+
+	writeFiles(t, dirB, map[string]string{
+		"global/excel/Armor.txt":   "armor2",
+		"global/excel/weapons.txt": "WEAPONS",
+		"global/added.dc6":         "added",
+		"same.txt":                 "same",
+		".hidden":                  "hidden",
+	})
+
+	changes, err := Compare(context.Background(), NewDirSource("a", dirA), NewDirSource("b", dirB), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := []struct {
+		path   string
+		status Status
+	}{
+		{"global/added.dc6", StatusAdded},
+		{"global/excel/armor.txt", StatusChanged},
+		{"global/excel/weapons.txt", StatusChanged},
+		{"local/removed.tbl", StatusRemoved},
+	}
+
+	if len(changes) != len(expected) {
+		t.Fatalf("expected %d changes, got %d: %v", len(expected), len(changes), changes)
+	}
+
+	for i, e := range expected {
+		if changes[i].Path != e.path || changes[i].Status != e.status {
+			t.Errorf("change %d: expected %s %s, got %s %s", i, e.status, e.path, changes[i].Status, changes[i].Path)
+		}
+	}
+
+	if changes[1].SizeA != len("armor") || changes[1].SizeB != len("armor2") {
+		t.Errorf("unexpected sizes of %s: %d and %d", changes[1].Path, changes[1].SizeA, changes[1].SizeB)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	if _, err := Compare(ctx, NewDirSource("a", dirA), NewDirSource("b", dirB), nil); err == nil {
+		t.Error("expected an error of canceled comparison")
+	}
+}
@@ -0,0 +1,157 @@
+package hsdiff
+
+import (
+	"bytes"
+	"fmt"
+	"path/filepath"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/OpenDiablo2/OpenDiablo2/d2common/d2fileformats/d2dat"
+	"github.com/OpenDiablo2/OpenDiablo2/d2common/d2fileformats/d2tbl"
+	"github.com/OpenDiablo2/OpenDiablo2/d2common/d2interface"
+)
+
+const paletteSize = 256 * 3
+
+// FileDiff is a diff of two versions of a file. Depending on the format,
+// one of Lines, Keys, Table or Colors is set.
+type FileDiff struct {
+	Format    Format        `json:"format"`
+	Identical bool          `json:"identical"`
+	SizeA     int           `json:"sizeA"`
+	SizeB     int           `json:"sizeB"`
+	Lines     []LineChange  `json:"lines,omitempty"`
+	Keys      []KeyChange   `json:"keys,omitempty"`
+	Table     *TableDiff    `json:"table,omitempty"`
+	Colors    []ColorChange `json:"colors,omitempty"`
+}
+
+// Diff compares two versions of a file. The format is detected using file's name and data.
+// Version a or b is nil, if the file doesn't exist.
+func Diff(name string, a, b []byte) (*FileDiff, error) {
+	result := &FileDiff{
+		Format:    DetectFormat(name, a, b),
+		Identical: bytes.Equal(a, b),
+		SizeA:     len(a),
+		SizeB:     len(b),
+	}
+
+	switch result.Format {
+	case FormatText:
+		result.Lines = Lines(string(a), string(b))
+	case FormatTable:
+		result.Table = Table(string(a), string(b))
+	case FormatStringTable:
+		dictA, err := loadTextDictionary(a)
+		if err != nil {
+			return nil, err
+		}
+
+		dictB, err := loadTextDictionary(b)
+		if err != nil {
+			return nil, err
+		}
+
+		result.Keys = TextDictionary(dictA, dictB)
+	case FormatPalette:
+		paletteA, err := loadPalette(a)
+		if err != nil {
+			return nil, err
+		}
+
+		paletteB, err := loadPalette(b)
+		if err != nil {
+			return nil, err
+		}
+
+		result.Colors = Palette(paletteA, paletteB)
+	case FormatBinary:
+		// only sizes are compared
+	}
+
+	return result, nil
+}
+
+// DetectFormat returns a format, which should be used to compare the file
+func DetectFormat(name string, a, b []byte) Format {
+	versions := make([][]byte, 0, 2)
+
+	for _, data := range [][]byte{a, b} {
+		if data != nil {
+			versions = append(versions, data)
+		}
+	}
+
+	all := func(fn func(data []byte) bool) bool {
+		for _, data := range versions {
+			if !fn(data) {
+				return false
+			}
+		}
+
+		return true
+	}
+
+	switch strings.ToLower(filepath.Ext(name)) {
+	case ".tbl":
+		if all(func(data []byte) bool { _, err := d2tbl.LoadTextDictionary(data); return err == nil }) {
+			return FormatStringTable
+		}
+	case ".dat":
+		if all(func(data []byte) bool { return len(data) >= paletteSize }) {
+			return FormatPalette
+		}
+	case ".txt":
+		if all(isText) && all(isTable) {
+			return FormatTable
+		}
+	}
+
+	if all(isText) {
+		return FormatText
+	}
+
+	return FormatBinary
+}
+
+func isText(data []byte) bool {
+	return utf8.Valid(data) && !bytes.ContainsRune(data, 0)
+}
+
+// isTable returns true if the header line has more than one column
+func isTable(data []byte) bool {
+	header, _, _ := bytes.Cut(data, []byte("\n"))
+
+	return bytes.ContainsRune(header, '\t')
+}
+
+func loadTextDictionary(data []byte) (d2tbl.TextDictionary, error) {
+	if data == nil {
+		return d2tbl.TextDictionary{}, nil
+	}
+
+	result, err := d2tbl.LoadTextDictionary(data)
+	if err != nil {
+		return nil, fmt.Errorf("error loading string table: %w", err)
+	}
+
+	return result, nil
+}
+
+func loadPalette(data []byte) (*[256]d2interface.Color, error) {
+	result := &[256]d2interface.Color{}
+
+	if data == nil {
+		return result, nil
+	}
+
+	palette, err := d2dat.Load(data)
+	if err != nil {
+		return nil, fmt.Errorf("error loading palette: %w", err)
+	}
+
+	*result = palette.GetColors()
+
+	return result, nil
+}
@@ -0,0 +1,3 @@
+// Package hsdiff compares sets of files (MPQ archives, project's content)
+// and contents of single files (text, string tables, palettes).
+package hsdiff
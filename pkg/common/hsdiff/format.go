@@ -0,0 +1,25 @@
+package hsdiff
+
+// Format is a format of compared files, which decides how they are compared
+//
+//go:generate stringer -linecomment -type Format -output format_string.go
+type Format byte
+
+// formats
+const (
+	FormatBinary      Format = iota // binary
+	FormatText                      // text
+	FormatStringTable               // string table
+	FormatTable                     // table
+	FormatPalette                   // palette
+)
+
+// MarshalText encodes the format as its name
+func (f Format) MarshalText() ([]byte, error) {
+	return []byte(f.String()), nil
+}
+
+// MarshalText encodes the status as its name
+func (s Status) MarshalText() ([]byte, error) {
+	return []byte(s.String()), nil
+}
@@ -0,0 +1,135 @@
+package hsdiff
+
+import "strings"
+
+// maxLCSSize limits memory used to compare lines; if the changed part of texts is bigger,
+// it is reported as removed and added as a whole
+const maxLCSSize = 1 << 22
+
+// LineChange is a single line of the line diff
+type LineChange struct {
+	// Status is StatusUnchanged, StatusAdded or StatusRemoved
+	Status Status `json:"status"`
+	Text   string `json:"text"`
+	// LineA and LineB are line numbers (starting from 1) in the compared texts
+	// (0 if the line doesn't exist in the text)
+	LineA int `json:"lineA"`
+	LineB int `json:"lineB"`
+}
+
+// Lines compares texts line by line. All the lines of both texts are returned.
+func Lines(a, b string) []LineChange {
+	linesA, linesB := splitLines(a), splitLines(b)
+
+	prefix := 0
+	for prefix < len(linesA) && prefix < len(linesB) && linesA[prefix] == linesB[prefix] {
+		prefix++
+	}
+
+	suffix := 0
+	for suffix < len(linesA)-prefix && suffix < len(linesB)-prefix &&
+		linesA[len(linesA)-1-suffix] == linesB[len(linesB)-1-suffix] {
+		suffix++
+	}
+
+	result := make([]LineChange, 0, len(linesA)+len(linesB)-prefix-suffix)
+
+	for i := 0; i < prefix; i++ {
+		result = append(result, LineChange{Status: StatusUnchanged, Text: linesA[i], LineA: i + 1, LineB: i + 1})
+	}
+
+	result = append(result, diffLines(
+		linesA[prefix:len(linesA)-suffix],
+		linesB[prefix:len(linesB)-suffix],
+		prefix, prefix,
+	)...)
+
+	for i := suffix; i > 0; i-- {
+		idxA, idxB := len(linesA)-i, len(linesB)-i
+		result = append(result, LineChange{Status: StatusUnchanged, Text: linesA[idxA], LineA: idxA + 1, LineB: idxB + 1})
+	}
+
+	return result
+}
+
+func splitLines(s string) []string {
+	if s == "" {
+		return nil
+	}
+
+	result := strings.Split(strings.TrimSuffix(s, "\n"), "\n")
+
+	for i := range result {
+		result[i] = strings.TrimSuffix(result[i], "\r")
+	}
+
+	return result
+}
+
+// diffLines finds the longest common subsequence of lines; offsets are numbers of lines preceding a and b
+func diffLines(a, b []string, offsetA, offsetB int) []LineChange {
+	result := make([]LineChange, 0, len(a)+len(b))
+
+	removed := func(i int) {
+		result = append(result, LineChange{Status: StatusRemoved, Text: a[i], LineA: offsetA + i + 1})
+	}
+
+	added := func(j int) {
+		result = append(result, LineChange{Status: StatusAdded, Text: b[j], LineB: offsetB + j + 1})
+	}
+
+	if len(a) == 0 || len(b) == 0 || len(a)*len(b) > maxLCSSize {
+		for i := range a {
+			removed(i)
+		}
+
+		for j := range b {
+			added(j)
+		}
+
+		return result
+	}
+
+	// lcs[i][j] is a length of the longest common subsequence of a[i:] and b[j:]
+	width := len(b) + 1
+	lcs := make([]int32, (len(a)+1)*width)
+
+	for i := len(a) - 1; i >= 0; i-- {
+		for j := len(b) - 1; j >= 0; j-- {
+			switch {
+			case a[i] == b[j]:
+				lcs[i*width+j] = lcs[(i+1)*width+j+1] + 1
+			case lcs[(i+1)*width+j] >= lcs[i*width+j+1]:
+				lcs[i*width+j] = lcs[(i+1)*width+j]
+			default:
+				lcs[i*width+j] = lcs[i*width+j+1]
+			}
+		}
+	}
+
+	i, j := 0, 0
+	for i < len(a) && j < len(b) {
+		switch {
+		case a[i] == b[j]:
+			result = append(result, LineChange{Status: StatusUnchanged, Text: a[i], LineA: offsetA + i + 1, LineB: offsetB + j + 1})
+			i++
+			j++
+		case lcs[(i+1)*width+j] >= lcs[i*width+j+1]:
+			removed(i)
+			i++
+		default:
+			added(j)
+			j++
+		}
+	}
+
+	for ; i < len(a); i++ {
+		removed(i)
+	}
+
+	for ; j < len(b); j++ {
+		added(j)
+	}
+
+	return result
+}
@@ -0,0 +1,72 @@
+package hsdiff
+
+import (
+	"strings"
+	"testing"
+)
+
+func Test_Lines(t *testing.T) {
+	a := "name\tcode\r\nsword\tswd\r\naxe\taxe\r\nbow\tbow\r\n"
+	b := "name\tcode\nsword\tswd\nclub\tclb\nbow\tbow\nstaff\tstf\n"
+
+	changes := Lines(a, b)
+
+	got := make([]string, len(changes))
+
+	for i, c := range changes {
+		prefix := " "
+
+		switch c.Status {
+		case StatusAdded:
+			prefix = "+"
+		case StatusRemoved:
+			prefix = "-"
+		}
+
+		got[i] = prefix + strings.ReplaceAll(c.Text, "\t", " ")
+	}
+
+	expected := []string{
+		" name code",
+		" sword swd",
+		"-axe axe",
+		"+club clb",
+		" bow bow",
+		"+staff stf",
+	}
+
+	if strings.Join(got, "\n") != strings.Join(expected, "\n") {
+		t.Fatalf("unexpected diff:\n%s", strings.Join(got, "\n"))
+	}
+
+	if last := changes[len(changes)-1]; last.LineA != 0 || last.LineB != 5 {
+		t.Errorf("unexpected line numbers of the last line: %d, %d", last.LineA, last.LineB)
+	}
+
+	if bow := changes[4]; bow.LineA != 4 || bow.LineB != 4 {
+		t.Errorf("unexpected line numbers of unchanged line: %d, %d", bow.LineA, bow.LineB)
+	}
+}
+
+func Test_TextDictionary(t *testing.T) {
+	changes := TextDictionary(
+		map[string]string{"a": "1", "b": "2", "c": "3"},
+		map[string]string{"a": "1", "b": "two", "d": "4"},
+	)
+
+	expected := []KeyChange{
+		{Key: "b", Status: StatusChanged, Old: "2", New: "two"},
+		{Key: "c", Status: StatusRemoved, Old: "3"},
+		{Key: "d", Status: StatusAdded, New: "4"},
+	}
+
+	if len(changes) != len(expected) {
+		t.Fatalf("expected %d changes, got %v", len(expected), changes)
+	}
+
+	for i := range expected {
+		if changes[i] != expected[i] {
+			t.Errorf("expected %v, got %v", expected[i], changes[i])
+		}
+	}
+}
@@ -0,0 +1,36 @@
+package hsdiff
+
+import (
+	"image/color"
+
+	"github.com/OpenDiablo2/OpenDiablo2/d2common/d2interface"
+)
+
+// ColorChange is a palette's color, which differs between two palettes
+type ColorChange struct {
+	Index int        `json:"index"`
+	Old   color.RGBA `json:"old"`
+	New   color.RGBA `json:"new"`
+}
+
+// Palette compares palettes index by index. Only changed colors are returned.
+func Palette(a, b *[256]d2interface.Color) []ColorChange {
+	result := make([]ColorChange, 0)
+
+	for idx := range a {
+		oldColor, newColor := toRGBA(a[idx]), toRGBA(b[idx])
+		if oldColor != newColor {
+			result = append(result, ColorChange{Index: idx, Old: oldColor, New: newColor})
+		}
+	}
+
+	return result
+}
+
+func toRGBA(c d2interface.Color) color.RGBA {
+	if c == nil {
+		return color.RGBA{}
+	}
+
+	return color.RGBA{R: c.R(), G: c.G(), B: c.B(), A: c.A()}
+}
@@ -0,0 +1,181 @@
+package hsdiff
+
+import (
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/OpenDiablo2/OpenDiablo2/d2common/d2interface"
+
+	"github.com/gucio321/HellSpawner/pkg/common/hsmpq"
+)
+
+// Source is a set of files to compare
+type Source interface {
+	// Name returns a name of the source displayed to user
+	Name() string
+	// Files returns source's files; keys are normalized paths (see NormalizePath),
+	// values are paths, which can be passed to ReadFile
+	Files() (map[string]string, error)
+	// ReadFile reads the file given by source's path
+	ReadFile(path string) ([]byte, error)
+	// FileSize returns a size of the file given by source's path, without reading it
+	FileSize(path string) (int, error)
+}
+
+// NormalizePath converts game path (e.g. data\global\excel\armor.txt)
+// or path relative to project's content (e.g. global/excel/armor.txt)
+// to the common form (global/excel/armor.txt)
+func NormalizePath(path string) string {
+	path = strings.ToLower(strings.ReplaceAll(path, `\`, "/"))
+	path = strings.Trim(path, "/")
+
+	return strings.TrimPrefix(path, "data/")
+}
+
+var _ Source = &MPQSource{}
+
+// MPQSource is an MPQ archive
+type MPQSource struct {
+	archive d2interface.Archive
+	files   []string
+
+	// sizes of files are read from archive's block table, when the first one is needed
+	sizesOnce sync.Once
+	sizes     map[string]int
+	sizesErr  error
+}
+
+// NewMPQSource creates a new MPQ source. Files are paths of archive's files
+// (archive's listfile or files found using an external listfile).
+func NewMPQSource(archive d2interface.Archive, files []string) *MPQSource {
+	return &MPQSource{
+		archive: archive,
+		files:   files,
+	}
+}
+
+// Name returns archive's file name
+func (s *MPQSource) Name() string {
+	return filepath.Base(s.archive.Path())
+}
+
+// Files returns archive's files
+func (s *MPQSource) Files() (map[string]string, error) {
+	result := make(map[string]string, len(s.files))
+
+	for _, path := range s.files {
+		result[NormalizePath(path)] = path
+	}
+
+	return result, nil
+}
+
+// ReadFile reads the file from the archive
+func (s *MPQSource) ReadFile(path string) ([]byte, error) {
+	data, err := s.archive.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("error reading %s from %s: %w", path, s.Name(), err)
+	}
+
+	return data, nil
+}
+
+// FileSize returns the size of the file listed by Files
+func (s *MPQSource) FileSize(path string) (int, error) {
+	s.sizesOnce.Do(func() {
+		s.sizes, s.sizesErr = hsmpq.ReadFileSizes(s.archive.Path(), s.files)
+	})
+
+	if s.sizesErr != nil {
+		return 0, fmt.Errorf("error reading sizes of files in %s: %w", s.Name(), s.sizesErr)
+	}
+
+	size, found := s.sizes[path]
+	if !found {
+		return 0, fmt.Errorf("%s not found in %s", path, s.Name())
+	}
+
+	return size, nil
+}
+
+var _ Source = &DirSource{}
+
+// DirSource is a directory (e.g. project's content). Hidden files (starting with a dot) are skipped.
+type DirSource struct {
+	name string
+	root string
+}
+
+// NewDirSource creates a new directory source
+func NewDirSource(name, root string) *DirSource {
+	return &DirSource{
+		name: name,
+		root: root,
+	}
+}
+
+// Name returns source's name
+func (s *DirSource) Name() string {
+	return s.name
+}
+
+// Files returns files of the directory
+func (s *DirSource) Files() (map[string]string, error) {
+	result := make(map[string]string)
+
+	err := filepath.WalkDir(s.root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+
+		if path != s.root && strings.HasPrefix(d.Name(), ".") {
+			if d.IsDir() {
+				return filepath.SkipDir
+			}
+
+			return nil
+		}
+
+		if d.IsDir() {
+			return nil
+		}
+
+		rel, err := filepath.Rel(s.root, path)
+		if err != nil {
+			return err
+		}
+
+		result[NormalizePath(filepath.ToSlash(rel))] = path
+
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error reading %s: %w", s.root, err)
+	}
+
+	return result, nil
+}
+
+// ReadFile reads the file
+func (s *DirSource) ReadFile(path string) ([]byte, error) {
+	data, err := os.ReadFile(filepath.Clean(path))
+	if err != nil {
+		return nil, fmt.Errorf("error reading %s: %w", path, err)
+	}
+
+	return data, nil
+}
+
+// FileSize returns the size of the file
+func (s *DirSource) FileSize(path string) (int, error) {
+	info, err := os.Stat(filepath.Clean(path))
+	if err != nil {
+		return 0, fmt.Errorf("error reading %s: %w", path, err)
+	}
+
+	return int(info.Size()), nil
+}
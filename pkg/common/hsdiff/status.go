@@ -0,0 +1,14 @@
+package hsdiff
+
+// Status describes how a file (or a line, key, etc.) differs between two versions
+//
+//go:generate stringer -linecomment -type Status -output status_string.go
+type Status byte
+
+// statuses
+const (
+	StatusUnchanged Status = iota // Unchanged
+	StatusAdded                   // Added
+	StatusRemoved                 // Removed
+	StatusChanged                 // Changed
+)
@@ -0,0 +1,157 @@
+package hsdiff
+
+import (
+	"strconv"
+	"strings"
+)
+
+// TableDiff is a diff of tab separated tables (e.g. data\global\excel\*.txt).
+// Columns are matched by their headers and rows by their first column.
+type TableDiff struct {
+	AddedColumns   []string    `json:"addedColumns,omitempty"`
+	RemovedColumns []string    `json:"removedColumns,omitempty"`
+	Rows           []RowChange `json:"rows,omitempty"`
+}
+
+// RowChange is a row, which differs between two tables
+type RowChange struct {
+	Key    string `json:"key"`
+	Status Status `json:"status"`
+	// Cells are changed cells; for added and removed rows these are all the non-empty cells
+	Cells []CellChange `json:"cells"`
+}
+
+// CellChange is a changed cell of the row
+type CellChange struct {
+	Column string `json:"column"`
+	Old    string `json:"old"`
+	New    string `json:"new"`
+}
+
+// table is a parsed tab separated table
+type table struct {
+	columns []string
+	keys    []string
+	rows    map[string]map[string]string
+}
+
+// uniqueKey returns the key, or the key with a number of its occurrence, if it was already used
+// (e.g. "Expansion" rows of excel files)
+func uniqueKey(key string, used map[string]int) string {
+	used[key]++
+
+	if n := used[key]; n > 1 {
+		return key + "#" + strconv.Itoa(n)
+	}
+
+	return key
+}
+
+func parseTable(text string) *table {
+	lines := splitLines(text)
+
+	result := &table{
+		rows: make(map[string]map[string]string),
+	}
+
+	if len(lines) == 0 {
+		return result
+	}
+
+	usedColumns := make(map[string]int)
+	for _, column := range strings.Split(lines[0], "\t") {
+		result.columns = append(result.columns, uniqueKey(column, usedColumns))
+	}
+
+	usedKeys := make(map[string]int)
+
+	for _, line := range lines[1:] {
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+
+		cells := strings.Split(line, "\t")
+		key := uniqueKey(cells[0], usedKeys)
+		row := make(map[string]string, len(cells))
+
+		for i, cell := range cells {
+			if i < len(result.columns) {
+				row[result.columns[i]] = cell
+			}
+		}
+
+		result.keys = append(result.keys, key)
+		result.rows[key] = row
+	}
+
+	return result
+}
+
+// Table compares tab separated tables
+func Table(a, b string) *TableDiff {
+	tableA, tableB := parseTable(a), parseTable(b)
+	result := &TableDiff{}
+
+	columnsA := make(map[string]bool, len(tableA.columns))
+	for _, column := range tableA.columns {
+		columnsA[column] = true
+	}
+
+	columnsB := make(map[string]bool, len(tableB.columns))
+	for _, column := range tableB.columns {
+		columnsB[column] = true
+	}
+
+	for _, column := range tableA.columns {
+		if !columnsB[column] {
+			result.RemovedColumns = append(result.RemovedColumns, column)
+		}
+	}
+
+	// rows present in both tables are compared using common columns only;
+	// added and removed columns are reported once for the whole table
+	common := make([]string, 0, len(tableB.columns))
+
+	for _, column := range tableB.columns {
+		if columnsA[column] {
+			common = append(common, column)
+		} else {
+			result.AddedColumns = append(result.AddedColumns, column)
+		}
+	}
+
+	for _, key := range tableA.keys {
+		rowA := tableA.rows[key]
+
+		rowB, found := tableB.rows[key]
+		if !found {
+			result.Rows = append(result.Rows, RowChange{Key: key, Status: StatusRemoved, Cells: compareRows(tableA.columns, rowA, nil)})
+
+			continue
+		}
+
+		if cells := compareRows(common, rowA, rowB); len(cells) > 0 {
+			result.Rows = append(result.Rows, RowChange{Key: key, Status: StatusChanged, Cells: cells})
+		}
+	}
+
+	for _, key := range tableB.keys {
+		if _, found := tableA.rows[key]; !found {
+			result.Rows = append(result.Rows, RowChange{Key: key, Status: StatusAdded, Cells: compareRows(tableB.columns, nil, tableB.rows[key])})
+		}
+	}
+
+	return result
+}
+
+func compareRows(columns []string, a, b map[string]string) []CellChange {
+	result := make([]CellChange, 0)
+
+	for _, column := range columns {
+		if a[column] != b[column] {
+			result = append(result, CellChange{Column: column, Old: a[column], New: b[column]})
+		}
+	}
+
+	return result
+}
@@ -0,0 +1,107 @@
+package hsdiff
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func Test_Table(t *testing.T) {
+	a := strings.Join([]string{
+		"name\tcode\tlevel\tspeed",
+		"Sword\tswd\t1\t0",
+		"Axe\taxe\t3\t10",
+		"Expansion",
+		"Club\tclb\t2\t0",
+		"Expansion",
+		"Bow\tbow\t5\t0",
+	}, "\r\n")
+
+	b := strings.Join([]string{
+		"name\tcode\tlevel\tdurability",
+		"Sword\tswd\t2\t100",
+		"Expansion",
+		"Club\tclb\t2\t",
+		"Expansion",
+		"Expansion",
+		"Staff\tstf\t4\t",
+	}, "\n")
+
+	diff := Table(a, b)
+
+	if len(diff.AddedColumns) != 1 || diff.AddedColumns[0] != "durability" {
+		t.Errorf("unexpected added columns: %v", diff.AddedColumns)
+	}
+
+	if len(diff.RemovedColumns) != 1 || diff.RemovedColumns[0] != "speed" {
+		t.Errorf("unexpected removed columns: %v", diff.RemovedColumns)
+	}
+
+	expected := []struct {
+		key    string
+		status Status
+		cells  int
+	}{
+		{"Sword", StatusChanged, 1},
+		{"Axe", StatusRemoved, 4},
+		{"Bow", StatusRemoved, 4},
+		// rows with the same key are matched in order of their occurrence
+		{"Expansion#3", StatusAdded, 1},
+		{"Staff", StatusAdded, 3},
+	}
+
+	if len(diff.Rows) != len(expected) {
+		t.Fatalf("expected %d rows, got %v", len(expected), diff.Rows)
+	}
+
+	for i, e := range expected {
+		row := diff.Rows[i]
+		if row.Key != e.key || row.Status != e.status || len(row.Cells) != e.cells {
+			t.Errorf("row %d: expected %s %s (%d cells), got %s %s %v", i, e.status, e.key, e.cells, row.Status, row.Key, row.Cells)
+		}
+	}
+
+	if cell := diff.Rows[0].Cells[0]; cell.Column != "level" || cell.Old != "1" || cell.New != "2" {
+		t.Errorf("unexpected cell change: %v", cell)
+	}
+}
+
+func Test_Diff(t *testing.T) {
+	tests := []struct {
+		name   string
+		a, b   []byte
+		format Format
+	}{
+		{"armor.txt", []byte("name\tcode\n"), []byte("name\tcode\nCap\tcap\n"), FormatTable},
+		{"readme.txt", []byte("hello\n"), []byte("hello world\n"), FormatText},
+		{"added.txt", nil, []byte("name\tcode\n"), FormatTable},
+		{"pal.dat", make([]byte, paletteSize), make([]byte, paletteSize), FormatPalette},
+		{"short.dat", []byte{0, 1, 2}, []byte{0, 1}, FormatBinary},
+		{"image.dc6", []byte{0, 1}, []byte{0, 2}, FormatBinary},
+	}
+
+	for _, test := range tests {
+		diff, err := Diff(test.name, test.a, test.b)
+		if err != nil {
+			t.Fatalf("%s: %v", test.name, err)
+		}
+
+		if diff.Format != test.format {
+			t.Errorf("%s: expected format %s, got %s", test.name, test.format, diff.Format)
+		}
+	}
+
+	diff, err := Diff("armor.txt", []byte("name\tcode\n"), []byte("name\tcode\nCap\tcap\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	data, err := json.Marshal(diff)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !strings.Contains(string(data), `"format":"table"`) || !strings.Contains(string(data), `"status":"Added"`) {
+		t.Errorf("unexpected JSON: %s", data)
+	}
+}
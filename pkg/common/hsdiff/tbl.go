@@ -0,0 +1,43 @@
+package hsdiff
+
+import (
+	"sort"
+
+	"github.com/OpenDiablo2/OpenDiablo2/d2common/d2fileformats/d2tbl"
+)
+
+// KeyChange is a string table's key, which differs between two tables
+type KeyChange struct {
+	Key    string `json:"key"`
+	Status Status `json:"status"`
+	Old    string `json:"old"`
+	New    string `json:"new"`
+}
+
+// TextDictionary compares string tables key by key. Only changed keys are returned (sorted by key).
+func TextDictionary(a, b d2tbl.TextDictionary) []KeyChange {
+	result := make([]KeyChange, 0)
+
+	for key, oldValue := range a {
+		newValue, found := b[key]
+
+		switch {
+		case !found:
+			result = append(result, KeyChange{Key: key, Status: StatusRemoved, Old: oldValue})
+		case oldValue != newValue:
+			result = append(result, KeyChange{Key: key, Status: StatusChanged, Old: oldValue, New: newValue})
+		}
+	}
+
+	for key, newValue := range b {
+		if _, found := a[key]; !found {
+			result = append(result, KeyChange{Key: key, Status: StatusAdded, New: newValue})
+		}
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Key < result[j].Key
+	})
+
+	return result
+}
@@ -0,0 +1,5 @@
+// Package hsexcel loads compiled excel tables (data\global\excel\*.bin), which
+// the game generates from .txt tables. A .bin is a record count followed by
+// records of a fixed size; layouts of some tables are known, so that they may
+// be converted back to .txt.
+package hsexcel
@@ -0,0 +1,95 @@
+package hsexcel
+
+import (
+	"encoding/binary"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// ColumnType is a type of a value stored in a record
+type ColumnType int
+
+// column types
+const (
+	// ColumnCode is a 4-character code (e.g. "tors"), padded with spaces or zeros
+	ColumnCode ColumnType = iota
+	ColumnUint8
+	ColumnUint16
+	ColumnUint32
+	ColumnInt32
+)
+
+// size returns a number of bytes taken by a value of the type
+func (c ColumnType) size() int {
+	switch c {
+	case ColumnUint8:
+		return 1
+	case ColumnUint16:
+		return 2 //nolint:mnd // uint16 size
+	default:
+		return 4 //nolint:mnd // uint32 size
+	}
+}
+
+// format returns a value of the type at the beginning of data in .txt form
+func (c ColumnType) format(data []byte) string {
+	switch c {
+	case ColumnCode:
+		return strings.TrimRight(string(data[:4]), " \x00")
+	case ColumnUint8:
+		return strconv.Itoa(int(data[0]))
+	case ColumnUint16:
+		return strconv.Itoa(int(binary.LittleEndian.Uint16(data)))
+	case ColumnUint32:
+		return strconv.FormatUint(uint64(binary.LittleEndian.Uint32(data)), 10)
+	case ColumnInt32:
+		return strconv.Itoa(int(int32(binary.LittleEndian.Uint32(data)))) //nolint:gosec // sign is intended
+	}
+
+	return ""
+}
+
+// Column is a value stored in each record of a table
+type Column struct {
+	// Name is a name of the .txt column the value comes from
+	Name   string
+	Offset int
+	Type   ColumnType
+}
+
+// Layout describes records of a table
+type Layout struct {
+	RecordSize int
+	Columns    []Column
+	// TextColumns are all the columns of .txt table (in order). Columns not compiled into .bin
+	// (e.g. comments or names used only by modders) are left empty when converting to .txt.
+	TextColumns []string
+}
+
+// codeTable returns a layout of a lookup table, which compiles to codes only
+func codeTable(nameColumn string) *Layout {
+	return &Layout{
+		RecordSize:  4, //nolint:mnd // code size
+		Columns:     []Column{{Name: "Code", Type: ColumnCode}},
+		TextColumns: []string{nameColumn, "Code"},
+	}
+}
+
+// knownLayouts are layouts of tables (by lower-case file name without extension)
+var knownLayouts = map[string]*Layout{
+	"bodylocs":    codeTable("Body Location"),
+	"colors":      codeTable("Transform Color"),
+	"elemtypes":   codeTable("Elemental Type"),
+	"hitclass":    codeTable("Hit Class"),
+	"playerclass": codeTable("Player Class"),
+	"storepage":   codeTable("Store Page"),
+}
+
+// KnownLayout returns layout of the table given by its file name (e.g. "Colors.bin")
+func KnownLayout(fileName string) (*Layout, bool) {
+	name := strings.ToLower(strings.TrimSuffix(fileName, filepath.Ext(fileName)))
+	layout, found := knownLayouts[name]
+
+	return layout, found
+}
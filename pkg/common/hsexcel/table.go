@@ -0,0 +1,135 @@
+package hsexcel
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"strings"
+)
+
+const countSize = 4
+
+// Table is a compiled excel table
+type Table struct {
+	Name       string
+	RecordSize int
+	Records    [][]byte
+	// Layout is nil if records of the table aren't known
+	Layout *Layout
+}
+
+// Load loads a table. Name is a file name of the table; it is used to find table's layout.
+func Load(name string, data []byte) (*Table, error) {
+	if len(data) < countSize {
+		return nil, errors.New("table is too short to contain a record count")
+	}
+
+	count := int(binary.LittleEndian.Uint32(data))
+	body := data[countSize:]
+
+	result := &Table{Name: name}
+
+	switch {
+	case count == 0 && len(body) == 0:
+		return result, nil
+	case count == 0 || len(body) == 0 || len(body)%count != 0:
+		return nil, fmt.Errorf("size of records (%d bytes) isn't a multiple of their count (%d)", len(body), count)
+	}
+
+	result.RecordSize = len(body) / count
+	result.Records = make([][]byte, count)
+
+	for i := range result.Records {
+		result.Records[i] = body[i*result.RecordSize : (i+1)*result.RecordSize]
+	}
+
+	// layout of a different size belongs to another version of the game
+	if layout, found := KnownLayout(filepath.Base(name)); found && layout.RecordSize == result.RecordSize {
+		result.Layout = layout
+	}
+
+	return result, nil
+}
+
+// Header returns names of table's columns
+func (t *Table) Header() []string {
+	if t.Layout != nil {
+		result := make([]string, len(t.Layout.Columns))
+		for i, c := range t.Layout.Columns {
+			result[i] = c.Name
+		}
+
+		return result
+	}
+
+	columns := t.rawColumns()
+	result := make([]string, len(columns))
+
+	for i, c := range columns {
+		result[i] = fmt.Sprintf("0x%02x", c.Offset)
+	}
+
+	return result
+}
+
+// Row returns values of the record given, as they're shown by Header
+func (t *Table) Row(record int) []string {
+	columns := t.rawColumns()
+	if t.Layout != nil {
+		columns = t.Layout.Columns
+	}
+
+	result := make([]string, len(columns))
+
+	for i, c := range columns {
+		result[i] = c.Type.format(t.Records[record][c.Offset:])
+	}
+
+	return result
+}
+
+// rawColumns splits records of unknown layout into 32-bit values (or bytes, if the size isn't aligned)
+func (t *Table) rawColumns() []Column {
+	columnType := ColumnUint32
+	if t.RecordSize%columnType.size() != 0 {
+		columnType = ColumnUint8
+	}
+
+	result := make([]Column, t.RecordSize/columnType.size())
+	for i := range result {
+		result[i] = Column{Offset: i * columnType.size(), Type: columnType}
+	}
+
+	return result
+}
+
+// ToText converts the table back to .txt (tab-separated values).
+// Columns, which aren't compiled into .bin, are left empty.
+func (t *Table) ToText() ([]byte, error) {
+	if t.Layout == nil {
+		return nil, fmt.Errorf("layout of %s is unknown", t.Name)
+	}
+
+	buf := &bytes.Buffer{}
+
+	buf.WriteString(strings.Join(t.Layout.TextColumns, "\t") + "\r\n")
+
+	for record := range t.Records {
+		row := make([]string, len(t.Layout.TextColumns))
+		values := t.Row(record)
+
+		for i, c := range t.Layout.Columns {
+			for j, name := range t.Layout.TextColumns {
+				if name == c.Name {
+					row[j] = values[i]
+				}
+			}
+		}
+
+		buf.WriteString(strings.Join(row, "\t") + "\r\n")
+	}
+
+	return buf.Bytes(), nil
+}
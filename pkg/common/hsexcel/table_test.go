@@ -0,0 +1,44 @@
+package hsexcel
+
+import (
+	"testing"
+)
+
+func Test_Table(t *testing.T) {
+	colors := []byte{2, 0, 0, 0, 'w', 'h', 'i', 't', 'b', 'l', 'a', 0}
+
+	table, err := Load("Colors.bin", colors)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if table.Layout == nil || len(table.Records) != 2 || table.Row(1)[0] != "bla" {
+		t.Fatalf("unexpected table %+v", table)
+	}
+
+	text, err := table.ToText()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if expected := "Transform Color\tCode\r\n\twhit\r\n\tbla\r\n"; string(text) != expected {
+		t.Errorf("unexpected text %q", text)
+	}
+
+	unknown, err := Load("unknown.bin", []byte{1, 0, 0, 0, 1, 0, 0, 0, 2, 0, 0, 0})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if header := unknown.Header(); len(header) != 2 || header[1] != "0x04" || unknown.Row(0)[1] != "2" {
+		t.Errorf("unexpected columns of unknown table %v %v", header, unknown.Row(0))
+	}
+
+	if _, err := unknown.ToText(); err == nil {
+		t.Error("table of unknown layout shouldn't be converted")
+	}
+
+	if _, err := Load("broken.bin", []byte{3, 0, 0, 0, 1, 2, 3, 4}); err == nil {
+		t.Error("records of different sizes shouldn't be loaded")
+	}
+}
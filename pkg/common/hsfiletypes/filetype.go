@@ -3,8 +3,6 @@ package hsfiletypes
 import (
 	"errors"
 	"strings"
-
-	"github.com/OpenDiablo2/OpenDiablo2/d2common/d2fileformats/d2tbl"
 )
 
 // FileType represents file type
@@ -27,23 +25,12 @@ const (
 	FileTypeTBLFontTable
 	FileTypeDS1
 	FileTypeAnimationData
+	FileTypeColormap
+	FileTypeExcelBin
+	FileTypeD2S
 	numFileTypes
 )
 
-// determinateTBLtype returns table type
-func determineSubtypeTBL(data *[]byte) FileType {
-	if _, err := d2tbl.LoadTextDictionary(*data); err == nil {
-		return FileTypeTBLStringTable
-	}
-
-	d := *data
-	if string(d[:4]) == "Woo!" {
-		return FileTypeTBLFontTable
-	}
-
-	return FileTypeText
-}
-
 // String returns file type string
 func (f FileType) String() string {
 	table := map[FileType]string{
@@ -61,10 +48,17 @@ func (f FileType) String() string {
 		FileTypeText:           "text file",
 		FileTypeDS1:            "DS1 Map Stamp",
 		FileTypeAnimationData:  "Animation Dataset",
+		FileTypeColormap:       "colormap",
+		FileTypeExcelBin:       "compiled excel table",
+		FileTypeD2S:            "character save",
 	}
 
 	val, found := table[f]
 	if !found {
+		if d, ok := Registered(f); ok {
+			return d.Name
+		}
+
 		return table[FileTypeUnknown]
 	}
 
@@ -87,39 +81,32 @@ func (f FileType) FileExtension() string {
 		FileTypeText:           ".txt",
 		FileTypeDS1:            ".ds1",
 		FileTypeAnimationData:  ".d2",
+		FileTypeColormap:       ".dat",
+		FileTypeExcelBin:       ".bin",
+		FileTypeD2S:            ".d2s",
 	}
 
-	return table[f]
-}
-
-type fileTypeCheckFn = func(data *[]byte) FileType
-
-// subtypeCheckFn returns a function to check a file. This is important for
-// distinguishing between files that share a common file extension.
-func (f FileType) subtypeCheckFn() fileTypeCheckFn {
-	table := map[FileType]fileTypeCheckFn{
-		FileTypeTBL:            determineSubtypeTBL,
-		FileTypeTBLFontTable:   determineSubtypeTBL,
-		FileTypeTBLStringTable: determineSubtypeTBL,
+	if d, ok := Registered(f); ok {
+		return d.Extensions[0]
 	}
 
 	return table[f]
 }
 
-// GetFileTypeFromExtension returns file type
+// GetFileTypeFromExtension returns file type. The type is determined by file's content (see Detect);
+// weak guesses (e.g. text) don't override a known extension, so they're used only for extension-less files.
 func GetFileTypeFromExtension(extension string, data *[]byte) (FileType, error) {
-	for fileType := FileType(0); fileType < numFileTypes; fileType++ {
-		extensionsMatch := strings.EqualFold(fileType.FileExtension(), extension)
-		if !extensionsMatch {
-			continue
+	if candidates := Detect(extension, *data); len(candidates) > 0 {
+		if best := candidates[0]; best.Confidence >= ConfidenceMedium || extension == "" {
+			return best.Type, nil
 		}
+	}
 
-		fnDetermine := fileType.subtypeCheckFn()
-		if fnDetermine == nil {
+	// content is broken (or not recognized); let the editor of the extension's file type report what's wrong
+	for fileType := FileType(0); fileType < numFileTypes; fileType++ {
+		if strings.EqualFold(fileType.FileExtension(), extension) {
 			return fileType, nil
 		}
-
-		return fnDetermine(data), nil
 	}
 
 	return FileTypeUnknown, errors.New("filetype: no file type matches the extension provided")
@@ -0,0 +1,102 @@
+package hsfiletypes
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+)
+
+// Descriptor describes a file type registered at runtime (e.g. by a plugin)
+type Descriptor struct {
+	// Name is a human-readable name of the file type; it must be unique
+	Name string
+	// Extensions are file's extensions (with the leading dot, e.g. ".d2s")
+	Extensions []string
+	// Sniff tells whether data is of this type. If nil, extension is enough to recognize the file.
+	// Sniffing allows to share an extension with another file type and to recognize misnamed files.
+	Sniff func(data []byte) bool
+	// Template returns the content of a new file. If nil, files of this type can't be created.
+	Template func() []byte
+}
+
+var registry = struct {
+	sync.RWMutex
+	descriptors []Descriptor
+}{}
+
+// Register adds a new file type. Registered types are checked before the built-in ones,
+// so they may take over an extension (preferably, with a Sniff function).
+func Register(d Descriptor) (FileType, error) {
+	if d.Name == "" {
+		return FileTypeUnknown, errors.New("filetype: name of the file type is required")
+	}
+
+	if len(d.Extensions) == 0 {
+		return FileTypeUnknown, fmt.Errorf("filetype: %s: at least one extension is required", d.Name)
+	}
+
+	for _, ext := range d.Extensions {
+		if !strings.HasPrefix(ext, ".") {
+			return FileTypeUnknown, fmt.Errorf("filetype: %s: extension %q should start with a dot", d.Name, ext)
+		}
+	}
+
+	registry.Lock()
+	defer registry.Unlock()
+
+	for _, existing := range registry.descriptors {
+		if strings.EqualFold(existing.Name, d.Name) {
+			return FileTypeUnknown, fmt.Errorf("filetype: %s is already registered", d.Name)
+		}
+	}
+
+	registry.descriptors = append(registry.descriptors, d)
+
+	return numFileTypes + FileType(len(registry.descriptors)-1), nil
+}
+
+// Registered returns the descriptor of a file type added by Register
+func Registered(f FileType) (Descriptor, bool) {
+	registry.RLock()
+	defer registry.RUnlock()
+
+	idx := int(f - numFileTypes)
+	if f < numFileTypes || idx >= len(registry.descriptors) {
+		return Descriptor{}, false
+	}
+
+	return registry.descriptors[idx], true
+}
+
+// registeredCandidates returns registered file types, which the data may be of.
+// A type matching the extension takes over the built-in ones; otherwise only its Sniff may recognize data.
+func registeredCandidates(extension string, data []byte) []Candidate {
+	registry.RLock()
+	defer registry.RUnlock()
+
+	result := make([]Candidate, 0)
+
+	for idx, d := range registry.descriptors {
+		candidate := Candidate{Type: numFileTypes + FileType(idx)}
+
+		for _, ext := range d.Extensions {
+			if strings.EqualFold(ext, extension) {
+				candidate.MatchesExtension = true
+			}
+		}
+
+		switch sniffed := d.Sniff != nil && d.Sniff(data); {
+		case candidate.MatchesExtension && (d.Sniff == nil || sniffed):
+			candidate.Confidence = ConfidenceCertain
+		case sniffed:
+			candidate.Confidence = ConfidenceHigh
+		default:
+			continue
+		}
+
+		result = append(result, candidate)
+	}
+
+	return result
+}
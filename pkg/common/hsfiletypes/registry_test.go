@@ -0,0 +1,66 @@
+package hsfiletypes
+
+import (
+	"bytes"
+	"testing"
+)
+
+func Test_Register(t *testing.T) {
+	if _, err := Register(Descriptor{Name: "no extensions"}); err == nil {
+		t.Error("file type without extensions shouldn't be registered")
+	}
+
+	if _, err := Register(Descriptor{Name: "bad extension", Extensions: []string{"d2s"}}); err == nil {
+		t.Error("extension without a dot shouldn't be accepted")
+	}
+
+	save, err := Register(Descriptor{
+		Name:       "Test character save",
+		Extensions: []string{".d2test"},
+		Template:   func() []byte { return []byte("new") },
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := Register(Descriptor{Name: "test character save", Extensions: []string{".x"}}); err == nil {
+		t.Error("duplicated name shouldn't be accepted")
+	}
+
+	// takes over text files starting with a magic
+	magic, err := Register(Descriptor{
+		Name:       "Test magic text",
+		Extensions: []string{".txt"},
+		Sniff:      func(data []byte) bool { return bytes.HasPrefix(data, []byte("MAGIC")) },
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		ext      string
+		data     string
+		expected FileType
+	}{
+		{".D2TEST", "", save},
+		{".txt", "MAGIC data", magic},
+		{".txt", "plain text", FileTypeText},
+	}
+
+	for _, test := range tests {
+		data := []byte(test.data)
+
+		fileType, err := GetFileTypeFromExtension(test.ext, &data)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if fileType != test.expected {
+			t.Errorf("%s %q: expected %s, got %s", test.ext, test.data, test.expected, fileType)
+		}
+	}
+
+	if save.String() != "Test character save" || save.FileExtension() != ".d2test" {
+		t.Errorf("unexpected descriptor of registered type: %s %s", save, save.FileExtension())
+	}
+}
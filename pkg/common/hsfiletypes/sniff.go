@@ -0,0 +1,410 @@
+package hsfiletypes
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/json"
+	"sort"
+	"strings"
+
+	"github.com/OpenDiablo2/OpenDiablo2/d2common/d2fileformats/d2tbl"
+)
+
+// Confidence tells how sure a sniffer is, that data is of some file type
+type Confidence int
+
+// confidence levels
+const (
+	// ConfidenceNone means, that data is not of the file type
+	ConfidenceNone Confidence = 0
+	// ConfidenceLow means, that data doesn't contradict the format (e.g. it has a proper size)
+	ConfidenceLow Confidence = 25
+	// ConfidenceMedium means, that some header fields have sane values
+	ConfidenceMedium Confidence = 50
+	// ConfidenceHigh means, that data starts with format's magic
+	ConfidenceHigh Confidence = 75
+	// ConfidenceCertain means, that the whole structure of data matches the format
+	ConfidenceCertain Confidence = 100
+)
+
+// extensionBonus is added to the confidence of a file type, whose extension matches
+const extensionBonus = ConfidenceLow
+
+// Candidate is a file type, which data may be of
+type Candidate struct {
+	Type             FileType
+	Confidence       Confidence
+	MatchesExtension bool
+}
+
+// Detect returns file types, which the data may be of; the most probable first.
+// Content decides, extension (which may be empty) only raises confidence of its file type,
+// so that misnamed or extension-less files are recognized properly.
+func Detect(extension string, data []byte) []Candidate {
+	result := registeredCandidates(extension, data)
+
+	for fileType := FileType(0); fileType < numFileTypes; fileType++ {
+		sniff := fileType.sniffFn()
+		if sniff == nil {
+			continue
+		}
+
+		candidate := Candidate{Type: fileType, Confidence: sniff(data)}
+		if candidate.Confidence == ConfidenceNone {
+			continue
+		}
+
+		if extension != "" && strings.EqualFold(fileType.FileExtension(), extension) {
+			candidate.MatchesExtension = true
+			candidate.Confidence = min(candidate.Confidence+extensionBonus, ConfidenceCertain)
+		}
+
+		result = append(result, candidate)
+	}
+
+	sort.SliceStable(result, func(i, j int) bool {
+		if result[i].Confidence != result[j].Confidence {
+			return result[i].Confidence > result[j].Confidence
+		}
+
+		return result[i].MatchesExtension && !result[j].MatchesExtension
+	})
+
+	return result
+}
+
+type sniffFn = func(data []byte) Confidence
+
+// sniffFn returns a function recognizing data of the file type.
+// File types without sniffer (e.g. FileTypeTBL, which is always resolved to its subtype) are never detected.
+func (f FileType) sniffFn() sniffFn {
+	table := map[FileType]sniffFn{
+		FileTypeText:           sniffText,
+		FileTypeFont:           sniffFont,
+		FileTypePalette:        sniffPalette,
+		FileTypeAudio:          sniffAudio,
+		FileTypeDCC:            sniffDCC,
+		FileTypeDC6:            sniffDC6,
+		FileTypeCOF:            sniffCOF,
+		FileTypeDT1:            sniffDT1,
+		FileTypePL2:            sniffPL2,
+		FileTypeTBLStringTable: sniffStringTable,
+		FileTypeTBLFontTable:   sniffFontTable,
+		FileTypeDS1:            sniffDS1,
+		FileTypeAnimationData:  sniffAnimationData,
+		FileTypeColormap:       sniffColormap,
+		FileTypeExcelBin:       sniffExcelBin,
+		FileTypeD2S:            sniffD2S,
+	}
+
+	return table[f]
+}
+
+// sniffText accepts data without control characters (except of whitespaces).
+// Encoding isn't checked, because game's text files aren't always UTF-8.
+func sniffText(data []byte) Confidence {
+	if len(data) == 0 {
+		return ConfidenceNone
+	}
+
+	for _, b := range data {
+		if b < ' ' && b != '\t' && b != '\n' && b != '\r' {
+			return ConfidenceNone
+		}
+	}
+
+	return ConfidenceLow
+}
+
+// sniffFont checks, if data is a JSON object with any of hsfont.Font's fields
+func sniffFont(data []byte) Confidence {
+	var fields map[string]json.RawMessage
+
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return ConfidenceNone
+	}
+
+	for _, key := range []string{"TableFile", "SpriteFile", "PaletteFile"} {
+		if _, found := fields[key]; found {
+			return ConfidenceHigh
+		}
+	}
+
+	return ConfidenceNone
+}
+
+// paletteSize is a size of palette (256 BGR colors)
+const paletteSize = 256 * 3
+
+// sniffPalette checks palette's size
+func sniffPalette(data []byte) Confidence {
+	if len(data) != paletteSize {
+		return ConfidenceNone
+	}
+
+	return ConfidenceLow
+}
+
+func sniffAudio(data []byte) Confidence {
+	const waveOffset = 8
+
+	if len(data) < waveOffset+4 || string(data[:4]) != "RIFF" || string(data[waveOffset:waveOffset+4]) != "WAVE" {
+		return ConfidenceNone
+	}
+
+	return ConfidenceCertain
+}
+
+// isDirectionCount returns true if n is a number of directions used by the game
+func isDirectionCount(n int) bool {
+	switch n {
+	case 1, 4, 8, 16, 32, 64: //nolint:mnd // directions' counts
+		return true
+	}
+
+	return false
+}
+
+func sniffDCC(data []byte) Confidence {
+	const (
+		signature   = 0x74
+		version     = 6
+		headerSize  = 15
+		tagPosition = 7
+	)
+
+	if len(data) < 2 || data[0] != signature || data[1] != version {
+		return ConfidenceNone
+	}
+
+	if len(data) < headerSize || !isDirectionCount(int(data[2])) ||
+		binary.LittleEndian.Uint32(data[tagPosition:]) != 1 {
+		return ConfidenceMedium
+	}
+
+	return ConfidenceCertain
+}
+
+func sniffDC6(data []byte) Confidence {
+	const (
+		version            = 6
+		terminationOffset  = 12
+		directionsOffset   = 16
+		framesOffset       = 20
+		headerSize         = 24
+		framePointerSize   = 4
+		maxFramesPerSprite = 256
+	)
+
+	if len(data) < headerSize || binary.LittleEndian.Uint32(data) != version {
+		return ConfidenceNone
+	}
+
+	termination := data[terminationOffset:directionsOffset]
+	if !bytes.Equal(termination, []byte{0xee, 0xee, 0xee, 0xee}) && !bytes.Equal(termination, []byte{0xcd, 0xcd, 0xcd, 0xcd}) {
+		return ConfidenceNone
+	}
+
+	directions := binary.LittleEndian.Uint32(data[directionsOffset:])
+	frames := binary.LittleEndian.Uint32(data[framesOffset:])
+
+	if !isDirectionCount(int(directions)) || frames == 0 || frames > maxFramesPerSprite ||
+		uint64(len(data)) < headerSize+uint64(directions)*uint64(frames)*framePointerSize {
+		return ConfidenceHigh
+	}
+
+	return ConfidenceCertain
+}
+
+// sniffCOF checks whether header's values are sane and the size of data matches them
+func sniffCOF(data []byte) Confidence {
+	const (
+		headerSize = 28
+		layerSize  = 9
+		maxLayers  = 16
+	)
+
+	if len(data) < headerSize {
+		return ConfidenceNone
+	}
+
+	layers, frames, directions := int(data[0]), int(data[1]), int(data[2])
+	if layers == 0 || layers > maxLayers || frames == 0 || !isDirectionCount(directions) {
+		return ConfidenceNone
+	}
+
+	if len(data) != headerSize+layers*layerSize+frames+frames*directions*layers {
+		return ConfidenceNone
+	}
+
+	return ConfidenceCertain
+}
+
+func sniffDT1(data []byte) Confidence {
+	const majorVersion, minorVersion = 7, 6
+
+	if len(data) < 8 || binary.LittleEndian.Uint32(data) != majorVersion || //nolint:mnd // two versions
+		binary.LittleEndian.Uint32(data[4:]) != minorVersion {
+		return ConfidenceNone
+	}
+
+	return ConfidenceCertain
+}
+
+// sniffPL2 checks size of data; palette transforms have constant size
+func sniffPL2(data []byte) Confidence {
+	const pl2Size = 443175
+
+	if len(data) != pl2Size {
+		return ConfidenceNone
+	}
+
+	return ConfidenceHigh
+}
+
+func sniffStringTable(data []byte) Confidence {
+	const (
+		headerSize     = 21
+		hashEntrySize  = 17
+		elementsOffset = 2
+		hashOffset     = 4
+	)
+
+	if len(data) < headerSize {
+		return ConfidenceNone
+	}
+
+	// the decoder allocates hash table before reading it, so its size is checked first
+	elements := uint64(binary.LittleEndian.Uint16(data[elementsOffset:]))
+	hashTableSize := uint64(binary.LittleEndian.Uint32(data[hashOffset:]))
+
+	if headerSize+elements*2+hashTableSize*hashEntrySize > uint64(len(data)) {
+		return ConfidenceNone
+	}
+
+	table, err := d2tbl.LoadTextDictionary(data)
+	if err != nil {
+		return ConfidenceNone
+	}
+
+	if len(table) == 0 {
+		return ConfidenceLow
+	}
+
+	return ConfidenceHigh
+}
+
+func sniffFontTable(data []byte) Confidence {
+	if !bytes.HasPrefix(data, []byte("Woo!")) {
+		return ConfidenceNone
+	}
+
+	return ConfidenceHigh
+}
+
+// sniffDS1 checks version and map's size; DS1 has no magic
+func sniffDS1(data []byte) Confidence {
+	const (
+		minVersion = 3
+		maxVersion = 18
+		maxSize    = 1024
+		headerSize = 12
+	)
+
+	if len(data) < headerSize {
+		return ConfidenceNone
+	}
+
+	version := binary.LittleEndian.Uint32(data)
+	width := binary.LittleEndian.Uint32(data[4:])  //nolint:mnd // width's offset
+	height := binary.LittleEndian.Uint32(data[8:]) //nolint:mnd // height's offset
+
+	if version < minVersion || version > maxVersion || width >= maxSize || height >= maxSize {
+		return ConfidenceNone
+	}
+
+	return ConfidenceMedium
+}
+
+// sniffAnimationData walks through blocks of records; their sizes have to sum up to the size of data
+func sniffAnimationData(data []byte) Confidence {
+	const (
+		numBlocks          = 256
+		maxRecordsPerBlock = 67
+		recordSize         = 160
+		countSize          = 4
+	)
+
+	position := 0
+
+	for block := 0; block < numBlocks; block++ {
+		if position+countSize > len(data) {
+			return ConfidenceNone
+		}
+
+		records := int(binary.LittleEndian.Uint32(data[position:]))
+		if records > maxRecordsPerBlock {
+			return ConfidenceNone
+		}
+
+		position += countSize + records*recordSize
+	}
+
+	if position != len(data) {
+		return ConfidenceNone
+	}
+
+	return ConfidenceCertain
+}
+
+// sniffColormap checks whether data is a sequence of 256-byte remap tables.
+// Size of palette is left for palettes, although three tables would have it too.
+func sniffColormap(data []byte) Confidence {
+	const tableSize = 256
+
+	if len(data) == 0 || len(data)%tableSize != 0 || len(data) == paletteSize {
+		return ConfidenceNone
+	}
+
+	return ConfidenceLow
+}
+
+// sniffExcelBin checks whether data is a record count followed by records of equal size
+func sniffExcelBin(data []byte) Confidence {
+	const countSize = 4
+
+	if len(data) < countSize {
+		return ConfidenceNone
+	}
+
+	count := uint64(binary.LittleEndian.Uint32(data))
+	size := uint64(len(data) - countSize)
+
+	if (count == 0 && size != 0) || (count != 0 && (size == 0 || size%count != 0)) {
+		return ConfidenceNone
+	}
+
+	return ConfidenceLow
+}
+
+func sniffD2S(data []byte) Confidence {
+	const (
+		magic        = 0xaa55aa55
+		headerSize   = 12
+		sizeOffset   = 8
+		minVersion   = 71
+		maxVersion   = 99
+		versionField = 4
+	)
+
+	if len(data) < headerSize || binary.LittleEndian.Uint32(data) != magic {
+		return ConfidenceNone
+	}
+
+	version := binary.LittleEndian.Uint32(data[versionField:])
+	if version < minVersion || version > maxVersion || binary.LittleEndian.Uint32(data[sizeOffset:]) != uint32(len(data)) {
+		return ConfidenceHigh
+	}
+
+	return ConfidenceCertain
+}
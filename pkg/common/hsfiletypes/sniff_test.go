@@ -0,0 +1,63 @@
+package hsfiletypes
+
+import (
+	"testing"
+
+	"github.com/OpenDiablo2/OpenDiablo2/d2common/d2fileformats/d2animdata"
+	"github.com/OpenDiablo2/OpenDiablo2/d2common/d2fileformats/d2dat"
+	"github.com/OpenDiablo2/OpenDiablo2/d2common/d2fileformats/d2dt1"
+	"github.com/OpenDiablo2/OpenDiablo2/d2common/d2fileformats/d2font"
+	"github.com/OpenDiablo2/OpenDiablo2/d2common/d2fileformats/d2pl2"
+	"github.com/OpenDiablo2/OpenDiablo2/d2common/d2fileformats/d2tbl"
+)
+
+func Test_Detect(t *testing.T) {
+	dc6 := []byte{
+		6, 0, 0, 0, 1, 0, 0, 0, 0, 0, 0, 0, 0xee, 0xee, 0xee, 0xee,
+		1, 0, 0, 0, 1, 0, 0, 0, 28, 0, 0, 0,
+	}
+
+	tests := []struct {
+		name     string
+		ext      string
+		data     []byte
+		expected FileType
+	}{
+		{"text", ".txt", []byte("name\tvalue\r\n"), FileTypeText},
+		{"palette", ".dat", d2dat.New().Marshal(), FileTypePalette},
+		{"palette without extension", "", d2dat.New().Marshal(), FileTypePalette},
+		{"palette transform", ".pl2", (&d2pl2.PL2{}).Marshal(), FileTypePL2},
+		{"palette transform without extension", "", (&d2pl2.PL2{}).Marshal(), FileTypePL2},
+		{"font table", ".tbl", (&d2font.Font{}).Marshal(), FileTypeTBLFontTable},
+		{"string table", ".tbl", (&d2tbl.TextDictionary{"key": "value"}).Marshal(), FileTypeTBLStringTable},
+		{"animation data", ".d2", (&d2animdata.AnimationData{}).Marshal(), FileTypeAnimationData},
+		{"animation data named as palette", ".dat", (&d2animdata.AnimationData{}).Marshal(), FileTypeAnimationData},
+		{"tileset", ".dt1", d2dt1.New().Marshal(), FileTypeDT1},
+		{"tileset without extension", "", d2dt1.New().Marshal(), FileTypeDT1},
+		{"DC6 named as DCC", ".dcc", dc6, FileTypeDC6},
+		{"DCC", "", []byte{0x74, 6, 1, 1, 0, 0, 0, 1, 0, 0, 0, 0, 0, 0, 0}, FileTypeDCC},
+		{"wave", "", []byte("RIFF\x00\x00\x00\x00WAVEfmt "), FileTypeAudio},
+		{"broken file falls back to extension", ".cof", []byte{0, 1, 2}, FileTypeCOF},
+		{"weak guess doesn't override extension", ".dc6", []byte("dc6"), FileTypeDC6},
+		{"colormap", ".dat", make([]byte, 256*21), FileTypeColormap},
+		{"compiled excel table", ".bin", []byte{2, 0, 0, 0, 'w', 'h', 'i', 't', 'b', 'l', 'a', 0}, FileTypeExcelBin},
+		{"character save without extension", "", []byte{0x55, 0xaa, 0x55, 0xaa, 96, 0, 0, 0, 12, 0, 0, 0}, FileTypeD2S},
+	}
+
+	for _, test := range tests {
+		data := test.data
+
+		fileType, err := GetFileTypeFromExtension(test.ext, &data)
+		if err != nil {
+			t.Fatalf("%s: %v", test.name, err)
+		}
+
+		if fileType != test.expected {
+			t.Errorf("%s: expected %s, got %s (candidates: %v)", test.name, test.expected, fileType, Detect(test.ext, data))
+		}
+	}
+
+	if candidates := Detect("", []byte{0, 1, 2}); len(candidates) != 0 {
+		t.Errorf("unexpected candidates for random data: %v", candidates)
+	}
+}
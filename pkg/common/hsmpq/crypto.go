@@ -0,0 +1,91 @@
+package hsmpq
+
+import (
+	"strings"
+)
+
+const (
+	cryptoTableSize = 0x500
+	cryptoSeed      = 0x00100001
+	encryptionSeed2 = 0xEEEEEEEE
+	hashSeed1       = 0x7FED7FED
+)
+
+// hash types (offsets in crypto table)
+const (
+	hashTableOffset = iota
+	hashNameA
+	hashNameB
+	hashFileKey
+)
+
+// cryptoTable is a table used by MPQ hashing and encryption algorithms
+type cryptoTable [cryptoTableSize]uint32
+
+//nolint:mnd // MPQ magic
+func newCryptoTable() *cryptoTable {
+	result := &cryptoTable{}
+	seed := uint32(cryptoSeed)
+
+	for index1 := 0; index1 < 0x100; index1++ {
+		index2 := index1
+
+		for i := 0; i < 5; i++ {
+			seed = (seed*125 + 3) % 0x2AAAAB
+			temp1 := (seed & 0xFFFF) << 0x10
+			seed = (seed*125 + 3) % 0x2AAAAB
+			temp2 := seed & 0xFFFF
+			result[index2] = temp1 | temp2
+			index2 += 0x100
+		}
+	}
+
+	return result
+}
+
+// hashString hashes a (case insensitive) file name
+//
+//nolint:mnd // MPQ magic
+func (c *cryptoTable) hashString(key string, hashType uint32) uint32 {
+	seed1 := uint32(hashSeed1)
+	seed2 := uint32(encryptionSeed2)
+
+	for _, char := range []byte(strings.ToUpper(key)) {
+		seed1 = c[(hashType*0x100)+uint32(char)] ^ (seed1 + seed2)
+		seed2 = uint32(char) + seed1 + seed2 + (seed2 << 5) + 3
+	}
+
+	return seed1
+}
+
+// encrypt encrypts data in place
+//
+//nolint:mnd // MPQ magic
+func (c *cryptoTable) encrypt(data []uint32, seed uint32) {
+	seed2 := uint32(encryptionSeed2)
+
+	for i := range data {
+		seed2 += c[0x400+(seed&0xFF)]
+		result := data[i] ^ (seed + seed2)
+
+		seed = ((^seed << 21) + 0x11111111) | (seed >> 11)
+		seed2 = data[i] + seed2 + (seed2 << 5) + 3
+		data[i] = result
+	}
+}
+
+// decrypt decrypts data in place
+//
+//nolint:mnd // MPQ magic
+func (c *cryptoTable) decrypt(data []uint32, seed uint32) {
+	seed2 := uint32(encryptionSeed2)
+
+	for i := range data {
+		seed2 += c[0x400+(seed&0xFF)]
+		result := data[i] ^ (seed + seed2)
+
+		seed = ((^seed << 21) + 0x11111111) | (seed >> 11)
+		seed2 = result + seed2 + (seed2 << 5) + 3
+		data[i] = result
+	}
+}
@@ -0,0 +1,5 @@
+// Package hsmpq contains a writer of MPQ archives. Files are stored
+// uncompressed and unencrypted, which is enough for the game and OpenDiablo2
+// to load them (e.g. as a patch MPQ exported from a project).
+// It also reads sizes of archive's files, which OpenDiablo2's reader doesn't expose.
+package hsmpq
@@ -0,0 +1,108 @@
+package hsmpq
+
+import (
+	"encoding/binary"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/OpenDiablo2/OpenDiablo2/d2common/d2fileformats/d2mpq"
+)
+
+const (
+	signature = "MPQ\x1A"
+	// hashEntryDeleted is a block index of a deleted file; lookup continues past it
+	hashEntryDeleted = 0xFFFFFFFE
+)
+
+// ReadFileSizes reads (uncompressed) sizes of archive's files from its hash and block tables,
+// without reading the files. Names are game paths; files not found in the archive are skipped.
+func ReadFileSizes(path string, names []string) (map[string]int, error) {
+	f, err := os.Open(filepath.Clean(path))
+	if err != nil {
+		return nil, fmt.Errorf("error opening %s: %w", path, err)
+	}
+
+	defer func() {
+		_ = f.Close()
+	}()
+
+	header := d2mpq.Header{}
+	if err := binary.Read(f, binary.LittleEndian, &header); err != nil {
+		return nil, fmt.Errorf("error reading header of %s: %w", path, err)
+	}
+
+	if string(header.Magic[:]) != signature {
+		return nil, fmt.Errorf("%s isn't an MPQ archive", path)
+	}
+
+	crypto := newCryptoTable()
+
+	hashTable, err := readTable(f, crypto, header.HashTableOffset, header.HashTableEntries, hashTableKey)
+	if err != nil {
+		return nil, fmt.Errorf("error reading hash table of %s: %w", path, err)
+	}
+
+	blockTable, err := readTable(f, crypto, header.BlockTableOffset, header.BlockTableEntries, blockTableKey)
+	if err != nil {
+		return nil, fmt.Errorf("error reading block table of %s: %w", path, err)
+	}
+
+	result := make(map[string]int, len(names))
+
+	for _, name := range names {
+		blockIdx, found := crypto.findHash(hashTable, name)
+		if !found || int(blockIdx) >= len(blockTable)/entryWords {
+			continue
+		}
+
+		// block: position, compressed size, uncompressed size, flags
+		result[name] = int(blockTable[blockIdx*entryWords+2])
+	}
+
+	return result, nil
+}
+
+// readTable reads and decrypts the hash or block table
+func readTable(r io.ReadSeeker, crypto *cryptoTable, offset, entries uint32, key string) ([]uint32, error) {
+	if _, err := r.Seek(int64(offset), io.SeekStart); err != nil {
+		return nil, fmt.Errorf("error seeking to %d: %w", offset, err)
+	}
+
+	result := make([]uint32, entries*entryWords)
+	if err := binary.Read(r, binary.LittleEndian, result); err != nil {
+		return nil, fmt.Errorf("error reading %d entries: %w", entries, err)
+	}
+
+	crypto.decrypt(result, crypto.hashString(key, hashFileKey))
+
+	return result, nil
+}
+
+// findHash looks for file's entry in the hash table (see Writer.insertHash)
+// and returns an index of its block
+func (c *cryptoTable) findHash(hashTable []uint32, name string) (uint32, bool) {
+	hashTableLen := uint32(len(hashTable) / entryWords)
+	if hashTableLen == 0 {
+		return 0, false
+	}
+
+	nameA, nameB := c.hashString(name, hashNameA), c.hashString(name, hashNameB)
+	idx := c.hashString(name, hashTableOffset) % hashTableLen
+
+	for i := uint32(0); i < hashTableLen; i, idx = i+1, (idx+1)%hashTableLen {
+		entry := hashTable[idx*entryWords : (idx+1)*entryWords]
+
+		switch {
+		case entry[3] == hashEntryEmpty:
+			return 0, false
+		case entry[3] == hashEntryDeleted:
+			continue
+		case entry[0] == nameA && entry[1] == nameB:
+			return entry[3], true
+		}
+	}
+
+	return 0, false
+}
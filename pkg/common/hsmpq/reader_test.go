@@ -0,0 +1,65 @@
+package hsmpq
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func Test_ReadFileSizes(t *testing.T) {
+	dir := t.TempDir()
+
+	w := NewWriter()
+	w.Add(`data\global\excel\armor.txt`, []byte("name\tcode\r\n"))
+	w.Add(`data\global\ui\panel\invchar6.dc6`, bytes.Repeat([]byte{1}, 5000))
+	w.Add(`data\local\empty.tbl`, []byte{})
+
+	path := filepath.Join(dir, "patch.mpq")
+	if err := w.Save(path); err != nil {
+		t.Fatal(err)
+	}
+
+	names := []string{
+		`data\global\excel\armor.txt`,
+		`DATA\GLOBAL\UI\PANEL\INVCHAR6.DC6`,
+		`data\local\empty.tbl`,
+		`data\global\missing.txt`,
+	}
+
+	sizes, err := ReadFileSizes(path, names)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name  string
+		size  int
+		found bool
+	}{
+		{name: names[0], size: 11, found: true},
+		{name: names[1], size: 5000, found: true},
+		{name: names[2], size: 0, found: true},
+		{name: names[3]},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			size, found := sizes[tt.name]
+			if found != tt.found || size != tt.size {
+				t.Fatalf("expected size %d (found %t), got %d (found %t)", tt.size, tt.found, size, found)
+			}
+		})
+	}
+}
+
+func Test_ReadFileSizes_NotMPQ(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "text.mpq")
+	if err := os.WriteFile(path, bytes.Repeat([]byte("not an archive "), 10), fileModeNew); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := ReadFileSizes(path, nil); err == nil {
+		t.Fatal("expected an error for a file, which isn't an MPQ archive")
+	}
+}
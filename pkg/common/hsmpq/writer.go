@@ -0,0 +1,194 @@
+package hsmpq
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+const (
+	headerSize = 32
+	// sector size is 0x200 << blockSizeShift
+	blockSizeShift = 3
+	// ListFileName is a name of archive's list of files
+	ListFileName = "(listfile)"
+
+	hashTableKey    = "(hash table)"
+	blockTableKey   = "(block table)"
+	minHashTableLen = 16
+	hashEntryEmpty  = 0xFFFFFFFF
+	// fileExists is a block flag of existing files
+	fileExists   = 0x80000000
+	fileModeNew  = 0o644
+	entryWords   = 4
+	bytesPerWord = 4
+)
+
+// Writer collects files and writes them into an MPQ archive
+type Writer struct {
+	files  map[string][]byte
+	crypto *cryptoTable
+}
+
+// NewWriter creates a new MPQ writer
+func NewWriter() *Writer {
+	result := &Writer{
+		files:  make(map[string][]byte),
+		crypto: newCryptoTable(),
+	}
+
+	return result
+}
+
+// Add adds a file to the archive. Name is a game path (e.g. data\global\excel\armor.txt);
+// adding a file with the same name (case insensitive) replaces the previous one.
+func (w *Writer) Add(name string, data []byte) {
+	name = strings.TrimPrefix(strings.ReplaceAll(name, "/", `\`), `\`)
+
+	for existing := range w.files {
+		if strings.EqualFold(existing, name) {
+			delete(w.files, existing)
+		}
+	}
+
+	w.files[name] = data
+}
+
+// Len returns a number of files added
+func (w *Writer) Len() int {
+	return len(w.files)
+}
+
+// Save writes the archive into the file given
+func (w *Writer) Save(path string) error {
+	buf := &bytes.Buffer{}
+	if err := w.Write(buf); err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), os.ModePerm); err != nil {
+		return fmt.Errorf("error creating directory for %s: %w", path, err)
+	}
+
+	if err := os.WriteFile(path, buf.Bytes(), fileModeNew); err != nil {
+		return fmt.Errorf("error writing %s: %w", path, err)
+	}
+
+	return nil
+}
+
+// Write writes the archive. List file is added automatically.
+//
+//nolint:funlen // no need to split
+func (w *Writer) Write(out io.Writer) error {
+	names := make([]string, 0, len(w.files))
+
+	for name := range w.files {
+		if name != ListFileName {
+			names = append(names, name)
+		}
+	}
+
+	sort.Strings(names)
+
+	listFile := []byte(strings.Join(names, "\r\n"))
+	names = append(names, ListFileName)
+
+	hashTableLen := uint32(minHashTableLen)
+	for int(hashTableLen) < len(names)*2 {
+		hashTableLen <<= 1
+	}
+
+	hashTable := make([]uint32, hashTableLen*entryWords)
+	for i := range hashTable {
+		hashTable[i] = hashEntryEmpty
+	}
+
+	blockTable := make([]uint32, 0, len(names)*entryWords)
+	data := &bytes.Buffer{}
+
+	for blockIdx, name := range names {
+		content := w.files[name]
+		if name == ListFileName {
+			content = listFile
+		}
+
+		if uint64(headerSize)+uint64(data.Len())+uint64(len(content)) > math.MaxUint32 {
+			return errors.New("archive is too large")
+		}
+
+		position := uint32(headerSize + data.Len())
+		size := uint32(len(content))
+
+		data.Write(content)
+
+		blockTable = append(blockTable, position, size, size, fileExists)
+
+		w.insertHash(hashTable, name, uint32(blockIdx))
+	}
+
+	hashTableOffset := uint32(headerSize + data.Len())
+	blockTableOffset := hashTableOffset + hashTableLen*entryWords*bytesPerWord
+	blockTableLen := uint32(len(names))
+
+	w.crypto.encrypt(hashTable, w.crypto.hashString(hashTableKey, hashFileKey))
+	w.crypto.encrypt(blockTable, w.crypto.hashString(blockTableKey, hashFileKey))
+
+	header := []any{
+		[4]byte{'M', 'P', 'Q', 0x1A},
+		uint32(headerSize),
+		blockTableOffset + blockTableLen*entryWords*bytesPerWord, // archive size
+		uint16(0), // format version
+		uint16(blockSizeShift),
+		hashTableOffset,
+		blockTableOffset,
+		hashTableLen,
+		blockTableLen,
+	}
+
+	for _, field := range header {
+		if err := binary.Write(out, binary.LittleEndian, field); err != nil {
+			return fmt.Errorf("error writing MPQ header: %w", err)
+		}
+	}
+
+	if _, err := out.Write(data.Bytes()); err != nil {
+		return fmt.Errorf("error writing MPQ data: %w", err)
+	}
+
+	if err := binary.Write(out, binary.LittleEndian, hashTable); err != nil {
+		return fmt.Errorf("error writing MPQ hash table: %w", err)
+	}
+
+	if err := binary.Write(out, binary.LittleEndian, blockTable); err != nil {
+		return fmt.Errorf("error writing MPQ block table: %w", err)
+	}
+
+	return nil
+}
+
+// insertHash puts file's entry into the first free slot of the hash table
+func (w *Writer) insertHash(hashTable []uint32, name string, blockIdx uint32) {
+	hashTableLen := uint32(len(hashTable) / entryWords)
+
+	for idx := w.crypto.hashString(name, hashTableOffset) & (hashTableLen - 1); ; idx = (idx + 1) & (hashTableLen - 1) {
+		entry := hashTable[idx*entryWords : (idx+1)*entryWords]
+		if entry[3] != hashEntryEmpty {
+			continue
+		}
+
+		entry[0] = w.crypto.hashString(name, hashNameA)
+		entry[1] = w.crypto.hashString(name, hashNameB)
+		entry[2] = 0 // neutral locale, default platform
+		entry[3] = blockIdx
+
+		return
+	}
+}
@@ -0,0 +1,71 @@
+package hsmpq
+
+import (
+	"bytes"
+	"path/filepath"
+	"testing"
+
+	"github.com/OpenDiablo2/OpenDiablo2/d2common/d2fileformats/d2mpq"
+)
+
+func Test_Writer_RoundTrip(t *testing.T) {
+	dir := t.TempDir()
+
+	files := map[string][]byte{
+		`data\global\excel\armor.txt`: []byte("name\tcode\r\nCap\tcap\r\n"),
+		// larger than a single sector
+		`data\global\ui\panel\invchar6.dc6`: bytes.Repeat([]byte{1, 2, 3, 4, 5}, 5000),
+		`data\local\empty.tbl`:              {},
+	}
+
+	w := NewWriter()
+
+	for name, data := range files {
+		w.Add(name, data)
+	}
+
+	// replaces the previous one
+	w.Add(`DATA/GLOBAL/EXCEL/ARMOR.TXT`, files[`data\global\excel\armor.txt`])
+
+	path := filepath.Join(dir, "patch.mpq")
+	if err := w.Save(path); err != nil {
+		t.Fatal(err)
+	}
+
+	mpq, err := d2mpq.FromFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	defer mpq.Close()
+
+	for name, expected := range files {
+		if len(expected) == 0 {
+			if !mpq.Contains(name) {
+				t.Errorf("%s not found", name)
+			}
+
+			continue
+		}
+
+		data, err := mpq.ReadFile(name)
+		if err != nil {
+			t.Errorf("error reading %s: %v", name, err)
+
+			continue
+		}
+
+		if !bytes.Equal(data, expected) {
+			t.Errorf("%s: unexpected content", name)
+		}
+	}
+
+	list, err := mpq.Listfile()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(list) != len(files) {
+		t.Fatalf("unexpected list file: %v", list)
+	}
+}
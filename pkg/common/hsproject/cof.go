@@ -0,0 +1,183 @@
+package hsproject
+
+import (
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/OpenDiablo2/OpenDiablo2/d2common/d2enum"
+	"github.com/OpenDiablo2/OpenDiablo2/d2common/d2fileformats/d2cof"
+	"github.com/OpenDiablo2/OpenDiablo2/d2common/d2fileformats/d2dcc"
+
+	"github.com/gucio321/HellSpawner/pkg/common"
+	"github.com/gucio321/HellSpawner/pkg/common/hscof"
+	"github.com/gucio321/HellSpawner/pkg/common/hsfiletypes"
+)
+
+// FindDCCLayers searches project and auxiliary MPQs for DCC layers of the given animation
+// (e.g. data\global\monsters\<token>\<layer>\<token><layer><armor><mode><weaponClass>.dcc).
+// If there is more than one DCC for a layer (e.g. different armor types) the first one is used.
+func (p *Project) FindDCCLayers(kind, token, mode, weaponClass string) ([]hscof.Layer, error) {
+	layers := p.findDCCLayerEntries(kind, token, mode, weaponClass)
+
+	result := make([]hscof.Layer, 0, len(layers))
+
+	for t := d2enum.CompositeTypeHead; t < d2enum.CompositeTypeMax; t++ {
+		entry, found := layers[t]
+		if !found {
+			continue
+		}
+
+		data, err := p.ReadFile(entry)
+		if err != nil {
+			return nil, fmt.Errorf("error reading %s: %w", entry.FullPath, err)
+		}
+
+		directions, frames, err := hscof.ReadDCCHeader(data)
+		if err != nil {
+			return nil, fmt.Errorf("error reading DCC header of %s: %w", entry.FullPath, err)
+		}
+
+		result = append(result, hscof.Layer{
+			Type:               t,
+			Path:               entry.FullPath,
+			Directions:         directions,
+			FramesPerDirection: frames,
+		})
+	}
+
+	return result, nil
+}
+
+// LoadAnimationDCCs looks for DCC layers of the animation named name (e.g. ZMNUHTH)
+// in every animation kind directory and decodes them.
+// Layers are returned in the default draw order.
+func (p *Project) LoadAnimationDCCs(name string) ([]*d2dcc.DCC, error) {
+	token, mode, weaponClass, ok := hscof.SplitAnimationName(name)
+	if !ok {
+		return nil, fmt.Errorf("invalid animation name %s", name)
+	}
+
+	for _, kind := range hscof.AnimationKinds() {
+		layers := p.findDCCLayerEntries(kind, token, mode, weaponClass)
+		if len(layers) == 0 {
+			continue
+		}
+
+		result := make([]*d2dcc.DCC, 0, len(layers))
+
+		for _, t := range hscof.DefaultDrawOrder() {
+			entry, found := layers[t]
+			if !found {
+				continue
+			}
+
+			data, err := p.ReadFile(entry)
+			if err != nil {
+				return nil, fmt.Errorf("error reading %s: %w", entry.FullPath, err)
+			}
+
+			dcc, err := d2dcc.Load(data)
+			if err != nil {
+				return nil, fmt.Errorf("error loading %s: %w", entry.FullPath, err)
+			}
+
+			result = append(result, dcc)
+		}
+
+		return result, nil
+	}
+
+	return nil, fmt.Errorf("no DCC layers found for animation %s", name)
+}
+
+func (p *Project) findDCCLayerEntries(kind, token, mode, weaponClass string) map[d2enum.CompositeType]*common.PathEntry {
+	layers := make(map[d2enum.CompositeType]*common.PathEntry)
+
+	entries := p.FindFiles(func(gamePath string) bool {
+		_, ok := hscof.MatchDCCPath(gamePath, kind, token, mode, weaponClass)
+
+		return ok
+	})
+
+	for _, entry := range entries {
+		gamePath := entry.FullPath
+		if entry.Source == common.PathEntrySourceProject {
+			gamePath = p.ContentPathToGamePath(entry.FullPath)
+		}
+
+		layerType, _ := hscof.MatchDCCPath(NormalizeGamePath(gamePath), kind, token, mode, weaponClass)
+		if _, found := layers[layerType]; !found {
+			layers[layerType] = entry
+		}
+	}
+
+	return layers
+}
+
+// CreateNewCOF saves the given COF in directory path under name <token><mode><weaponClass>.cof
+func (p *Project) CreateNewCOF(path *common.PathEntry, cof *d2cof.COF, token, mode, weaponClass string) error {
+	name := strings.ToUpper(token+mode+weaponClass) + hsfiletypes.FileTypeCOF.FileExtension()
+	fileName := filepath.Join(path.FullPath, name)
+
+	if _, err := os.Stat(fileName); err == nil {
+		fmtPath := filepath.Join(path.FullPath, strings.ToUpper(token+mode+weaponClass)+"%d"+hsfiletypes.FileTypeCOF.FileExtension())
+
+		if fileName, err = getNextUniqueNewPath(fmtPath, maxNewFileAttempts); err != nil {
+			return err
+		}
+	}
+
+	if err := os.WriteFile(fileName, cof.Marshal(), os.FileMode(newFileMode)); err != nil {
+		return fmt.Errorf("cannot write to file %s: %w", fileName, err)
+	}
+
+	log.Printf("created %s with %d layers", fileName, cof.NumberOfLayers)
+
+	p.InvalidateFileStructure()
+
+	return nil
+}
+
+// LoadAnimationCOFs loads every COF file from data\global\<kind>\<token>\cof\ directories
+// of the composite view of the project. COFs are mapped by their upper-case names (without extension),
+// which is also how animation data (.d2) records are named.
+func (p *Project) LoadAnimationCOFs() map[string]*d2cof.COF {
+	const (
+		cofPathDepth = 6 // data\global\<kind>\<token>\cof\<name>.cof
+		cofDirIdx    = 4
+	)
+
+	entries := p.FindFiles(func(gamePath string) bool {
+		parts := strings.Split(gamePath, gamePathSep)
+
+		return len(parts) == cofPathDepth && parts[cofDirIdx] == "cof" &&
+			filepath.Ext(gamePath) == hsfiletypes.FileTypeCOF.FileExtension()
+	})
+
+	result := make(map[string]*d2cof.COF)
+
+	for _, entry := range entries {
+		name := strings.ToUpper(strings.TrimSuffix(entry.Name, filepath.Ext(entry.Name)))
+
+		data, err := p.ReadFile(entry)
+		if err != nil {
+			log.Printf("error reading %s: %v", entry.FullPath, err)
+
+			continue
+		}
+
+		cof, err := d2cof.Unmarshal(data)
+		if err != nil {
+			log.Printf("error loading %s: %v", entry.FullPath, err)
+
+			continue
+		}
+
+		result[name] = cof
+	}
+
+	return result
+}
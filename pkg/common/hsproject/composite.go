@@ -0,0 +1,263 @@
+package hsproject
+
+import (
+	"fmt"
+	"io/fs"
+	"log"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/OpenDiablo2/OpenDiablo2/d2common/d2fileformats/d2mpq"
+	"github.com/OpenDiablo2/OpenDiablo2/d2common/d2interface"
+
+	"github.com/gucio321/HellSpawner/pkg/common"
+)
+
+const (
+	gamePathSep    = `\`
+	gamePathPrefix = `data\`
+)
+
+// NormalizeGamePath converts path to the form used inside of MPQs
+// (lower case, back slashes, `data\` prefix)
+func NormalizeGamePath(path string) string {
+	path = strings.ToLower(strings.ReplaceAll(path, "/", gamePathSep))
+	path = strings.TrimPrefix(path, gamePathSep)
+
+	if !strings.HasPrefix(path, gamePathPrefix) {
+		path = gamePathPrefix + path
+	}
+
+	return path
+}
+
+// GamePathToContentPath returns a path in project's content directory, that
+// corresponds to the given game path. Files copied from MPQs are stored there
+// without the leading `data` directory.
+func (p *Project) GamePathToContentPath(gamePath string) string {
+	rel := strings.TrimPrefix(NormalizeGamePath(gamePath), gamePathPrefix)
+
+	return filepath.Join(p.GetProjectFileContentPath(), filepath.FromSlash(strings.ReplaceAll(rel, gamePathSep, "/")))
+}
+
+// ContentPathToGamePath converts a path from project's content directory to the game path.
+// Returns an empty string if path is not inside of the content directory.
+func (p *Project) ContentPathToGamePath(path string) string {
+	rel, err := filepath.Rel(p.GetProjectFileContentPath(), path)
+	if err != nil || rel == "." || strings.HasPrefix(rel, "..") {
+		return ""
+	}
+
+	return NormalizeGamePath(filepath.ToSlash(rel))
+}
+
+// FindFiles returns files from the composite view of the project (project's content first,
+// then auxiliary MPQs in load order) which game path matches the given function.
+// A file present in project shadows files with the same path from MPQs.
+func (p *Project) FindFiles(match func(gamePath string) bool) []*common.PathEntry {
+	result := make([]*common.PathEntry, 0)
+	found := make(map[string]bool)
+
+	contentPath := p.GetProjectFileContentPath()
+
+	// walking errors just make us skip a part of the tree
+	_ = filepath.WalkDir(contentPath, func(path string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() {
+			return nil
+		}
+
+		gamePath := p.ContentPathToGamePath(path)
+		if gamePath == "" || found[gamePath] || !match(gamePath) {
+			return nil
+		}
+
+		found[gamePath] = true
+
+		result = append(result, &common.PathEntry{
+			Name:     d.Name(),
+			FullPath: path,
+			Source:   common.PathEntrySourceProject,
+		})
+
+		return nil
+	})
+
+	for _, mpq := range p.mpqs {
+		if mpq == nil {
+			continue
+		}
+
+		files, err := mpq.Listfile()
+		if err != nil {
+			continue
+		}
+
+		sort.Strings(files)
+
+		for _, file := range files {
+			gamePath := NormalizeGamePath(file)
+			if found[gamePath] || !match(gamePath) {
+				continue
+			}
+
+			found[gamePath] = true
+
+			result = append(result, &common.PathEntry{
+				Name:     filepath.Base(strings.ReplaceAll(file, gamePathSep, "/")),
+				FullPath: file,
+				Source:   common.PathEntrySourceMPQ,
+				MPQFile:  mpq.Path(),
+			})
+		}
+	}
+
+	return result
+}
+
+// GetFileFromGamePath looks for the given game path in the composite view of the project.
+// Returns nil if file doesn't exist.
+func (p *Project) GetFileFromGamePath(gamePath string) *common.PathEntry {
+	gamePath = NormalizeGamePath(gamePath)
+
+	contentPath := p.GamePathToContentPath(gamePath)
+	if entry := p.findContentFile(contentPath); entry != nil {
+		return entry
+	}
+
+	for _, mpq := range p.mpqs {
+		if mpq == nil || !mpq.Contains(gamePath) {
+			continue
+		}
+
+		return &common.PathEntry{
+			Name:     filepath.Base(strings.ReplaceAll(gamePath, gamePathSep, "/")),
+			FullPath: gamePath,
+			Source:   common.PathEntrySourceMPQ,
+			MPQFile:  mpq.Path(),
+		}
+	}
+
+	return nil
+}
+
+// findContentFile looks for the path in content directory ignoring letters case
+// (game paths are case-insensitive)
+func (p *Project) findContentFile(path string) *common.PathEntry {
+	rel, err := filepath.Rel(p.GetProjectFileContentPath(), path)
+	if err != nil {
+		return nil
+	}
+
+	current := p.GetProjectFileContentPath()
+
+	for _, part := range strings.Split(rel, string(filepath.Separator)) {
+		entries, err := os.ReadDir(current)
+		if err != nil {
+			return nil
+		}
+
+		next := ""
+
+		for _, entry := range entries {
+			if strings.EqualFold(entry.Name(), part) {
+				next = filepath.Join(current, entry.Name())
+
+				break
+			}
+		}
+
+		if next == "" {
+			return nil
+		}
+
+		current = next
+	}
+
+	if info, err := os.Stat(current); err != nil || info.IsDir() {
+		return nil
+	}
+
+	return &common.PathEntry{
+		Name:     filepath.Base(current),
+		FullPath: current,
+		Source:   common.PathEntrySourceProject,
+	}
+}
+
+// ReadFile reads file from path entry. Unlike common.PathEntry.GetFileBytes,
+// it uses project's already loaded MPQs if possible.
+func (p *Project) ReadFile(entry *common.PathEntry) ([]byte, error) {
+	if entry.Source != common.PathEntrySourceMPQ {
+		return entry.GetFileBytes()
+	}
+
+	for _, mpq := range p.mpqs {
+		if mpq == nil || mpq.Path() != entry.MPQFile {
+			continue
+		}
+
+		data, err := mpq.ReadFile(entry.FullPath)
+		if err != nil {
+			return nil, fmt.Errorf("error reading file from mpq: %w", err)
+		}
+
+		return data, nil
+	}
+
+	return entry.GetFileBytes()
+}
+
+// WriteGameFile writes the file of the game path given into project's content directory.
+// Written file shadows the file from MPQs.
+func (p *Project) WriteGameFile(gamePath string, data []byte) error {
+	path := p.GamePathToContentPath(gamePath)
+
+	if err := os.MkdirAll(filepath.Dir(path), os.FileMode(newDirMode)); err != nil {
+		return fmt.Errorf("cannot create directory for %s: %w", gamePath, err)
+	}
+
+	if err := os.WriteFile(path, data, os.FileMode(newFileMode)); err != nil {
+		return fmt.Errorf("cannot write %s: %w", gamePath, err)
+	}
+
+	return nil
+}
+
+// Detached returns a copy of the project with its own handles of auxiliary MPQs.
+// MPQ handles can't be shared between goroutines, so background jobs (e.g. validation
+// or loading previews) should work on a detached project. Call the function returned
+// to close the handles when the job is done.
+func (p *Project) Detached() (detached *Project, closeMPQs func()) {
+	result := *p
+	result.pathEntryCache = nil
+	result.mpqs = make([]d2interface.Archive, len(p.mpqs))
+
+	for idx, mpq := range p.mpqs {
+		if mpq == nil {
+			continue
+		}
+
+		data, err := d2mpq.FromFile(mpq.Path())
+		if err != nil {
+			log.Printf("error opening %s: %v", mpq.Path(), err)
+
+			continue
+		}
+
+		result.mpqs[idx] = data
+	}
+
+	return &result, func() {
+		for _, mpq := range result.mpqs {
+			if mpq == nil {
+				continue
+			}
+
+			if err := mpq.Close(); err != nil {
+				log.Printf("error closing %s: %v", mpq.Path(), err)
+			}
+		}
+	}
+}
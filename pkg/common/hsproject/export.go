@@ -0,0 +1,55 @@
+package hsproject
+
+import (
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/gucio321/HellSpawner/pkg/common/hsmpq"
+)
+
+const mpqExtension = ".mpq"
+
+// ExportedMPQPath returns a default path of project's exported MPQ
+// (next to the project's file)
+func (p *Project) ExportedMPQPath() string {
+	name := strings.TrimSuffix(filepath.Base(p.filePath), filepath.Ext(p.filePath))
+
+	return filepath.Join(filepath.Dir(p.filePath), name+mpqExtension)
+}
+
+// ExportMPQ writes all the files from project's content into an MPQ archive.
+// Returns a number of files exported.
+func (p *Project) ExportMPQ(path string) (int, error) {
+	writer := hsmpq.NewWriter()
+
+	err := filepath.WalkDir(p.GetProjectFileContentPath(), func(filePath string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+
+		if d.IsDir() {
+			return nil
+		}
+
+		data, err := os.ReadFile(filepath.Clean(filePath))
+		if err != nil {
+			return fmt.Errorf("error reading %s: %w", filePath, err)
+		}
+
+		writer.Add(p.ContentPathToGamePath(filePath), data)
+
+		return nil
+	})
+	if err != nil {
+		return 0, fmt.Errorf("error collecting project's files: %w", err)
+	}
+
+	if err := writer.Save(path); err != nil {
+		return 0, fmt.Errorf("error exporting MPQ: %w", err)
+	}
+
+	return writer.Len(), nil
+}
@@ -0,0 +1,181 @@
+package hsproject
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+const copySuffix = " copy"
+
+// MoveFile moves a file or directory into the directory given.
+// Returns the new path.
+func (p *Project) MoveFile(path, dir string) (string, error) {
+	newPath := filepath.Join(dir, filepath.Base(path))
+	if newPath == path {
+		return path, nil
+	}
+
+	if isInside(dir, path) {
+		return "", fmt.Errorf("cannot move %s into itself", path)
+	}
+
+	if _, err := os.Stat(newPath); !os.IsNotExist(err) {
+		return "", fmt.Errorf("cannot move %s: %s already exists", path, newPath)
+	}
+
+	if err := os.Rename(path, newPath); err != nil {
+		return "", fmt.Errorf("cannot move %s: %w", path, err)
+	}
+
+	p.InvalidateFileStructure()
+
+	return newPath, nil
+}
+
+// CopyFile copies a file or directory into the directory given. If there already is a file with the same name,
+// the copy gets a unique one (e.g. "name copy 2.ext"). Returns the new path.
+func (p *Project) CopyFile(path, dir string) (string, error) {
+	if isInside(dir, path) {
+		return "", fmt.Errorf("cannot copy %s into itself", path)
+	}
+
+	newPath, err := uniqueCopyPath(filepath.Join(dir, filepath.Base(path)))
+	if err != nil {
+		return "", err
+	}
+
+	err = filepath.WalkDir(path, func(src string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+
+		rel, err := filepath.Rel(path, src)
+		if err != nil {
+			return err
+		}
+
+		dst := filepath.Join(newPath, rel)
+
+		if d.IsDir() {
+			return os.MkdirAll(dst, os.FileMode(newDirMode))
+		}
+
+		return copyFile(src, dst)
+	})
+	if err != nil {
+		return "", fmt.Errorf("cannot copy %s: %w", path, err)
+	}
+
+	p.InvalidateFileStructure()
+
+	return newPath, nil
+}
+
+// DuplicateFile copies a file or directory next to itself
+func (p *Project) DuplicateFile(path string) (string, error) {
+	return p.CopyFile(path, filepath.Dir(path))
+}
+
+// isInside returns true if path is the dir or is inside of it
+func isInside(path, dir string) bool {
+	rel, err := filepath.Rel(dir, path)
+
+	return err == nil && rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator))
+}
+
+func uniqueCopyPath(path string) (string, error) {
+	if _, err := os.Stat(path); os.IsNotExist(err) {
+		return path, nil
+	}
+
+	ext := filepath.Ext(path)
+	base := strings.TrimSuffix(path, ext) + copySuffix
+
+	if _, err := os.Stat(base + ext); os.IsNotExist(err) {
+		return base + ext, nil
+	}
+
+	// %% in a path would break the format
+	fmtPath := strings.ReplaceAll(base, "%", "%%") + " %d" + strings.ReplaceAll(ext, "%", "%%")
+
+	for i := 2; i <= maxNewFileAttempts; i++ {
+		possiblePath := fmt.Sprintf(fmtPath, i)
+		if _, err := os.Stat(possiblePath); os.IsNotExist(err) {
+			return possiblePath, nil
+		}
+	}
+
+	return "", errors.New("could not find a name for the copy of " + path)
+}
+
+func copyFile(src, dst string) error {
+	return copyFileWith(src, func(mode os.FileMode) (*os.File, error) {
+		return os.OpenFile(filepath.Clean(dst), os.O_CREATE|os.O_EXCL|os.O_WRONLY, mode)
+	})
+}
+
+// replaceFile copies src over an existing dst. The data are copied into a temporary file
+// in dst's directory first, so that dst is kept untouched if copying fails.
+func replaceFile(src, dst string) error {
+	var tmp *os.File
+
+	err := copyFileWith(src, func(mode os.FileMode) (*os.File, error) {
+		f, err := os.CreateTemp(filepath.Dir(dst), "."+filepath.Base(dst)+".*")
+		if err != nil {
+			return nil, err
+		}
+
+		tmp = f
+
+		return f, f.Chmod(mode)
+	})
+
+	if err == nil {
+		err = os.Rename(tmp.Name(), dst)
+	}
+
+	if err != nil && tmp != nil {
+		_ = os.Remove(tmp.Name())
+	}
+
+	return err
+}
+
+// copyFileWith copies src into the file created by create (called with src's mode)
+func copyFileWith(src string, create func(mode os.FileMode) (*os.File, error)) error {
+	in, err := os.Open(filepath.Clean(src))
+	if err != nil {
+		return err
+	}
+
+	defer func() {
+		_ = in.Close()
+	}()
+
+	info, err := in.Stat()
+	if err != nil {
+		return err
+	}
+
+	out, err := create(info.Mode())
+	if err != nil {
+		if out != nil {
+			_ = out.Close()
+		}
+
+		return err
+	}
+
+	if _, err := io.Copy(out, in); err != nil {
+		_ = out.Close()
+
+		return err
+	}
+
+	return out.Close()
+}
@@ -0,0 +1,57 @@
+package hsproject
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func Test_Project_FileOperations(t *testing.T) {
+	project := newTestProject(t)
+
+	content := project.GetProjectFileContentPath()
+	folder := filepath.Join(content, "folder")
+	file := filepath.Join(content, "file.txt")
+
+	if err := os.MkdirAll(folder, 0o755); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := os.WriteFile(file, []byte("data"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	duplicates := []string{"file copy.txt", "file copy 2.txt"}
+	for _, expected := range duplicates {
+		duplicate, err := project.DuplicateFile(file)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if duplicate != filepath.Join(content, expected) {
+			t.Errorf("expected %s, got %s", expected, duplicate)
+		}
+	}
+
+	moved, err := project.MoveFile(file, folder)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if data, err := os.ReadFile(moved); err != nil || string(data) != "data" {
+		t.Fatalf("file wasn't moved: %v", err)
+	}
+
+	copied, err := project.CopyFile(folder, content)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := os.Stat(filepath.Join(copied, "file.txt")); err != nil {
+		t.Fatalf("directory wasn't copied: %v", err)
+	}
+
+	if _, err := project.MoveFile(content, folder); err == nil {
+		t.Error("expected error on moving directory into itself")
+	}
+}
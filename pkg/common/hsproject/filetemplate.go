@@ -0,0 +1,196 @@
+package hsproject
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/OpenDiablo2/OpenDiablo2/d2common/d2fileformats/d2ds1"
+	"github.com/OpenDiablo2/OpenDiablo2/d2common/d2fileformats/d2tbl"
+
+	"github.com/gucio321/HellSpawner/pkg/common"
+	"github.com/gucio321/HellSpawner/pkg/common/hsfiletypes"
+)
+
+const (
+	maxDS1Size = 256
+	numActs    = 5
+)
+
+// FileTemplateParameter is a value user fills in before a file is created from the template
+type FileTemplateParameter struct {
+	Name string
+	// Default is used if user doesn't provide a value
+	Default string
+	// Number parameters are edited as integers
+	Number bool
+}
+
+// FileTemplate is a parametrized starting point of a new file
+type FileTemplate struct {
+	Name       string
+	FileType   hsfiletypes.FileType
+	Parameters []FileTemplateParameter
+	generate   func(values map[string]string) ([]byte, error)
+}
+
+// FileTemplates returns templates available in the "New" menu
+func FileTemplates() []*FileTemplate {
+	return []*FileTemplate{
+		{
+			Name:     "Map of size",
+			FileType: hsfiletypes.FileTypeDS1,
+			Parameters: []FileTemplateParameter{
+				{Name: "Width", Default: "8", Number: true},
+				{Name: "Height", Default: "8", Number: true},
+				{Name: "Act", Default: "1", Number: true},
+			},
+			generate: generateDS1,
+		},
+		{
+			Name:     "String table with keys",
+			FileType: hsfiletypes.FileTypeTBLStringTable,
+			Parameters: []FileTemplateParameter{
+				{Name: "Keys (comma separated)", Default: ""},
+			},
+			generate: generateStringTable,
+		},
+		{
+			Name:     "Excel table with columns",
+			FileType: hsfiletypes.FileTypeText,
+			Parameters: []FileTemplateParameter{
+				{Name: "Columns (comma separated)", Default: "Name"},
+			},
+			generate: generateExcelTable,
+		},
+	}
+}
+
+// Generate creates file's data. Missing values are replaced with parameters' defaults.
+func (t *FileTemplate) Generate(values map[string]string) ([]byte, error) {
+	filled := make(map[string]string, len(t.Parameters))
+
+	for _, param := range t.Parameters {
+		value, found := values[param.Name]
+		if !found {
+			value = param.Default
+		}
+
+		filled[param.Name] = strings.TrimSpace(value)
+	}
+
+	return t.generate(filled)
+}
+
+// CreateNewFileFromTemplate creates a new file from the template in the directory given
+func (p *Project) CreateNewFileFromTemplate(t *FileTemplate, values map[string]string, path *common.PathEntry) error {
+	data, err := t.Generate(values)
+	if err != nil {
+		return fmt.Errorf("error generating %s: %w", t.Name, err)
+	}
+
+	fileName, err := newFilePath(path.FullPath, t.FileType.FileExtension())
+	if err != nil {
+		return err
+	}
+
+	if err := os.WriteFile(fileName, data, os.FileMode(newFileMode)); err != nil {
+		return fmt.Errorf("cannot write to file %s: %w", fileName, err)
+	}
+
+	return p.startRenamingNewFile(fileName)
+}
+
+func intValue(values map[string]string, name string, minValue, maxValue int) (int, error) {
+	value, err := strconv.Atoi(values[name])
+	if err != nil {
+		return 0, fmt.Errorf("%s should be a number: %w", name, err)
+	}
+
+	if value < minValue || value > maxValue {
+		return 0, fmt.Errorf("%s should be between %d and %d", name, minValue, maxValue)
+	}
+
+	return value, nil
+}
+
+// listValue splits comma separated list skipping empty items
+func listValue(values map[string]string, name string) []string {
+	result := make([]string, 0)
+
+	for _, item := range strings.Split(values[name], ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			result = append(result, item)
+		}
+	}
+
+	return result
+}
+
+func generateDS1(values map[string]string) ([]byte, error) {
+	width, err := intValue(values, "Width", 1, maxDS1Size)
+	if err != nil {
+		return nil, err
+	}
+
+	height, err := intValue(values, "Height", 1, maxDS1Size)
+	if err != nil {
+		return nil, err
+	}
+
+	act, err := intValue(values, "Act", 1, numActs)
+	if err != nil {
+		return nil, err
+	}
+
+	// d2ds1 can't create a map from scratch, so a 1x1 map (version 18, one wall and one floor layer)
+	// is loaded and resized
+	const (
+		version = 18
+		// wall, orientation, floor and shadow of the only tile
+		tileStreams = 4
+	)
+
+	// version, size - 1, act, substitution type, number of files, walls and floors
+	header := []int32{version, 0, 0, int32(act - 1), 0, 0, 1, 1} //nolint:gosec // act is checked above
+	header = append(header, make([]int32, tileStreams)...)
+	// objects and NPCs
+	header = append(header, 0, 0)
+
+	buf := &bytes.Buffer{}
+	if err := binary.Write(buf, binary.LittleEndian, header); err != nil {
+		return nil, fmt.Errorf("error writing map: %w", err)
+	}
+
+	ds1, err := d2ds1.Unmarshal(buf.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("error creating map: %w", err)
+	}
+
+	ds1.SetSize(width, height)
+
+	return ds1.Marshal(), nil
+}
+
+func generateStringTable(values map[string]string) ([]byte, error) {
+	dict := d2tbl.TextDictionary{}
+
+	for _, key := range listValue(values, "Keys (comma separated)") {
+		dict[key] = ""
+	}
+
+	return dict.Marshal(), nil
+}
+
+func generateExcelTable(values map[string]string) ([]byte, error) {
+	columns := listValue(values, "Columns (comma separated)")
+	if len(columns) == 0 {
+		return nil, errors.New("table should have at least one column")
+	}
+
+	return []byte(strings.Join(columns, "\t") + "\r\n"), nil
+}
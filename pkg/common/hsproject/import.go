@@ -0,0 +1,179 @@
+package hsproject
+
+import (
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/gucio321/HellSpawner/pkg/common/hsfiletypes"
+)
+
+// ImportConflictPolicy decides what to do with imported files, which already exist in the project
+//
+//go:generate stringer -linecomment -type ImportConflictPolicy -output importconflictpolicy_string.go
+type ImportConflictPolicy byte
+
+// import conflict policies
+const (
+	ImportOverwrite ImportConflictPolicy = iota // Overwrite
+	ImportSkip                                  // Skip
+	ImportRename                                // Rename
+)
+
+// ImportFile is a single file to import
+type ImportFile struct {
+	Source      string
+	Destination string
+	Type        hsfiletypes.FileType
+	// Exists is true if the destination file already exists
+	Exists bool
+}
+
+// ImportPlan is a list of files to import
+type ImportPlan []ImportFile
+
+// Conflicts returns a number of files, which already exist in the project
+func (p ImportPlan) Conflicts() int {
+	result := 0
+
+	for _, f := range p {
+		if f.Exists {
+			result++
+		}
+	}
+
+	return result
+}
+
+// ImportResult summarizes an import
+type ImportResult struct {
+	Imported int
+	Skipped  int
+	// Unknown are imported files, which type HellSpawner doesn't know
+	Unknown []string
+}
+
+// PlanImport collects files to import into the project's directory given. Sources may be files
+// or directories; directories are imported together with their content.
+// Hidden files (starting with a dot) are skipped.
+func (p *Project) PlanImport(sources []string, dir string) (ImportPlan, error) {
+	if !isInside(dir, p.GetProjectFileContentPath()) {
+		return nil, fmt.Errorf("%s is not inside of the project", dir)
+	}
+
+	result := make(ImportPlan, 0)
+
+	for _, source := range sources {
+		source = filepath.Clean(source)
+		base := filepath.Dir(source)
+
+		err := filepath.WalkDir(source, func(path string, d fs.DirEntry, err error) error {
+			if err != nil {
+				return err
+			}
+
+			if strings.HasPrefix(d.Name(), ".") {
+				if d.IsDir() {
+					return filepath.SkipDir
+				}
+
+				return nil
+			}
+
+			if d.IsDir() {
+				return nil
+			}
+
+			rel, err := filepath.Rel(base, path)
+			if err != nil {
+				return err
+			}
+
+			result = append(result, p.planImportFile(path, filepath.Join(dir, rel)))
+
+			return nil
+		})
+		if err != nil {
+			return nil, fmt.Errorf("error reading %s: %w", source, err)
+		}
+	}
+
+	return result, nil
+}
+
+func (p *Project) planImportFile(source, destination string) ImportFile {
+	result := ImportFile{
+		Source:      source,
+		Destination: destination,
+	}
+
+	if _, err := os.Stat(destination); err == nil {
+		result.Exists = true
+	}
+
+	// we need data only for types sharing extension (e.g. .tbl)
+	var data []byte
+	if d, err := os.ReadFile(filepath.Clean(source)); err == nil {
+		data = d
+	}
+
+	if fileType, err := hsfiletypes.GetFileTypeFromExtension(filepath.Ext(source), &data); err == nil {
+		result.Type = fileType
+	}
+
+	return result
+}
+
+// Import copies files of the plan into the project
+func (p *Project) Import(plan ImportPlan, policy ImportConflictPolicy) (*ImportResult, error) {
+	result := &ImportResult{}
+
+	defer p.InvalidateFileStructure()
+
+	for _, f := range plan {
+		destination := f.Destination
+
+		overwrite := false
+
+		if f.Exists {
+			switch policy {
+			case ImportSkip:
+				result.Skipped++
+
+				continue
+			case ImportRename:
+				path, err := uniqueCopyPath(destination)
+				if err != nil {
+					return result, err
+				}
+
+				destination = path
+			case ImportOverwrite:
+				overwrite = true
+			}
+		}
+
+		if err := os.MkdirAll(filepath.Dir(destination), os.FileMode(newDirMode)); err != nil {
+			return result, fmt.Errorf("cannot create directory for %s: %w", destination, err)
+		}
+
+		copyFunc := copyFile
+		if overwrite {
+			copyFunc = replaceFile
+		}
+
+		if err := copyFunc(f.Source, destination); err != nil {
+			return result, fmt.Errorf("cannot import %s: %w", f.Source, err)
+		}
+
+		result.Imported++
+
+		if f.Type == hsfiletypes.FileTypeUnknown {
+			result.Unknown = append(result.Unknown, destination)
+		}
+	}
+
+	return result, nil
+}
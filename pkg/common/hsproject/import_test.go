@@ -0,0 +1,89 @@
+package hsproject
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/gucio321/HellSpawner/pkg/common/hsfiletypes"
+)
+
+func Test_Project_Import(t *testing.T) {
+	project := newTestProject(t)
+	external := t.TempDir()
+	content := project.GetProjectFileContentPath()
+
+	files := map[string]string{
+		filepath.Join(external, "armor.txt"):            "new",
+		filepath.Join(external, "ui", "panel.dc6"):      "dc6",
+		filepath.Join(external, "ui", ".hidden"):        "hidden",
+		filepath.Join(external, "ui", "readme.unknown"): "?",
+		filepath.Join(content, "armor.txt"):             "old",
+	}
+
+	for path, data := range files {
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+
+		if err := os.WriteFile(path, []byte(data), 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	sources := []string{filepath.Join(external, "armor.txt"), filepath.Join(external, "ui")}
+
+	plan, err := project.PlanImport(sources, content)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(plan) != 3 || plan.Conflicts() != 1 {
+		t.Fatalf("unexpected plan %v", plan)
+	}
+
+	for _, f := range plan {
+		if f.Destination == filepath.Join(content, "ui", "panel.dc6") && f.Type != hsfiletypes.FileTypeDC6 {
+			t.Errorf("unexpected type of %s: %s", f.Destination, f.Type)
+		}
+	}
+
+	tests := []struct {
+		policy   ImportConflictPolicy
+		imported int
+		path     string
+		expected string
+	}{
+		{ImportSkip, 2, "armor.txt", "old"},
+		{ImportRename, 3, "armor copy.txt", "new"},
+		{ImportOverwrite, 3, "armor.txt", "new"},
+	}
+
+	for _, tt := range tests {
+		plan, err := project.PlanImport(sources, content)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		result, err := project.Import(plan, tt.policy)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if result.Imported != tt.imported || len(result.Unknown) != 1 {
+			t.Errorf("%s: unexpected result %+v", tt.policy, result)
+		}
+
+		if data, err := os.ReadFile(filepath.Join(content, tt.path)); err != nil || string(data) != tt.expected {
+			t.Errorf("%s: unexpected content of %s: %q (%v)", tt.policy, tt.path, data, err)
+		}
+	}
+
+	if leftovers, _ := filepath.Glob(filepath.Join(content, ".armor.txt.*")); len(leftovers) != 0 {
+		t.Errorf("temporary files left after overwriting: %v", leftovers)
+	}
+
+	if _, err := project.PlanImport(sources, external); err == nil {
+		t.Error("expected error on importing outside of the project")
+	}
+}
@@ -0,0 +1,170 @@
+package hsproject
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"unicode"
+)
+
+// Variables available in launch profile's templates
+const (
+	LaunchVarProject     = "project"
+	LaunchVarContent     = "content"
+	LaunchVarExportedMPQ = "exportedMPQ"
+	LaunchVarLocale      = "locale"
+)
+
+// PreLaunchStep is an action done before launching a profile
+//
+//go:generate stringer -linecomment -type PreLaunchStep -output prelaunchstep_string.go
+type PreLaunchStep byte
+
+// pre-launch steps
+const (
+	PreLaunchNone      PreLaunchStep = iota // None
+	PreLaunchExportMPQ                      // Export MPQ
+)
+
+// PreLaunchSteps returns all the pre-launch steps
+func PreLaunchSteps() []PreLaunchStep {
+	return []PreLaunchStep{PreLaunchNone, PreLaunchExportMPQ}
+}
+
+// LaunchProfile describes how to run the project in an external engine.
+// Executable, Args, Env and WorkDir are templates, which may contain
+// ${project}, ${content}, ${exportedMPQ} and ${locale} (see LaunchVariables).
+// Other ${VARIABLES} are taken from HellSpawner's environment.
+type LaunchProfile struct {
+	Name       string
+	Executable string
+	Args       string
+	// Env contains environment variables in form KEY=value
+	Env       []string
+	WorkDir   string
+	PreLaunch PreLaunchStep
+}
+
+// LaunchVariables are values of launch profile's template variables
+type LaunchVariables map[string]string
+
+// LaunchProfilePresets returns profiles, which may be used as a base of the new ones
+func LaunchProfilePresets() []LaunchProfile {
+	return []LaunchProfile{
+		{
+			Name:       "OpenDiablo2",
+			Executable: "OpenDiablo2",
+		},
+		{
+			Name:       "Diablo II (-direct -txt)",
+			Executable: "Game.exe",
+			Args:       "-direct -txt",
+			PreLaunch:  PreLaunchExportMPQ,
+		},
+	}
+}
+
+// LaunchVariables returns values of launch profile's variables for the project
+func (p *Project) LaunchVariables(locale string) LaunchVariables {
+	return LaunchVariables{
+		LaunchVarProject:     p.GetProjectFilePath(),
+		LaunchVarContent:     p.GetProjectFileContentPath(),
+		LaunchVarExportedMPQ: p.ExportedMPQPath(),
+		LaunchVarLocale:      locale,
+	}
+}
+
+// Expand replaces variables in s. Unknown variables are taken from the environment.
+func (v LaunchVariables) Expand(s string) string {
+	return os.Expand(s, func(name string) string {
+		if value, ok := v[name]; ok {
+			return value
+		}
+
+		return os.Getenv(name)
+	})
+}
+
+// Command returns expanded executable path, arguments, environment and working directory of the profile.
+// If working directory isn't set, the executable's directory is used.
+func (l *LaunchProfile) Command(vars LaunchVariables) (executable string, args, env []string, workDir string, err error) {
+	executable = vars.Expand(l.Executable)
+	if strings.TrimSpace(executable) == "" {
+		return "", nil, nil, "", errors.New("launch profile doesn't specify an executable")
+	}
+
+	// arguments are split before expanding, so that paths with spaces stay a single argument
+	rawArgs, err := SplitArgs(l.Args)
+	if err != nil {
+		return "", nil, nil, "", err
+	}
+
+	args = make([]string, len(rawArgs))
+	for idx, arg := range rawArgs {
+		args[idx] = vars.Expand(arg)
+	}
+
+	env = make([]string, 0, len(l.Env))
+
+	for _, e := range l.Env {
+		if strings.TrimSpace(e) == "" {
+			continue
+		}
+
+		env = append(env, vars.Expand(e))
+	}
+
+	workDir = vars.Expand(l.WorkDir)
+	if workDir == "" && filepath.Dir(executable) != "." {
+		workDir = filepath.Dir(executable)
+	}
+
+	return executable, args, env, workDir, nil
+}
+
+// SplitArgs splits command line into arguments. Arguments are separated by whitespaces;
+// single and double quotes may be used to group them.
+func SplitArgs(cmdLine string) ([]string, error) {
+	var (
+		result  []string
+		current strings.Builder
+		inArg   bool
+		quote   rune
+	)
+
+	for _, r := range cmdLine {
+		switch {
+		case quote != 0:
+			if r == quote {
+				quote = 0
+			} else {
+				current.WriteRune(r)
+			}
+		case r == '"' || r == '\'':
+			quote = r
+			inArg = true
+		case unicode.IsSpace(r):
+			if inArg {
+				result = append(result, current.String())
+				current.Reset()
+
+				inArg = false
+			}
+		default:
+			current.WriteRune(r)
+
+			inArg = true
+		}
+	}
+
+	if quote != 0 {
+		return nil, errors.New("unterminated quote in arguments")
+	}
+
+	if inArg {
+		result = append(result, current.String())
+	}
+
+	return result, nil
+}
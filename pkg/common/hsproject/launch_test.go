@@ -0,0 +1,71 @@
+package hsproject
+
+import (
+	"reflect"
+	"testing"
+)
+
+func Test_SplitArgs(t *testing.T) {
+	tests := []struct {
+		cmdLine  string
+		expected []string
+	}{
+		{"", nil},
+		{"-direct -txt", []string{"-direct", "-txt"}},
+		{`  -mpq "${exportedMPQ}"  -w`, []string{"-mpq", "${exportedMPQ}", "-w"}},
+		{`--name='my mod' ""`, []string{"--name=my mod", ""}},
+	}
+
+	for _, tt := range tests {
+		args, err := SplitArgs(tt.cmdLine)
+		if err != nil {
+			t.Errorf("%q: unexpected error %v", tt.cmdLine, err)
+
+			continue
+		}
+
+		if !reflect.DeepEqual(args, tt.expected) {
+			t.Errorf("%q: expected %q, got %q", tt.cmdLine, tt.expected, args)
+		}
+	}
+
+	if _, err := SplitArgs(`-mpq "unterminated`); err == nil {
+		t.Error("expected error on unterminated quote")
+	}
+}
+
+func Test_LaunchProfile_Command(t *testing.T) {
+	profile := &LaunchProfile{
+		Executable: "/games/d2/Game.exe",
+		Args:       `-direct -txt -mpq ${exportedMPQ} -l ${locale}`,
+		Env:        []string{"MOD=${project}", ""},
+	}
+
+	vars := LaunchVariables{
+		LaunchVarProject:     "/my projects/mod.hsp",
+		LaunchVarExportedMPQ: "/my projects/mod.mpq",
+		LaunchVarLocale:      "English",
+	}
+
+	executable, args, env, workDir, err := profile.Command(vars)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if executable != "/games/d2/Game.exe" || workDir != "/games/d2" {
+		t.Errorf("unexpected executable %q or working directory %q", executable, workDir)
+	}
+
+	expectedArgs := []string{"-direct", "-txt", "-mpq", "/my projects/mod.mpq", "-l", "English"}
+	if !reflect.DeepEqual(args, expectedArgs) {
+		t.Errorf("expected arguments %q, got %q", expectedArgs, args)
+	}
+
+	if !reflect.DeepEqual(env, []string{"MOD=/my projects/mod.hsp"}) {
+		t.Errorf("unexpected environment %q", env)
+	}
+
+	if _, _, _, _, err := (&LaunchProfile{}).Command(vars); err == nil {
+		t.Error("expected error on empty executable")
+	}
+}
@@ -17,6 +17,13 @@ type marshaler interface {
 	Marshal() []byte
 }
 
+// templateMarshaler marshals a new file of the type registered at runtime
+type templateMarshaler func() []byte
+
+func (t templateMarshaler) Marshal() []byte {
+	return t()
+}
+
 func getMarshallerByType(fileType hsfiletypes.FileType) marshaler {
 	switch fileType {
 	case hsfiletypes.FileTypeTBLFontTable:
@@ -37,5 +44,9 @@ func getMarshallerByType(fileType hsfiletypes.FileType) marshaler {
 		return d2dt1.New()
 	}
 
+	if d, ok := hsfiletypes.Registered(fileType); ok && d.Template != nil {
+		return templateMarshaler(d.Template)
+	}
+
 	return nil
 }
@@ -0,0 +1,101 @@
+package hsproject
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+)
+
+// ProjectVersion is a version of project's file format saved by this HellSpawner.
+// Increase it together with adding a migration, whenever Project's structure changes.
+const ProjectVersion = 1
+
+const (
+	versionField      = "Version"
+	maxBackupAttempts = 100
+)
+
+// ErrProjectTooNew is returned when the project was saved by a newer HellSpawner
+var ErrProjectTooNew = errors.New("project was saved by a newer version of HellSpawner")
+
+// migration upgrades project's data by one version
+type migration func(project map[string]interface{}) error
+
+// migrations[i] upgrades a project of version i to version i+1
+var migrations = []migration{
+	migrateUnversioned,
+}
+
+// migrateUnversioned upgrades projects saved before the format was versioned.
+// Their structure is the same, they only miss the version field.
+func migrateUnversioned(_ map[string]interface{}) error {
+	return nil
+}
+
+// migrate upgrades project's file data to the current version.
+// It returns the version of the data given and the upgraded data.
+func migrate(data []byte) (version int, result []byte, err error) {
+	var project map[string]interface{}
+
+	if err := json.Unmarshal(data, &project); err != nil {
+		return 0, nil, fmt.Errorf("project is malformed: %w", err)
+	}
+
+	if value, found := project[versionField]; found {
+		number, ok := value.(float64)
+		if !ok || number != float64(int(number)) || number < 0 {
+			return 0, nil, fmt.Errorf("invalid project version %v", value)
+		}
+
+		version = int(number)
+	}
+
+	switch {
+	case version > ProjectVersion:
+		return version, nil, fmt.Errorf("%w (project's version is %d, the newest supported is %d); please update HellSpawner",
+			ErrProjectTooNew, version, ProjectVersion)
+	case version == ProjectVersion:
+		return version, data, nil
+	}
+
+	for v := version; v < ProjectVersion; v++ {
+		if err := migrations[v](project); err != nil {
+			return version, nil, fmt.Errorf("error migrating project from version %d to %d: %w", v, v+1, err)
+		}
+	}
+
+	project[versionField] = ProjectVersion
+
+	if result, err = json.MarshalIndent(project, "", "   "); err != nil {
+		return version, nil, fmt.Errorf("cannot marshal migrated project: %w", err)
+	}
+
+	return version, result, nil
+}
+
+// backupProject writes a copy of the project's file data before migration (e.g. "mod.hsp.v0.bak").
+// Returns backup's path.
+func backupProject(fileName string, version int, data []byte) (string, error) {
+	path := fmt.Sprintf("%s.v%d.bak", fileName, version)
+
+	for i := 1; fileExists(path); i++ {
+		if i > maxBackupAttempts {
+			return "", fmt.Errorf("cannot find a free name for backup of %s", fileName)
+		}
+
+		path = fmt.Sprintf("%s.v%d.%d.bak", fileName, version, i)
+	}
+
+	if err := os.WriteFile(path, data, os.FileMode(newFileMode)); err != nil {
+		return "", fmt.Errorf("cannot write backup %s: %w", path, err)
+	}
+
+	return path, nil
+}
+
+func fileExists(path string) bool {
+	_, err := os.Stat(path)
+
+	return err == nil
+}
@@ -0,0 +1,49 @@
+package hsproject
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func Test_LoadFromFile_Migration(t *testing.T) {
+	if len(migrations) != ProjectVersion {
+		t.Fatalf("there should be a migration for every version (%d migrations, version %d)", len(migrations), ProjectVersion)
+	}
+
+	dir := t.TempDir()
+
+	fileName := filepath.Join(dir, "old.hsp")
+	unversioned := []byte(`{"ProjectName": "old", "AuxiliaryMPQs": ["d2data.mpq"]}`)
+
+	if err := os.WriteFile(fileName, unversioned, 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	project, err := LoadFromFile(fileName)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if project.Version != ProjectVersion || project.ProjectName != "old" || len(project.AuxiliaryMPQs) != 1 {
+		t.Errorf("unexpected migrated project %+v", project)
+	}
+
+	if backup, err := os.ReadFile(fileName + ".v0.bak"); err != nil || string(backup) != string(unversioned) {
+		t.Errorf("original project wasn't backed up (%v)", err)
+	}
+
+	if saved, err := os.ReadFile(fileName); err != nil || string(saved) == string(unversioned) {
+		t.Errorf("migrated project wasn't saved (%v)", err)
+	}
+
+	newer := []byte(`{"Version": 1000, "ProjectName": "new"}`)
+	if err := os.WriteFile(fileName, newer, 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := LoadFromFile(fileName); !errors.Is(err, ErrProjectTooNew) {
+		t.Errorf("expected an error about newer project, got %v", err)
+	}
+}
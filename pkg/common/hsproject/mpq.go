@@ -23,12 +23,9 @@ func (p *Project) GetMPQFileNodes(mpq d2interface.Archive, cfg *config.Config) *
 		MPQFile:     mpq.Path(),
 	}
 
-	files, err := mpq.Listfile()
+	files, err := p.ListMPQFiles(mpq, cfg)
 	if err != nil {
-		files, err = p.searchForMpqFiles(mpq, cfg)
-		if err != nil {
-			return result
-		}
+		return result
 	}
 
 	pathNodes := make(map[string]*common.PathEntry)
@@ -66,6 +63,17 @@ func (p *Project) GetMPQFileNodes(mpq d2interface.Archive, cfg *config.Config) *
 	return result
 }
 
+// ListMPQFiles returns paths of mpq's files. If mpq has no listfile,
+// files are searched using the external listfile set in config.
+func (p *Project) ListMPQFiles(mpq d2interface.Archive, cfg *config.Config) ([]string, error) {
+	files, err := mpq.Listfile()
+	if err == nil {
+		return files, nil
+	}
+
+	return p.searchForMpqFiles(mpq, cfg)
+}
+
 // searchForMpqFiles searches for files in MPQ's without listfiles using a list of known filenames
 func (p *Project) searchForMpqFiles(mpq d2interface.Archive, cfg *config.Config) ([]string, error) {
 	var files []string
@@ -0,0 +1,110 @@
+package hsproject
+
+import (
+	"fmt"
+	"path"
+	"strings"
+
+	"github.com/OpenDiablo2/OpenDiablo2/d2common/d2fileformats/d2dat"
+	"github.com/OpenDiablo2/OpenDiablo2/d2common/d2interface"
+
+	"github.com/gucio321/HellSpawner/pkg/common"
+)
+
+const paletteSize = 256 * 3
+
+// PaletteRule assigns a palette to files which game path matches the pattern.
+// Pattern is a glob (e.g. data\global\ui\*), which matches files as well as
+// everything inside of matched directories.
+type PaletteRule struct {
+	Pattern string
+	Palette string
+}
+
+// Matches returns true if the rule matches the game path given
+func (r PaletteRule) Matches(gamePath string) bool {
+	pattern := toSlash(NormalizeGamePath(r.Pattern))
+
+	for p := toSlash(NormalizeGamePath(gamePath)); p != "." && p != "/"; p = path.Dir(p) {
+		if ok, err := path.Match(pattern, p); err == nil && ok {
+			return true
+		}
+	}
+
+	return false
+}
+
+func toSlash(gamePath string) string {
+	return strings.ReplaceAll(gamePath, gamePathSep, "/")
+}
+
+// DefaultPaletteRules returns palette rules used, when none of project's rules matches
+func DefaultPaletteRules() []PaletteRule {
+	return []PaletteRule{
+		{Pattern: `data\global\ui\*`, Palette: `data\global\palette\units\pal.dat`},
+		{Pattern: `data\global\items\*`, Palette: `data\global\palette\units\pal.dat`},
+		{Pattern: `data\global\tiles\act1\*`, Palette: `data\global\palette\act1\pal.dat`},
+		{Pattern: `data\global\tiles\act2\*`, Palette: `data\global\palette\act2\pal.dat`},
+		{Pattern: `data\global\tiles\act3\*`, Palette: `data\global\palette\act3\pal.dat`},
+		{Pattern: `data\global\tiles\act4\*`, Palette: `data\global\palette\act4\pal.dat`},
+		{Pattern: `data\global\tiles\expansion\*`, Palette: `data\global\palette\act5\pal.dat`},
+		{Pattern: `data\global\monsters\*`, Palette: `data\global\palette\act1\pal.dat`},
+		{Pattern: `data\global\chars\*`, Palette: `data\global\palette\act1\pal.dat`},
+		{Pattern: `data\global\objects\*`, Palette: `data\global\palette\act1\pal.dat`},
+		{Pattern: `data\global\missiles\*`, Palette: `data\global\palette\act1\pal.dat`},
+		{Pattern: `data\global\overlays\*`, Palette: `data\global\palette\act1\pal.dat`},
+	}
+}
+
+// EntryGamePath returns game path of the path entry (from project or from MPQ)
+func (p *Project) EntryGamePath(entry *common.PathEntry) string {
+	if entry.Source == common.PathEntrySourceProject {
+		return p.ContentPathToGamePath(entry.FullPath)
+	}
+
+	return NormalizeGamePath(entry.FullPath)
+}
+
+// ResolvePalette looks for a palette for the file given using project's palette rules
+// and then the default ones. Returns nil if no rule matches or the palette doesn't exist.
+func (p *Project) ResolvePalette(entry *common.PathEntry) *common.PathEntry {
+	gamePath := p.EntryGamePath(entry)
+	if gamePath == "" {
+		return nil
+	}
+
+	rules := make([]PaletteRule, 0, len(p.PaletteRules))
+	rules = append(rules, p.PaletteRules...)
+	rules = append(rules, DefaultPaletteRules()...)
+
+	for _, rule := range rules {
+		if !rule.Matches(gamePath) {
+			continue
+		}
+
+		return p.GetFileFromGamePath(rule.Palette)
+	}
+
+	return nil
+}
+
+// LoadPalette loads colors of the palette (.dat) given
+func (p *Project) LoadPalette(entry *common.PathEntry) (*[256]d2interface.Color, error) {
+	data, err := p.ReadFile(entry)
+	if err != nil {
+		return nil, fmt.Errorf("error reading palette %s: %w", entry.FullPath, err)
+	}
+
+	if len(data) < paletteSize {
+		return nil, fmt.Errorf("palette %s is too short (%d bytes)", entry.FullPath, len(data))
+	}
+
+	palette, err := d2dat.Load(data)
+	if err != nil {
+		return nil, fmt.Errorf("error loading palette %s: %w", entry.FullPath, err)
+	}
+
+	colors := palette.GetColors()
+
+	return &colors, nil
+}
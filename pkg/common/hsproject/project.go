@@ -33,10 +33,15 @@ const (
 
 // Project represents HellSpawner's project
 type Project struct {
+	// Version is a version of project's file format (see ProjectVersion)
+	Version       int
 	ProjectName   string
 	Description   string
 	Author        string
 	AuxiliaryMPQs []string
+	PaletteRules  []PaletteRule
+	// LaunchProfiles are listed in Project menu
+	LaunchProfiles []LaunchProfile
 
 	filePath       string
 	pathEntryCache *common.PathEntry
@@ -84,6 +89,8 @@ func (p *Project) Save() error {
 
 	var file []byte
 
+	p.Version = ProjectVersion
+
 	if file, err = json.MarshalIndent(p, "", "   "); err != nil {
 		return fmt.Errorf("cannot marshal project: %w", err)
 	}
@@ -113,7 +120,8 @@ func (p *Project) ValidateAuxiliaryMPQs(cfg *config.Config) error {
 	return nil
 }
 
-// LoadFromFile loads projects file
+// LoadFromFile loads projects file. Projects saved by older HellSpawner are migrated
+// to the current version (the original file is backed up first).
 func LoadFromFile(fileName string) (*Project, error) {
 	var err error
 
@@ -125,12 +133,30 @@ func LoadFromFile(fileName string) (*Project, error) {
 		return nil, fmt.Errorf("cannot read project's file %s: %w", fileName, err)
 	}
 
-	if err := json.Unmarshal(file, &result); err != nil {
+	version, migrated, err := migrate(file)
+	if err != nil {
+		return nil, fmt.Errorf("cannot load project %s: %w", fileName, err)
+	}
+
+	if err := json.Unmarshal(migrated, &result); err != nil {
 		return nil, fmt.Errorf("cannot unmarshal file %s: %w", fileName, err)
 	}
 
 	result.filePath = fileName
 
+	if version != ProjectVersion {
+		backup, err := backupProject(fileName, version, file)
+		if err != nil {
+			return nil, err
+		}
+
+		if err := result.Save(); err != nil {
+			return nil, err
+		}
+
+		log.Printf("project %s migrated from version %d to %d (backup saved at %s)", fileName, version, ProjectVersion, backup)
+	}
+
 	if err := result.ensureProjectPaths(); err != nil {
 		return nil, err
 	}
@@ -301,12 +327,7 @@ func (p *Project) CreateNewFolder(path *common.PathEntry) (err error) {
 
 // CreateNewFile creates a new file
 func (p *Project) CreateNewFile(fileType hsfiletypes.FileType, path *common.PathEntry) (err error) {
-	basePath := path.FullPath
-
-	fmtFile := fmt.Sprintf("untitled%s", fileType.FileExtension())
-	fmtPath := filepath.Join(basePath, fmtFile)
-
-	fileName, err := getNextUniqueNewPath(fmtPath, maxNewFileAttempts)
+	fileName, err := newFilePath(path.FullPath, fileType.FileExtension())
 	if err != nil {
 		logErr("%s", err)
 		return err
@@ -329,10 +350,22 @@ func (p *Project) CreateNewFile(fileType hsfiletypes.FileType, path *common.Path
 		}
 	}
 
+	return p.startRenamingNewFile(fileName)
+}
+
+// newFilePath returns a free path of a new file (untitledN.ext) in the directory given
+func newFilePath(dir, extension string) (string, error) {
+	fmtPath := filepath.Join(dir, "untitled%d"+extension)
+
+	return getNextUniqueNewPath(fmtPath, maxNewFileAttempts)
+}
+
+// startRenamingNewFile refreshes project's files and lets user rename the file just created
+func (p *Project) startRenamingNewFile(fileName string) error {
 	p.InvalidateFileStructure()
 
 	// Force regeneration of file structure so that rename can find the file
-	_, err = p.GetFileStructure()
+	_, err := p.GetFileStructure()
 	p.RenameFile(fileName)
 
 	return err
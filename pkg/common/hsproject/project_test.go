@@ -0,0 +1,18 @@
+package hsproject
+
+import (
+	"path/filepath"
+	"testing"
+)
+
+// newTestProject creates an empty project in a temporary directory
+func newTestProject(t *testing.T) *Project {
+	t.Helper()
+
+	project, err := CreateNew(filepath.Join(t.TempDir(), "test"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return project
+}
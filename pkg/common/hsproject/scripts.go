@@ -0,0 +1,60 @@
+package hsproject
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+const (
+	scriptsDir      = "scripts"
+	scriptExtension = ".js"
+)
+
+// ScriptsPath returns a directory of project's scripts
+func (p *Project) ScriptsPath() string {
+	return filepath.Join(filepath.Dir(p.filePath), scriptsDir)
+}
+
+// Scripts returns sorted names of project's scripts
+func (p *Project) Scripts() ([]string, error) {
+	entries, err := os.ReadDir(p.ScriptsPath())
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, nil
+		}
+
+		return nil, fmt.Errorf("cannot read scripts directory: %w", err)
+	}
+
+	result := make([]string, 0, len(entries))
+
+	for _, entry := range entries {
+		if entry.IsDir() || !strings.EqualFold(filepath.Ext(entry.Name()), scriptExtension) {
+			continue
+		}
+
+		result = append(result, entry.Name())
+	}
+
+	sort.Strings(result)
+
+	return result, nil
+}
+
+// ReadScript reads project's script of the name given
+func (p *Project) ReadScript(name string) (string, error) {
+	if filepath.Base(name) != name {
+		return "", fmt.Errorf("invalid script name %s", name)
+	}
+
+	data, err := os.ReadFile(filepath.Join(p.ScriptsPath(), name))
+	if err != nil {
+		return "", fmt.Errorf("cannot read script %s: %w", name, err)
+	}
+
+	return string(data), nil
+}
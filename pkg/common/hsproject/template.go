@@ -0,0 +1,174 @@
+package hsproject
+
+import (
+	"fmt"
+	"log"
+	"os"
+	"strings"
+
+	"github.com/gucio321/HellSpawner/pkg/app/config"
+)
+
+// Template is a starting point of a new project
+type Template struct {
+	Name        string
+	Description string
+	// AuxiliaryMPQs are added to the project if they're present in auxiliary MPQs directory
+	AuxiliaryMPQs []string
+	// Folders are game paths of directories created in project's content
+	Folders []string
+	// Files are game paths of starter files copied from auxiliary MPQs
+	Files []string
+}
+
+// gameMPQs are MPQs of the game in load order (patch first)
+var gameMPQs = []string{
+	"patch_d2.mpq",
+	"d2exp.mpq",
+	"d2data.mpq",
+	"d2char.mpq",
+	"d2sfx.mpq",
+}
+
+// Templates returns project templates
+func Templates() []*Template {
+	return []*Template{
+		{
+			Name:        "Empty mod",
+			Description: "An empty project",
+		},
+		{
+			Name:          "New act",
+			Description:   "A new act with its own tiles, maps and level tables",
+			AuxiliaryMPQs: gameMPQs,
+			Folders: []string{
+				`data\global\tiles\act6`,
+				`data\global\palette\act6`,
+			},
+			Files: []string{
+				`data\global\excel\levels.txt`,
+				`data\global\excel\lvlprest.txt`,
+				`data\global\excel\lvltypes.txt`,
+				`data\global\excel\lvlmaze.txt`,
+				`data\global\excel\lvlsub.txt`,
+				`data\global\excel\lvlwarp.txt`,
+				`data\global\excel\automap.txt`,
+			},
+		},
+		{
+			Name:          "UI reskin",
+			Description:   "New look of game's panels, menus and cursors",
+			AuxiliaryMPQs: gameMPQs,
+			Folders: []string{
+				`data\global\ui\panel`,
+				`data\global\ui\frontend`,
+				`data\global\ui\cursor`,
+			},
+			Files: []string{
+				`data\global\ui\panel\800ctrlpnl7.dc6`,
+				`data\global\ui\panel\invchar6.dc6`,
+				`data\global\ui\cursor\ohand.dc6`,
+				`data\global\palette\units\pal.dat`,
+			},
+		},
+		{
+			Name:          "Translation",
+			Description:   "Game's texts and fonts in another language",
+			AuxiliaryMPQs: gameMPQs,
+			Folders: []string{
+				`data\local\lng\eng`,
+				`data\local\font\latin`,
+			},
+			Files: []string{
+				`data\local\lng\eng\string.tbl`,
+				`data\local\lng\eng\expansionstring.tbl`,
+				`data\local\lng\eng\patchstring.tbl`,
+				`data\local\font\latin\font8.tbl`,
+				`data\local\font\latin\font16.tbl`,
+				`data\local\font\latin\font30.tbl`,
+			},
+		},
+	}
+}
+
+// CreateFromTemplate creates a new project and populates it with template's folders and files.
+// Starter files, which can't be found in auxiliary MPQs, are skipped.
+func CreateFromTemplate(fileName string, template *Template, cfg *config.Config) (*Project, error) {
+	result, err := CreateNew(fileName)
+	if err != nil {
+		return nil, err
+	}
+
+	result.Description = template.Description
+	result.AuxiliaryMPQs = availableMPQs(template.AuxiliaryMPQs, cfg)
+
+	if err := result.Save(); err != nil {
+		return nil, err
+	}
+
+	for _, folder := range template.Folders {
+		path := result.GamePathToContentPath(NormalizeGamePath(folder))
+		if err := os.MkdirAll(path, os.FileMode(newDirMode)); err != nil {
+			return nil, fmt.Errorf("cannot create directory %s: %w", path, err)
+		}
+	}
+
+	if len(template.Files) > 0 && len(result.AuxiliaryMPQs) > 0 {
+		if err := result.ReloadAuxiliaryMPQs(cfg); err != nil {
+			return nil, fmt.Errorf("error loading auxiliary MPQs: %w", err)
+		}
+	}
+
+	for _, gamePath := range template.Files {
+		if err := result.copyGameFile(gamePath); err != nil {
+			log.Printf("template %s: %v", template.Name, err)
+		}
+	}
+
+	result.InvalidateFileStructure()
+
+	return result, nil
+}
+
+// availableMPQs returns MPQs present in auxiliary MPQs directory (with their real names)
+func availableMPQs(names []string, cfg *config.Config) []string {
+	result := make([]string, 0)
+
+	if len(names) == 0 || cfg == nil || cfg.AuxiliaryMpqPath == "" {
+		return result
+	}
+
+	entries, err := os.ReadDir(cfg.AuxiliaryMpqPath)
+	if err != nil {
+		log.Print(err)
+
+		return result
+	}
+
+	for _, name := range names {
+		for _, entry := range entries {
+			if !entry.IsDir() && strings.EqualFold(entry.Name(), name) {
+				result = append(result, entry.Name())
+
+				break
+			}
+		}
+	}
+
+	return result
+}
+
+// copyGameFile copies a file from auxiliary MPQs into project's content
+func (p *Project) copyGameFile(gamePath string) error {
+	entry := p.GetFileFromGamePath(gamePath)
+	if entry == nil {
+		return fmt.Errorf("file %s not found", gamePath)
+	}
+
+	data, err := p.ReadFile(entry)
+	if err != nil {
+		return err
+	}
+
+	return p.WriteGameFile(gamePath, data)
+}
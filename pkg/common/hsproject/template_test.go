@@ -0,0 +1,77 @@
+package hsproject
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/OpenDiablo2/OpenDiablo2/d2common/d2fileformats/d2ds1"
+	"github.com/OpenDiablo2/OpenDiablo2/d2common/d2fileformats/d2tbl"
+
+	"github.com/gucio321/HellSpawner/pkg/app/config"
+)
+
+func Test_CreateFromTemplate(t *testing.T) {
+	dir := t.TempDir()
+
+	var translation *Template
+
+	for _, template := range Templates() {
+		if template.Name == "Translation" {
+			translation = template
+		}
+	}
+
+	// there are no MPQs, so starter files are skipped
+	project, err := CreateFromTemplate(filepath.Join(dir, "test"), translation, &config.Config{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if project.Description != translation.Description || len(project.AuxiliaryMPQs) != 0 {
+		t.Errorf("unexpected project %+v", project)
+	}
+
+	if info, err := os.Stat(filepath.Join(project.GetProjectFileContentPath(), "local", "lng", "eng")); err != nil || !info.IsDir() {
+		t.Errorf("template's folder wasn't created (%v)", err)
+	}
+}
+
+func Test_FileTemplates(t *testing.T) {
+	templates := make(map[string]*FileTemplate)
+	for _, template := range FileTemplates() {
+		templates[template.Name] = template
+	}
+
+	data, err := templates["Map of size"].Generate(map[string]string{"Width": "12", "Act": "3"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ds1, err := d2ds1.Unmarshal(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if w, h := ds1.Size(); w != 12 || h != 8 || ds1.Act != 3 {
+		t.Errorf("unexpected map %dx%d of act %d", w, h, ds1.Act)
+	}
+
+	if _, err := templates["Map of size"].Generate(map[string]string{"Act": "6"}); err == nil {
+		t.Error("map of invalid act shouldn't be created")
+	}
+
+	data, err = templates["String table with keys"].Generate(map[string]string{"Keys (comma separated)": "a, b,,c"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	dict, err := d2tbl.LoadTextDictionary(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, found := dict["b"]; !found || len(dict) != 3 {
+		t.Errorf("unexpected string table %v", dict)
+	}
+}
@@ -0,0 +1,405 @@
+package hsproject
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/OpenDiablo2/OpenDiablo2/d2common/d2fileformats/d2animdata"
+	"github.com/OpenDiablo2/OpenDiablo2/d2common/d2fileformats/d2cof"
+	"github.com/OpenDiablo2/OpenDiablo2/d2common/d2fileformats/d2dat"
+	"github.com/OpenDiablo2/OpenDiablo2/d2common/d2fileformats/d2dc6"
+	"github.com/OpenDiablo2/OpenDiablo2/d2common/d2fileformats/d2dcc"
+	"github.com/OpenDiablo2/OpenDiablo2/d2common/d2fileformats/d2ds1"
+	"github.com/OpenDiablo2/OpenDiablo2/d2common/d2fileformats/d2dt1"
+	"github.com/OpenDiablo2/OpenDiablo2/d2common/d2fileformats/d2font"
+	"github.com/OpenDiablo2/OpenDiablo2/d2common/d2fileformats/d2pl2"
+	"github.com/OpenDiablo2/OpenDiablo2/d2common/d2fileformats/d2tbl"
+
+	"github.com/gucio321/HellSpawner/pkg/common/hsd2s"
+	"github.com/gucio321/HellSpawner/pkg/common/hsexcel"
+	"github.com/gucio321/HellSpawner/pkg/common/hsfiletypes"
+	"github.com/gucio321/HellSpawner/pkg/common/hsfiletypes/hsfont"
+	"github.com/gucio321/HellSpawner/pkg/common/hsremap"
+)
+
+// maxGamePathLength is a maximal length of the game path (MAX_PATH without the terminating zero)
+const maxGamePathLength = 259
+
+// ProblemSeverity is a severity of the problem found by project's validation
+//
+//go:generate stringer -linecomment -type ProblemSeverity -output problemseverity_string.go
+type ProblemSeverity byte
+
+// problem severities
+const (
+	ProblemError   ProblemSeverity = iota // Error
+	ProblemWarning                        // Warning
+)
+
+// Problem is a problem of project's file
+type Problem struct {
+	// Path is a full path of the file
+	Path     string
+	Severity ProblemSeverity
+	Message  string
+}
+
+// format describes how to check a file type
+type format struct {
+	// load loads the file; it returns nil marshaler, if the format can't be saved
+	load func(data []byte) (marshaler, error)
+	// unordered formats don't keep order of their entries, so their round-trip
+	// compares loaded content instead of bytes
+	unordered bool
+}
+
+func formatOf(fileType hsfiletypes.FileType) *format {
+	switch fileType {
+	case hsfiletypes.FileTypeTBLStringTable:
+		return &format{
+			load: func(data []byte) (marshaler, error) {
+				dict, err := d2tbl.LoadTextDictionary(data)
+				return &dict, err
+			},
+			unordered: true,
+		}
+	case hsfiletypes.FileTypeTBLFontTable:
+		return &format{load: func(data []byte) (marshaler, error) { return d2font.Load(data) }}
+	case hsfiletypes.FileTypeAnimationData:
+		return &format{load: func(data []byte) (marshaler, error) { return d2animdata.Load(data) }}
+	case hsfiletypes.FileTypeCOF:
+		return &format{load: func(data []byte) (marshaler, error) { return d2cof.Unmarshal(data) }}
+	case hsfiletypes.FileTypePalette:
+		return &format{load: loadPaletteData}
+	case hsfiletypes.FileTypePL2:
+		return &format{load: func(data []byte) (marshaler, error) { return d2pl2.Load(data) }}
+	case hsfiletypes.FileTypeDS1:
+		return &format{load: func(data []byte) (marshaler, error) { return d2ds1.Unmarshal(data) }}
+	case hsfiletypes.FileTypeDT1:
+		return &format{load: func(data []byte) (marshaler, error) { return d2dt1.LoadDT1(data) }}
+	case hsfiletypes.FileTypeDC6:
+		return &format{load: func(data []byte) (marshaler, error) { return d2dc6.Load(data) }}
+	case hsfiletypes.FileTypeDCC:
+		// DCC can't be saved yet (https://github.com/gucio321/HellSpawner/issues/181)
+		return &format{load: func(data []byte) (marshaler, error) {
+			_, err := d2dcc.Load(data)
+			return nil, err
+		}}
+	case hsfiletypes.FileTypeColormap:
+		return &format{load: func(data []byte) (marshaler, error) {
+			_, err := hsremap.FromColormap(data, nil)
+			return nil, err
+		}}
+	case hsfiletypes.FileTypeExcelBin:
+		// compiled tables are read-only; without a name only records' size can be checked
+		return &format{load: func(data []byte) (marshaler, error) {
+			_, err := hsexcel.Load("", data)
+			return nil, err
+		}}
+	case hsfiletypes.FileTypeD2S:
+		return &format{load: func(data []byte) (marshaler, error) {
+			_, err := hsd2s.Load(data)
+			return nil, err
+		}}
+	}
+
+	return nil
+}
+
+func loadPaletteData(data []byte) (marshaler, error) {
+	if len(data) < paletteSize {
+		return nil, fmt.Errorf("palette is too short (%d bytes, expected %d)", len(data), paletteSize)
+	}
+
+	palette, err := d2dat.Load(data)
+	if err != nil {
+		return nil, fmt.Errorf("error loading palette: %w", err)
+	}
+
+	result, ok := palette.(*d2dat.DATPalette)
+	if !ok {
+		return nil, nil
+	}
+
+	return result, nil
+}
+
+// roundTrip loads the file and saves it again; changed is true if saved data differ from the original.
+// Loaders panicking on malformed data are reported as errors.
+func (f *format) roundTrip(data []byte) (loaded marshaler, changed bool, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			loaded, changed, err = nil, false, fmt.Errorf("file is malformed: %v", r)
+		}
+	}()
+
+	loaded, err = f.load(data)
+	if err != nil || loaded == nil {
+		return loaded, false, err
+	}
+
+	saved := loaded.Marshal()
+	if bytes.Equal(saved, data) {
+		return loaded, false, nil
+	}
+
+	if !f.unordered {
+		return loaded, true, nil
+	}
+
+	if reloaded, err := f.load(saved); err == nil {
+		return loaded, !reflect.DeepEqual(loaded, reloaded), nil
+	}
+
+	// saved file can't be even loaded
+	return loaded, true, nil
+}
+
+func newProblem(path string, severity ProblemSeverity, format string, args ...interface{}) Problem {
+	return Problem{
+		Path:     path,
+		Severity: severity,
+		Message:  fmt.Sprintf(format, args...),
+	}
+}
+
+// Validate checks all the files of project's content: whether they can be loaded and saved
+// without changes, whether their paths are valid game paths and whether files they refer to exist.
+// Progress (if not nil) is called after each checked file.
+// References are read from auxiliary MPQs, so run it on a Detached project in background.
+func (p *Project) Validate(ctx context.Context, progress func(done, total int)) ([]Problem, error) {
+	files, err := p.contentFiles()
+	if err != nil {
+		return nil, err
+	}
+
+	result := p.validatePaths(files)
+
+	for i, path := range files {
+		if err := ctx.Err(); err != nil {
+			return nil, fmt.Errorf("validation canceled: %w", err)
+		}
+
+		result = append(result, p.validateFile(path)...)
+
+		if progress != nil {
+			progress(i+1, len(files))
+		}
+	}
+
+	sort.SliceStable(result, func(i, j int) bool {
+		return result[i].Path < result[j].Path
+	})
+
+	return result, nil
+}
+
+// contentFiles returns all the files of project's content except of hidden ones
+func (p *Project) contentFiles() ([]string, error) {
+	result := make([]string, 0)
+
+	err := filepath.WalkDir(p.GetProjectFileContentPath(), func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+
+		if strings.HasPrefix(d.Name(), ".") {
+			if d.IsDir() {
+				return filepath.SkipDir
+			}
+
+			return nil
+		}
+
+		if !d.IsDir() {
+			result = append(result, path)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error reading project's content: %w", err)
+	}
+
+	return result, nil
+}
+
+// validatePaths checks whether files can be placed in the game under their paths
+func (p *Project) validatePaths(files []string) []Problem {
+	result := make([]Problem, 0)
+	byGamePath := make(map[string]string)
+
+	for _, path := range files {
+		gamePath := p.ContentPathToGamePath(path)
+
+		if rel, err := filepath.Rel(p.GetProjectFileContentPath(), path); err == nil &&
+			strings.HasPrefix(strings.ToLower(filepath.ToSlash(rel)), "data/") {
+			result = append(result, newProblem(path, ProblemWarning,
+				"\"data\" directory is redundant (project's content is already placed in data\\)"))
+		}
+
+		if len(gamePath) > maxGamePathLength {
+			result = append(result, newProblem(path, ProblemError,
+				"game path is too long (%d characters, maximum is %d)", len(gamePath), maxGamePathLength))
+		}
+
+		if other, found := byGamePath[gamePath]; found {
+			result = append(result, newProblem(path, ProblemError,
+				"%s has the same game path (game paths are case-insensitive)", other))
+
+			continue
+		}
+
+		byGamePath[gamePath] = path
+	}
+
+	return result
+}
+
+// validateFile checks whether the file can be loaded and saved and whether files it refers to exist
+func (p *Project) validateFile(path string) []Problem {
+	data, err := os.ReadFile(filepath.Clean(path))
+	if err != nil {
+		return []Problem{newProblem(path, ProblemError, "cannot read file: %v", err)}
+	}
+
+	fileType, err := hsfiletypes.GetFileTypeFromExtension(filepath.Ext(path), &data)
+	if err != nil {
+		// HellSpawner doesn't know the file, so there is nothing to check
+		return nil
+	}
+
+	if fileType == hsfiletypes.FileTypeFont {
+		return p.validateFont(path, data)
+	}
+
+	f := formatOf(fileType)
+	if f == nil {
+		return nil
+	}
+
+	loaded, changed, err := f.roundTrip(data)
+	if err != nil {
+		return []Problem{newProblem(path, ProblemError, "cannot load %s: %v", fileType, err)}
+	}
+
+	result := make([]Problem, 0)
+
+	if changed {
+		result = append(result, newProblem(path, ProblemWarning,
+			"file would change when saved by HellSpawner (round-trip differs)"))
+	}
+
+	if ds1, ok := loaded.(*d2ds1.DS1); ok {
+		result = append(result, p.validateDS1Files(path, ds1)...)
+	}
+
+	return result
+}
+
+// validateFont checks files used by the font
+func (p *Project) validateFont(path string, data []byte) []Problem {
+	font, err := hsfont.LoadFromJSON(data)
+	if err != nil {
+		return []Problem{newProblem(path, ProblemError, "cannot load font: %v", err)}
+	}
+
+	result := make([]Problem, 0)
+
+	references := []struct {
+		name     string
+		path     string
+		fileType hsfiletypes.FileType
+	}{
+		{"table", font.TableFile, hsfiletypes.FileTypeTBLFontTable},
+		{"sprite", font.SpriteFile, hsfiletypes.FileTypeDC6},
+		{"palette", font.PaletteFile, hsfiletypes.FileTypePL2},
+	}
+
+	for _, ref := range references {
+		if ref.path == "" {
+			result = append(result, newProblem(path, ProblemWarning, "font has no %s file", ref.name))
+
+			continue
+		}
+
+		refData, err := p.readReference(ref.path)
+		if err != nil {
+			result = append(result, newProblem(path, ProblemError, "%s file %s: %v", ref.name, ref.path, err))
+
+			continue
+		}
+
+		if _, _, err := formatOf(ref.fileType).roundTrip(refData); err != nil {
+			result = append(result, newProblem(path, ProblemError, "%s file %s cannot be loaded: %v", ref.name, ref.path, err))
+		}
+	}
+
+	return result
+}
+
+// readReference reads a file referred by another file. Reference is either
+// an absolute path or a game path (looked up in project and auxiliary MPQs).
+func (p *Project) readReference(ref string) ([]byte, error) {
+	if filepath.IsAbs(ref) {
+		data, err := os.ReadFile(filepath.Clean(ref))
+		if err != nil {
+			return nil, fmt.Errorf("cannot read file: %w", err)
+		}
+
+		return data, nil
+	}
+
+	entry := p.GetFileFromGamePath(ref)
+	if entry == nil {
+		return nil, fmt.Errorf("file doesn't exist in the project nor in auxiliary MPQs: %w", fs.ErrNotExist)
+	}
+
+	return p.ReadFile(entry)
+}
+
+// validateDS1Files checks whether tile sets listed in DS1 exist
+func (p *Project) validateDS1Files(path string, ds1 *d2ds1.DS1) []Problem {
+	result := make([]Problem, 0)
+
+	for _, file := range ds1.Files {
+		if file == "" {
+			continue
+		}
+
+		if p.GetFileFromGamePath(ds1FileGamePath(file)) == nil {
+			result = append(result, newProblem(path, ProblemWarning,
+				"tile set %s doesn't exist in the project nor in auxiliary MPQs", file))
+		}
+	}
+
+	return result
+}
+
+// ds1FileGamePath converts DS1's file reference (e.g. \d2\data\global\tiles\act1\town\floor.tg1)
+// to the game path of the tile set (data\global\tiles\act1\town\floor.dt1)
+func ds1FileGamePath(file string) string {
+	const (
+		tg1Extension = ".tg1"
+		dt1Extension = ".dt1"
+	)
+
+	file = strings.ToLower(strings.ReplaceAll(file, "/", gamePathSep))
+
+	if idx := strings.Index(file, gamePathPrefix); idx >= 0 {
+		file = file[idx:]
+	}
+
+	if strings.HasSuffix(file, tg1Extension) {
+		file = strings.TrimSuffix(file, tg1Extension) + dt1Extension
+	}
+
+	return NormalizeGamePath(file)
+}
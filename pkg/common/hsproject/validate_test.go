@@ -0,0 +1,112 @@
+package hsproject
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/OpenDiablo2/OpenDiablo2/d2common/d2fileformats/d2tbl"
+)
+
+func Test_Project_Validate(t *testing.T) {
+	project := newTestProject(t)
+
+	content := project.GetProjectFileContentPath()
+
+	dict := d2tbl.TextDictionary{"key1": "value1", "key2": "value2", "key3": "value3"}
+
+	files := map[string][]byte{
+		filepath.Join("local", "strings.tbl"):       dict.Marshal(),
+		filepath.Join("local", "broken.tbl"):        {1},
+		filepath.Join("global", "palette.dat"):      make([]byte, paletteSize),
+		filepath.Join("global", "short.dat"):        make([]byte, 10),
+		filepath.Join("global", "Armor.txt"):        []byte("name\tcode\n"),
+		filepath.Join("global", "armor.txt"):        []byte("name\tcode\n"),
+		filepath.Join("data", "global", "misc.txt"): []byte("text"),
+		filepath.Join("font", "font.hsf"):           []byte(`{"TableFile": "data\\local\\font\\missing.tbl"}`),
+		filepath.Join(".hidden", "broken.dat"):      []byte("hidden"),
+	}
+
+	for path, data := range files {
+		path = filepath.Join(content, path)
+
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+
+		if err := os.WriteFile(path, data, 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	done := 0
+
+	problems, err := project.Validate(context.Background(), func(d, _ int) { done = d })
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if done != len(files)-1 {
+		t.Errorf("expected %d files to be checked, got %d", len(files)-1, done)
+	}
+
+	expected := map[string][]ProblemSeverity{
+		filepath.Join("local", "broken.tbl"):        {ProblemError},
+		filepath.Join("global", "short.dat"):        {ProblemError},
+		filepath.Join("global", "armor.txt"):        {ProblemError},
+		filepath.Join("data", "global", "misc.txt"): {ProblemWarning},
+		// missing table, no sprite and no palette
+		filepath.Join("font", "font.hsf"): {ProblemError, ProblemWarning, ProblemWarning},
+	}
+
+	found := make(map[string][]ProblemSeverity)
+
+	for _, problem := range problems {
+		rel, err := filepath.Rel(content, problem.Path)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		found[rel] = append(found[rel], problem.Severity)
+	}
+
+	if len(found) != len(expected) {
+		t.Errorf("unexpected problems: %v", problems)
+	}
+
+	for path, severities := range expected {
+		if len(found[path]) != len(severities) {
+			t.Errorf("%s: expected problems %v, got %v", path, severities, found[path])
+
+			continue
+		}
+
+		for i := range severities {
+			if found[path][i] != severities[i] {
+				t.Errorf("%s: expected problems %v, got %v", path, severities, found[path])
+			}
+		}
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	if _, err := project.Validate(ctx, nil); err == nil {
+		t.Error("expected an error of canceled validation")
+	}
+}
+
+func Test_ds1FileGamePath(t *testing.T) {
+	tests := map[string]string{
+		`\d2\data\global\tiles\ACT1\Town\Floor.tg1`: `data\global\tiles\act1\town\floor.dt1`,
+		`global/tiles/act2/floor.dt1`:               `data\global\tiles\act2\floor.dt1`,
+	}
+
+	for file, expected := range tests {
+		if result := ds1FileGamePath(file); !strings.EqualFold(result, expected) {
+			t.Errorf("%s: expected %s, got %s", file, expected, result)
+		}
+	}
+}
@@ -0,0 +1,85 @@
+package hsproject
+
+import (
+	"log"
+	"path/filepath"
+
+	"github.com/gucio321/HellSpawner/pkg/common"
+	"github.com/gucio321/HellSpawner/pkg/common/hswatcher"
+)
+
+// Watch creates a watcher of project's content directory
+func (p *Project) Watch() *hswatcher.Watcher {
+	return hswatcher.New(p.GetProjectFileContentPath(), hswatcher.DefaultInterval)
+}
+
+// ApplyFileChanges updates project's file structure cache with changes found by a watcher.
+// If the cache cannot be updated incrementally, it gets invalidated.
+func (p *Project) ApplyFileChanges(events []hswatcher.Event) {
+	if p.pathEntryCache == nil {
+		return
+	}
+
+	for _, e := range events {
+		switch e.Op {
+		case hswatcher.OpCreate:
+			if !p.addPathEntry(e.Path, e.IsDir) {
+				p.InvalidateFileStructure()
+
+				return
+			}
+		case hswatcher.OpRemove:
+			p.removePathEntry(e.Path)
+		case hswatcher.OpWrite:
+			// file structure doesn't change
+		}
+	}
+}
+
+func (p *Project) addPathEntry(path string, isDir bool) bool {
+	parent := p.FindPathEntry(filepath.Dir(path))
+	if parent == nil || !parent.IsDirectory {
+		return false
+	}
+
+	for _, child := range parent.Children {
+		if child.FullPath == path {
+			return true
+		}
+	}
+
+	entry := &common.PathEntry{
+		Children:    []*common.PathEntry{},
+		Name:        filepath.Base(path),
+		FullPath:    path,
+		IsDirectory: isDir,
+		Source:      common.PathEntrySourceProject,
+	}
+
+	if isDir {
+		if err := p.getFileNodes(path, entry); err != nil {
+			log.Print(err)
+
+			return false
+		}
+	}
+
+	parent.Children = append(parent.Children, entry)
+
+	return true
+}
+
+func (p *Project) removePathEntry(path string) {
+	parent := p.FindPathEntry(filepath.Dir(path))
+	if parent == nil {
+		return
+	}
+
+	for idx, child := range parent.Children {
+		if child.FullPath == path {
+			parent.Children = append(parent.Children[:idx], parent.Children[idx+1:]...)
+
+			return
+		}
+	}
+}
@@ -0,0 +1,58 @@
+package hsproject
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/gucio321/HellSpawner/pkg/common/hswatcher"
+)
+
+func Test_Project_ApplyFileChanges(t *testing.T) {
+	project := newTestProject(t)
+
+	content := project.GetProjectFileContentPath()
+	removed := filepath.Join(content, "removed.txt")
+
+	if err := os.WriteFile(removed, nil, 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	root, err := project.GetFileStructure()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	watcher := project.Watch()
+	watcher.Start(func([]hswatcher.Event) {})
+
+	defer watcher.Stop()
+
+	created := filepath.Join(content, "dir", "created.txt")
+
+	if err := os.MkdirAll(filepath.Dir(created), 0o755); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := os.WriteFile(created, nil, 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := os.Remove(removed); err != nil {
+		t.Fatal(err)
+	}
+
+	project.ApplyFileChanges(watcher.Poll())
+
+	if current, _ := project.GetFileStructure(); current != root {
+		t.Fatal("file structure was rebuilt instead of updated")
+	}
+
+	if project.FindPathEntry(removed) != nil {
+		t.Error("removed file is still in file structure")
+	}
+
+	if project.FindPathEntry(created) == nil {
+		t.Error("created file isn't in file structure")
+	}
+}
@@ -0,0 +1,4 @@
+// Package hsremap contains palette remaps - tables mapping palette indices to
+// another indices (e.g. PL2 transforms or colormaps), which the game applies
+// to images before palettizing them.
+package hsremap
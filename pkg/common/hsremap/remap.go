@@ -0,0 +1,137 @@
+package hsremap
+
+import (
+	"fmt"
+
+	"github.com/OpenDiablo2/OpenDiablo2/d2common/d2fileformats/d2dat"
+	"github.com/OpenDiablo2/OpenDiablo2/d2common/d2fileformats/d2pl2"
+	"github.com/OpenDiablo2/OpenDiablo2/d2common/d2fileformats/d2txt"
+	"github.com/OpenDiablo2/OpenDiablo2/d2common/d2interface"
+)
+
+const (
+	numColors = 256
+	maxAlpha  = 0xff
+
+	redShift   = 24
+	greenShift = 16
+	blueShift  = 8
+	// colorsTxtNameField is a column of colors.txt naming item colormaps
+	colorsTxtNameField = "Transform Color"
+)
+
+// Remap represents a single palette remap
+type Remap struct {
+	Name    string
+	Indices [numColors]byte
+}
+
+// Apply returns a palette, which colors are colors of the palette given remapped by r.
+// Nil palette means grayscale (index is a color value).
+// If r is nil, palette is returned unchanged.
+func (r *Remap) Apply(palette *[numColors]d2interface.Color) *[numColors]d2interface.Color {
+	if r == nil {
+		return palette
+	}
+
+	result := &[numColors]d2interface.Color{}
+
+	for idx, remapped := range r.Indices {
+		if palette != nil {
+			result[idx] = palette[remapped]
+
+			continue
+		}
+
+		col := &d2dat.DATColor{}
+		col.SetRGBA(grayscaleRGBA(remapped))
+		result[idx] = col
+	}
+
+	return result
+}
+
+// grayscaleRGBA returns gray color with all the components equal to val in 0xRRGGBBAA form
+func grayscaleRGBA(val byte) uint32 {
+	v := uint32(val)
+
+	return v<<redShift | v<<greenShift | v<<blueShift | maxAlpha
+}
+
+// FromPL2 returns remaps from PL2 file, which makes sense to preview on a sprite.
+// Blend tables (alpha, additive, multiplicative and max component) are skipped, because
+// they blend two colors instead of remapping one.
+func FromPL2(pl2 *d2pl2.PL2) []*Remap {
+	result := make([]*Remap, 0)
+
+	add := func(name string, transform *d2pl2.PL2PaletteTransform) {
+		result = append(result, &Remap{Name: name, Indices: transform.Indices})
+	}
+
+	for i := range pl2.LightLevelVariations {
+		add(fmt.Sprintf("Light Level %d", i), &pl2.LightLevelVariations[i])
+	}
+
+	for i := range pl2.InvColorVariations {
+		add(fmt.Sprintf("InvColor %d", i), &pl2.InvColorVariations[i])
+	}
+
+	add("Selected Unit Shift", &pl2.SelectedUintShift)
+
+	for i := range pl2.HueVariations {
+		add(fmt.Sprintf("Hue %d", i), &pl2.HueVariations[i])
+	}
+
+	add("Red Tones", &pl2.RedTones)
+	add("Green Tones", &pl2.GreenTones)
+	add("Blue Tones", &pl2.BlueTones)
+	add("Darkened Color Shift", &pl2.DarkendColorShift)
+
+	for i := range pl2.TextColorShifts {
+		add(fmt.Sprintf("Text Color Shift %d", i), &pl2.TextColorShifts[i])
+	}
+
+	return result
+}
+
+// FromColormap loads remaps from colormap file (e.g. data\global\items\palette\*.dat or
+// data\global\monsters\randtransforms.dat), which is a sequence of 256-byte tables.
+// If len(names) is equal to number of tables, names are used as remap names.
+func FromColormap(data []byte, names []string) ([]*Remap, error) {
+	if len(data) == 0 || len(data)%numColors != 0 {
+		return nil, fmt.Errorf("invalid colormap size %d (should be a multiple of %d)", len(data), numColors)
+	}
+
+	count := len(data) / numColors
+	useNames := len(names) == count
+	result := make([]*Remap, count)
+
+	for i := range result {
+		name := fmt.Sprintf("Colormap %d", i)
+		if useNames {
+			name = fmt.Sprintf("%s (%d)", names[i], i)
+		}
+
+		result[i] = &Remap{Name: name}
+		copy(result[i].Indices[:], data[i*numColors:(i+1)*numColors])
+	}
+
+	return result, nil
+}
+
+// ColorNames reads colormap names from colors.txt (item color tints)
+func ColorNames(colorsTxt []byte) []string {
+	result := make([]string, 0)
+
+	if len(colorsTxt) == 0 {
+		return result
+	}
+
+	dict := d2txt.LoadDataDictionary(colorsTxt)
+
+	for dict.Next() {
+		result = append(result, dict.String(colorsTxtNameField))
+	}
+
+	return result
+}
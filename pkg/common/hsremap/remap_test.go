@@ -0,0 +1,165 @@
+package hsremap
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/OpenDiablo2/OpenDiablo2/d2common/d2fileformats/d2dat"
+	"github.com/OpenDiablo2/OpenDiablo2/d2common/d2fileformats/d2pl2"
+	"github.com/OpenDiablo2/OpenDiablo2/d2common/d2interface"
+)
+
+func testPalette() *[numColors]d2interface.Color {
+	result := &[numColors]d2interface.Color{}
+
+	for i := range result {
+		col := &d2dat.DATColor{}
+		col.SetRGBA(uint32(i)<<redShift | maxAlpha)
+		result[i] = col
+	}
+
+	return result
+}
+
+// shiftRemap maps every index to the index increased by shift (wrapping around)
+func shiftRemap(shift int) *Remap {
+	result := &Remap{Name: "shift"}
+
+	for i := range result.Indices {
+		result.Indices[i] = byte(i + shift)
+	}
+
+	return result
+}
+
+func Test_Remap_Apply(t *testing.T) {
+	palette := testPalette()
+
+	tests := []struct {
+		name    string
+		remap   *Remap
+		palette *[numColors]d2interface.Color
+		// expected red component of colors 0, 1 and 255
+		expected [3]uint8
+	}{
+		{name: "nil remap", palette: palette, expected: [3]uint8{0, 1, 255}},
+		{name: "identity", remap: shiftRemap(0), palette: palette, expected: [3]uint8{0, 1, 255}},
+		{name: "shift", remap: shiftRemap(1), palette: palette, expected: [3]uint8{1, 2, 0}},
+		{name: "last index", remap: &Remap{Indices: [numColors]byte{numColors - 1, numColors - 1}}, palette: palette, expected: [3]uint8{255, 255, 0}},
+		{name: "grayscale", remap: shiftRemap(-1), expected: [3]uint8{255, 0, 254}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := tt.remap.Apply(tt.palette)
+
+			if tt.remap == nil && result != tt.palette {
+				t.Fatal("nil remap didn't return the palette given")
+			}
+
+			for i, idx := range []int{0, 1, numColors - 1} {
+				if r := result[idx].R(); r != tt.expected[i] {
+					t.Errorf("color %d: expected red %d, got %d", idx, tt.expected[i], r)
+				}
+			}
+		})
+	}
+}
+
+func Test_FromColormap(t *testing.T) {
+	twoTables := append(bytes.Repeat([]byte{1}, numColors), bytes.Repeat([]byte{2}, numColors)...)
+
+	tests := []struct {
+		name     string
+		data     []byte
+		names    []string
+		expected []string
+		wantErr  bool
+	}{
+		{name: "empty", wantErr: true},
+		{name: "partial table", data: make([]byte, numColors+1), wantErr: true},
+		{name: "single table", data: make([]byte, numColors), expected: []string{"Colormap 0"}},
+		{name: "named tables", data: twoTables, names: []string{"whit", "lgry"}, expected: []string{"whit (0)", "lgry (1)"}},
+		{name: "names don't match", data: twoTables, names: []string{"whit"}, expected: []string{"Colormap 0", "Colormap 1"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			remaps, err := FromColormap(tt.data, tt.names)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if len(remaps) != len(tt.expected) {
+				t.Fatalf("expected %d remaps, got %d", len(tt.expected), len(remaps))
+			}
+
+			for i, remap := range remaps {
+				if remap.Name != tt.expected[i] {
+					t.Errorf("expected name %q, got %q", tt.expected[i], remap.Name)
+				}
+
+				if remap.Indices[0] != tt.data[i*numColors] || remap.Indices[numColors-1] != tt.data[(i+1)*numColors-1] {
+					t.Errorf("%s: unexpected indices", remap.Name)
+				}
+			}
+		})
+	}
+}
+
+func Test_FromPL2(t *testing.T) {
+	pl2 := &d2pl2.PL2{}
+	pl2.HueVariations[3].Indices[10] = 42
+	pl2.UnknownVariations[0].Indices[10] = 43
+
+	loaded, err := d2pl2.Load(pl2.Marshal())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	remaps := FromPL2(loaded)
+
+	expected := len(pl2.LightLevelVariations) + len(pl2.InvColorVariations) + 1 +
+		len(pl2.HueVariations) + 4 + len(pl2.TextColorShifts)
+
+	if len(remaps) != expected {
+		t.Fatalf("expected %d remaps, got %d", expected, len(remaps))
+	}
+
+	for _, remap := range remaps {
+		if remap.Name == "Hue 3" && remap.Indices[10] != 42 {
+			t.Fatalf("unexpected indices of %s", remap.Name)
+		}
+
+		if remap.Indices[10] == 43 {
+			t.Fatalf("%s: unknown variations shouldn't be used", remap.Name)
+		}
+	}
+}
+
+func Test_ColorNames(t *testing.T) {
+	tests := []struct {
+		name     string
+		data     string
+		expected []string
+	}{
+		{name: "empty", expected: []string{}},
+		{name: "colors", data: "Transform Color\tCode\r\nwhit\t0\r\nlgry\t1\r\n", expected: []string{"whit", "lgry"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			names := ColorNames([]byte(tt.data))
+
+			if len(names) != len(tt.expected) {
+				t.Fatalf("expected %v, got %v", tt.expected, names)
+			}
+
+			for i := range names {
+				if names[i] != tt.expected[i] {
+					t.Fatalf("expected %v, got %v", tt.expected, names)
+				}
+			}
+		})
+	}
+}
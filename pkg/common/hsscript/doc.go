@@ -0,0 +1,15 @@
+// Package hsscript contains a JavaScript engine used to automate operations
+// on project's assets (e.g. batch renaming or recoloring).
+//
+// Scripts have access to the following globals:
+//
+//	project                 - name and paths of the project
+//	print(...values)        - writes values to the output
+//	files(pattern)          - game paths of project's and MPQs' files matching the glob pattern
+//	                          (e.g. "data/global/ui/**/*.dc6"); * doesn't cross directories, ** does
+//	exists(path)            - tells whether the file exists in the project or MPQs
+//	read(path), readText(path)         - reads a file (project's version first, then MPQs)
+//	write(path, data), writeText(path, text) - writes a file into project's content
+//	load(path)              - loads a file of a known format (.dc6, .dt1, .ds1, .tbl, .pl2, .dat, .cof)
+//	save(path, object)      - marshals an object returned by load and writes it into project's content
+package hsscript
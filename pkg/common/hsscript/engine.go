@@ -0,0 +1,206 @@
+package hsscript
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+
+	"github.com/robertkrimen/otto"
+
+	"github.com/gucio321/HellSpawner/pkg/common/hsproject"
+)
+
+// ErrInterrupted is returned when script was stopped by Interrupt
+var ErrInterrupted = errors.New("script interrupted")
+
+// Engine runs scripts with access to the project. Only one script runs at a time.
+type Engine struct {
+	vm      *otto.Otto
+	project *hsproject.Project
+	output  io.Writer
+	mutex   sync.Mutex
+}
+
+// New creates a new engine. Scripts' output is written to the writer given.
+func New(project *hsproject.Project, output io.Writer) *Engine {
+	result := &Engine{
+		vm:      otto.New(),
+		project: project,
+		output:  output,
+	}
+
+	// the buffer lets Interrupt not to block
+	result.vm.Interrupt = make(chan func(), 1)
+
+	result.setGlobals()
+
+	return result
+}
+
+func (e *Engine) setGlobals() {
+	globals := map[string]interface{}{
+		"project": map[string]interface{}{
+			"name":        e.project.ProjectName,
+			"path":        e.project.GetProjectFilePath(),
+			"contentPath": e.project.GetProjectFileContentPath(),
+		},
+		"print":     e.print,
+		"files":     e.files,
+		"exists":    e.exists,
+		"read":      e.read,
+		"readText":  func(path string) string { return string(e.read(path)) },
+		"write":     e.write,
+		"writeText": func(path, text string) { e.write(path, []byte(text)) },
+		"load":      e.load,
+		"save":      e.save,
+	}
+
+	for name, value := range globals {
+		if err := e.vm.Set(name, value); err != nil {
+			panic(fmt.Sprintf("cannot set script's global %s: %v", name, err))
+		}
+	}
+}
+
+// Run runs the code and returns its result as a string (empty, if code has no result)
+func (e *Engine) Run(code string) (result string, err error) {
+	e.mutex.Lock()
+	defer e.mutex.Unlock()
+
+	// drop an interruption, which came when nothing was running
+	select {
+	case <-e.vm.Interrupt:
+	default:
+	}
+
+	defer func() {
+		if r := recover(); r != nil {
+			if r != ErrInterrupted { //nolint:errorlint // it's a sentinel panic value
+				panic(r)
+			}
+
+			err = ErrInterrupted
+		}
+	}()
+
+	value, err := e.vm.Run(code)
+	if err != nil {
+		return "", fmt.Errorf("script error: %w", err)
+	}
+
+	if value.IsUndefined() {
+		return "", nil
+	}
+
+	return value.String(), nil
+}
+
+// SetProject changes the project used by scripts (e.g. to a detached copy with own MPQ handles).
+// It waits until the running script ends.
+func (e *Engine) SetProject(project *hsproject.Project) {
+	e.mutex.Lock()
+	defer e.mutex.Unlock()
+
+	e.project = project
+}
+
+// RunScript runs project's script of the name given
+func (e *Engine) RunScript(name string) (string, error) {
+	code, err := e.project.ReadScript(name)
+	if err != nil {
+		return "", err
+	}
+
+	return e.Run(code)
+}
+
+// Interrupt stops the running script
+func (e *Engine) Interrupt() {
+	select {
+	case e.vm.Interrupt <- func() { panic(ErrInterrupted) }:
+	default:
+	}
+}
+
+// throw throws a JavaScript error; it may be called only from functions called by scripts
+func (e *Engine) throw(err error) {
+	panic(e.vm.MakeCustomError("Error", err.Error()))
+}
+
+func (e *Engine) print(values ...interface{}) {
+	text := make([]string, len(values))
+	for idx, value := range values {
+		text[idx] = fmt.Sprint(value)
+	}
+
+	if _, err := fmt.Fprintln(e.output, strings.Join(text, " ")); err != nil {
+		e.throw(err)
+	}
+}
+
+// scriptPath converts a game path to the form used by scripts (with slashes)
+func scriptPath(gamePath string) string {
+	return strings.ReplaceAll(gamePath, `\`, "/")
+}
+
+func (e *Engine) files(pattern string) []string {
+	glob, err := compileGlob(scriptPath(pattern))
+	if err != nil {
+		e.throw(err)
+	}
+
+	files := e.project.FindFiles(func(gamePath string) bool {
+		return glob.MatchString(scriptPath(gamePath))
+	})
+
+	result := make([]string, len(files))
+	for idx, file := range files {
+		result[idx] = scriptPath(e.project.EntryGamePath(file))
+	}
+
+	return result
+}
+
+func (e *Engine) exists(path string) bool {
+	return e.project.GetFileFromGamePath(path) != nil
+}
+
+func (e *Engine) read(path string) []byte {
+	entry := e.project.GetFileFromGamePath(path)
+	if entry == nil {
+		e.throw(fmt.Errorf("file %s not found", path))
+	}
+
+	data, err := e.project.ReadFile(entry)
+	if err != nil {
+		e.throw(err)
+	}
+
+	return data
+}
+
+func (e *Engine) write(path string, data []byte) {
+	if err := e.project.WriteGameFile(path, data); err != nil {
+		e.throw(err)
+	}
+}
+
+func (e *Engine) load(path string) interface{} {
+	result, err := decode(path, e.read(path))
+	if err != nil {
+		e.throw(err)
+	}
+
+	return result
+}
+
+func (e *Engine) save(path string, object interface{}) {
+	data, err := encode(object)
+	if err != nil {
+		e.throw(err)
+	}
+
+	e.write(path, data)
+}
@@ -0,0 +1,139 @@
+package hsscript
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/OpenDiablo2/OpenDiablo2/d2common/d2fileformats/d2tbl"
+
+	"github.com/gucio321/HellSpawner/pkg/common/hsproject"
+)
+
+func Test_compileGlob(t *testing.T) {
+	tests := []struct {
+		pattern, path string
+		match         bool
+	}{
+		{"data/local/*.tbl", "data/local/string.tbl", true},
+		{"data/local/*.tbl", "data/local/lng/eng/string.tbl", false},
+		{"data/**/*.TBL", "data/local/lng/eng/string.tbl", true},
+		{"data/**/string.tbl", "data/string.tbl", true},
+		{"data/local/?.tbl", "data/local/ab.tbl", false},
+		{"data/local/(x).tbl", "data/local/(x).tbl", true},
+	}
+
+	for _, test := range tests {
+		glob, err := compileGlob(test.pattern)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if glob.MatchString(test.path) != test.match {
+			t.Errorf("%s on %s: expected %v", test.pattern, test.path, test.match)
+		}
+	}
+}
+
+func newTestEngine(t *testing.T, output *bytes.Buffer) (*Engine, *hsproject.Project) {
+	t.Helper()
+
+	dir := t.TempDir()
+
+	project, err := hsproject.CreateNew(filepath.Join(dir, "test"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return New(project, output), project
+}
+
+func Test_Engine_Run(t *testing.T) {
+	output := &bytes.Buffer{}
+	engine, project := newTestEngine(t, output)
+
+	dict := d2tbl.TextDictionary{"old_a": "A", "old_b": "B", "other": "C"}
+	if err := project.WriteGameFile("data/local/strings.tbl", dict.Marshal()); err != nil {
+		t.Fatal(err)
+	}
+
+	const script = `
+		var paths = files("data/**/*.tbl");
+		for (var i = 0; i < paths.length; i++) {
+			var tbl = load(paths[i]);
+			Object.keys(tbl).forEach(function (key) {
+				if (key.indexOf("old_") === 0) {
+					tbl["new_" + key.substr(4)] = tbl[key];
+					delete tbl[key];
+				}
+			});
+			save(paths[i], tbl);
+			print("renamed", paths[i]);
+		}
+		paths.length;
+	`
+
+	result, err := engine.Run(script)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if result != "1" || !strings.Contains(output.String(), "renamed data/local/strings.tbl") {
+		t.Errorf("unexpected result %q, output %q", result, output.String())
+	}
+
+	data, err := os.ReadFile(project.GamePathToContentPath("data/local/strings.tbl"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	saved, err := d2tbl.LoadTextDictionary(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if saved["new_a"] != "A" || saved["new_b"] != "B" || saved["other"] != "C" || len(saved) != 3 {
+		t.Errorf("unexpected saved table: %v", saved)
+	}
+
+	if _, err := engine.Run(`read("data/missing.txt")`); err == nil || !strings.Contains(err.Error(), "not found") {
+		t.Errorf("missing file should be reported: %v", err)
+	}
+}
+
+func Test_Engine_Interrupt(t *testing.T) {
+	engine, _ := newTestEngine(t, &bytes.Buffer{})
+
+	done := make(chan struct{})
+
+	// interruption sent before the script starts is dropped, so keep trying
+	go func() {
+		ticker := time.NewTicker(10 * time.Millisecond)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-done:
+				return
+			case <-ticker.C:
+				engine.Interrupt()
+			}
+		}
+	}()
+
+	_, err := engine.Run("while (true) {}")
+	close(done)
+
+	if !errors.Is(err, ErrInterrupted) {
+		t.Errorf("expected interruption, got %v", err)
+	}
+
+	// engine should still work after interruption
+	if result, err := engine.Run("1 + 1"); err != nil || result != "2" {
+		t.Errorf("unexpected result %q: %v", result, err)
+	}
+}
@@ -0,0 +1,57 @@
+package hsscript
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/OpenDiablo2/OpenDiablo2/d2common/d2fileformats/d2cof"
+	"github.com/OpenDiablo2/OpenDiablo2/d2common/d2fileformats/d2dat"
+	"github.com/OpenDiablo2/OpenDiablo2/d2common/d2fileformats/d2dc6"
+	"github.com/OpenDiablo2/OpenDiablo2/d2common/d2fileformats/d2ds1"
+	"github.com/OpenDiablo2/OpenDiablo2/d2common/d2fileformats/d2dt1"
+	"github.com/OpenDiablo2/OpenDiablo2/d2common/d2fileformats/d2pl2"
+	"github.com/OpenDiablo2/OpenDiablo2/d2common/d2fileformats/d2tbl"
+)
+
+type marshaler interface {
+	Marshal() []byte
+}
+
+// decoders load files of known formats by extension
+var decoders = map[string]func(data []byte) (interface{}, error){
+	".dc6": func(data []byte) (interface{}, error) { return d2dc6.Load(data) },
+	".dt1": func(data []byte) (interface{}, error) { return d2dt1.LoadDT1(data) },
+	".ds1": func(data []byte) (interface{}, error) { return d2ds1.Unmarshal(data) },
+	".tbl": func(data []byte) (interface{}, error) { return d2tbl.LoadTextDictionary(data) },
+	".pl2": func(data []byte) (interface{}, error) { return d2pl2.Load(data) },
+	".dat": func(data []byte) (interface{}, error) { return d2dat.Load(data) },
+	".cof": func(data []byte) (interface{}, error) { return d2cof.Unmarshal(data) },
+}
+
+func decode(path string, data []byte) (interface{}, error) {
+	ext := strings.ToLower(filepath.Ext(path))
+
+	decoder, found := decoders[ext]
+	if !found {
+		return nil, fmt.Errorf("unsupported format %s", ext)
+	}
+
+	result, err := decoder(data)
+	if err != nil {
+		return nil, fmt.Errorf("cannot load %s: %w", path, err)
+	}
+
+	return result, nil
+}
+
+func encode(object interface{}) ([]byte, error) {
+	switch o := object.(type) {
+	case d2tbl.TextDictionary:
+		return o.Marshal(), nil
+	case marshaler:
+		return o.Marshal(), nil
+	}
+
+	return nil, fmt.Errorf("cannot save %T", object)
+}
@@ -0,0 +1,46 @@
+package hsscript
+
+import (
+	"regexp"
+	"strings"
+)
+
+// compileGlob converts a glob pattern to a regular expression. * matches any characters
+// except a slash, ** matches also slashes and ? matches a single character. Matching is case-insensitive.
+func compileGlob(pattern string) (*regexp.Regexp, error) {
+	var result strings.Builder
+
+	result.WriteString("(?i)^")
+
+	for i := 0; i < len(pattern); i++ {
+		switch c := pattern[i]; c {
+		case '*':
+			if i+1 < len(pattern) && pattern[i+1] == '*' {
+				i++
+
+				// "**/" matches also no directory at all
+				if i+1 < len(pattern) && pattern[i+1] == '/' {
+					i++
+
+					result.WriteString("(.*/)?")
+
+					continue
+				}
+
+				result.WriteString(".*")
+
+				continue
+			}
+
+			result.WriteString("[^/]*")
+		case '?':
+			result.WriteString("[^/]")
+		default:
+			result.WriteString(regexp.QuoteMeta(string(c)))
+		}
+	}
+
+	result.WriteString("$")
+
+	return regexp.Compile(result.String())
+}
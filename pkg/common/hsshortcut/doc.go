@@ -0,0 +1,3 @@
+// Package hsshortcut contains a registry of application's actions and their
+// keyboard shortcuts (default ones, overridden by user).
+package hsshortcut
@@ -0,0 +1,95 @@
+package hsshortcut
+
+import (
+	"log"
+	"sort"
+)
+
+// Action is an action of the application, which can be run by a keyboard shortcut
+type Action struct {
+	ID   string
+	Name string
+	// Default is a default shortcut of the action (in the form accepted by Parse)
+	Default string
+}
+
+// Registry holds actions and their shortcuts
+type Registry struct {
+	actions   []Action
+	shortcuts map[string]Shortcut
+}
+
+// NewRegistry creates a registry of actions given. Overrides are user's shortcuts
+// (by actions' IDs); invalid overrides are ignored.
+func NewRegistry(actions []Action, overrides map[string]string) *Registry {
+	result := &Registry{
+		actions:   actions,
+		shortcuts: make(map[string]Shortcut),
+	}
+
+	for _, action := range actions {
+		shortcut, err := Parse(action.Default)
+		if err != nil {
+			log.Printf("action %s: %v", action.ID, err)
+		}
+
+		if override, found := overrides[action.ID]; found {
+			if s, err := Parse(override); err == nil {
+				shortcut = s
+			} else {
+				log.Printf("action %s: %v", action.ID, err)
+			}
+		}
+
+		result.shortcuts[action.ID] = shortcut
+	}
+
+	return result
+}
+
+// Actions returns all the actions
+func (r *Registry) Actions() []Action {
+	return r.actions
+}
+
+// Shortcut returns a shortcut of the action
+func (r *Registry) Shortcut(id string) Shortcut {
+	return r.shortcuts[id]
+}
+
+// Label returns a text describing action's shortcut (e.g. in menus); empty if action has no shortcut
+func (r *Registry) Label(id string) string {
+	return r.shortcuts[id].String()
+}
+
+// Conflicts returns shortcuts used by more than one action (with IDs of these actions)
+func (r *Registry) Conflicts() map[string][]string {
+	return Conflicts(r.shortcuts)
+}
+
+// Conflicts returns shortcuts assigned to more than one action. Shortcuts are given
+// by action IDs; result maps shortcut's label to sorted IDs of actions using it.
+func Conflicts(shortcuts map[string]Shortcut) map[string][]string {
+	byShortcut := make(map[Shortcut][]string)
+
+	for id, shortcut := range shortcuts {
+		if shortcut.IsZero() {
+			continue
+		}
+
+		byShortcut[shortcut] = append(byShortcut[shortcut], id)
+	}
+
+	result := make(map[string][]string)
+
+	for shortcut, ids := range byShortcut {
+		if len(ids) == 1 {
+			continue
+		}
+
+		sort.Strings(ids)
+		result[shortcut.String()] = ids
+	}
+
+	return result
+}
@@ -0,0 +1,146 @@
+package hsshortcut
+
+import (
+	"fmt"
+	"strings"
+)
+
+const shortcutSep = "+"
+
+// modifier names
+const (
+	ctrlName  = "Ctrl"
+	shiftName = "Shift"
+	altName   = "Alt"
+)
+
+// keyNames are names of keys, which may be used in shortcuts. Aliases are mapped to canonical names.
+var keyNames = func() map[string]string {
+	result := map[string]string{
+		"esc":       "Escape",
+		"escape":    "Escape",
+		"enter":     "Enter",
+		"return":    "Enter",
+		"tab":       "Tab",
+		"space":     "Space",
+		"backspace": "Backspace",
+		"insert":    "Insert",
+		"ins":       "Insert",
+		"delete":    "Delete",
+		"del":       "Delete",
+		"home":      "Home",
+		"end":       "End",
+		"pageup":    "PageUp",
+		"pagedown":  "PageDown",
+		"up":        "Up",
+		"down":      "Down",
+		"left":      "Left",
+		"right":     "Right",
+		"minus":     "Minus",
+		"-":         "Minus",
+		"equal":     "Equal",
+		"=":         "Equal",
+		"comma":     "Comma",
+		",":         "Comma",
+		"period":    "Period",
+		".":         "Period",
+		"slash":     "Slash",
+		"/":         "Slash",
+	}
+
+	for c := 'A'; c <= 'Z'; c++ {
+		result[strings.ToLower(string(c))] = string(c)
+	}
+
+	for c := '0'; c <= '9'; c++ {
+		result[string(c)] = string(c)
+	}
+
+	const numFunctionKeys = 12
+
+	for i := 1; i <= numFunctionKeys; i++ {
+		name := fmt.Sprintf("F%d", i)
+		result[strings.ToLower(name)] = name
+	}
+
+	return result
+}()
+
+// Shortcut is a keyboard shortcut. Zero value means no shortcut.
+type Shortcut struct {
+	Ctrl  bool
+	Shift bool
+	Alt   bool
+	// Key is a canonical name of the key (e.g. "S", "F1", "Escape")
+	Key string
+}
+
+// Parse parses a shortcut written as modifiers and a key joined with "+" (e.g. "Ctrl+Shift+S").
+// Names are case-insensitive; empty string means no shortcut.
+func Parse(s string) (Shortcut, error) {
+	result := Shortcut{}
+
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return result, nil
+	}
+
+	parts := strings.Split(s, shortcutSep)
+
+	// "+" itself is not supported, so an empty part means a syntax error
+	for idx, part := range parts {
+		part = strings.TrimSpace(part)
+
+		if idx < len(parts)-1 {
+			switch strings.ToLower(part) {
+			case "ctrl", "control":
+				result.Ctrl = true
+			case "shift":
+				result.Shift = true
+			case "alt":
+				result.Alt = true
+			default:
+				return Shortcut{}, fmt.Errorf("%q: unknown modifier %q", s, part)
+			}
+
+			continue
+		}
+
+		key, found := keyNames[strings.ToLower(part)]
+		if !found {
+			return Shortcut{}, fmt.Errorf("%q: unknown key %q", s, part)
+		}
+
+		result.Key = key
+	}
+
+	return result, nil
+}
+
+// IsZero returns true if the shortcut is not set
+func (s Shortcut) IsZero() bool {
+	return s.Key == ""
+}
+
+// String returns a canonical form of the shortcut (e.g. "Ctrl+Shift+S")
+func (s Shortcut) String() string {
+	if s.IsZero() {
+		return ""
+	}
+
+	parts := make([]string, 0)
+
+	if s.Ctrl {
+		parts = append(parts, ctrlName)
+	}
+
+	if s.Shift {
+		parts = append(parts, shiftName)
+	}
+
+	if s.Alt {
+		parts = append(parts, altName)
+	}
+
+	return strings.Join(append(parts, s.Key), shortcutSep)
+}
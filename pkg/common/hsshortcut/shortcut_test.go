@@ -0,0 +1,71 @@
+package hsshortcut
+
+import (
+	"reflect"
+	"testing"
+)
+
+func Test_Parse(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+		valid    bool
+	}{
+		{"Ctrl+S", "Ctrl+S", true},
+		{"shift + ctrl + s", "Ctrl+Shift+S", true},
+		{"Alt+F4", "Alt+F4", true},
+		{"esc", "Escape", true},
+		{"", "", true},
+		{"Ctrl+", "", false},
+		{"Hyper+S", "", false},
+		{"Ctrl+Banana", "", false},
+	}
+
+	for _, test := range tests {
+		shortcut, err := Parse(test.input)
+
+		if (err == nil) != test.valid {
+			t.Errorf("%q: unexpected error %v", test.input, err)
+
+			continue
+		}
+
+		if shortcut.String() != test.expected {
+			t.Errorf("%q: expected %q, got %q", test.input, test.expected, shortcut.String())
+		}
+	}
+}
+
+func Test_Registry(t *testing.T) {
+	actions := []Action{
+		{ID: "save", Name: "Save", Default: "Ctrl+S"},
+		{ID: "saveAll", Name: "Save All", Default: "Ctrl+Shift+S"},
+		{ID: "open", Name: "Open", Default: "Ctrl+O"},
+		{ID: "close", Name: "Close", Default: "Ctrl+W"},
+	}
+
+	overrides := map[string]string{
+		"saveAll": "ctrl+s",
+		"open":    "invalid+O",
+		"close":   "",
+	}
+
+	registry := NewRegistry(actions, overrides)
+
+	if label := registry.Label("saveAll"); label != "Ctrl+S" {
+		t.Errorf("override wasn't applied: %q", label)
+	}
+
+	if label := registry.Label("open"); label != "Ctrl+O" {
+		t.Errorf("invalid override should be ignored: %q", label)
+	}
+
+	if !registry.Shortcut("close").IsZero() {
+		t.Error("empty override should remove the shortcut")
+	}
+
+	expected := map[string][]string{"Ctrl+S": {"save", "saveAll"}}
+	if conflicts := registry.Conflicts(); !reflect.DeepEqual(conflicts, expected) {
+		t.Errorf("expected conflicts %v, got %v", expected, conflicts)
+	}
+}
@@ -0,0 +1,4 @@
+// Package hswatcher contains a polling file system watcher, which reports
+// files created, modified and removed inside of a directory tree
+// (e.g. project's content changed by external tools).
+package hswatcher
@@ -0,0 +1,239 @@
+package hswatcher
+
+import (
+	"io/fs"
+	"log"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// DefaultInterval is a default time between two scans of the watched directory
+const DefaultInterval = time.Second
+
+// Op is a kind of change
+//
+//go:generate stringer -linecomment -type Op -output op_string.go
+type Op byte
+
+// changes reported by the watcher
+const (
+	OpCreate Op = iota // CREATE
+	OpWrite            // WRITE
+	OpRemove           // REMOVE
+)
+
+// Event describes a change of a single file or directory.
+// When a directory is created or removed, only the directory itself is reported
+// (not its content).
+type Event struct {
+	Path  string
+	Op    Op
+	IsDir bool
+}
+
+// Handler is called with events found during a single scan
+type Handler func(events []Event)
+
+type fileInfo struct {
+	modTime time.Time
+	size    int64
+	isDir   bool
+}
+
+type snapshot map[string]fileInfo
+
+// Watcher periodically scans a directory tree and reports changes.
+// Hidden files and directories (starting with a dot) are ignored.
+type Watcher struct {
+	root     string
+	interval time.Duration
+	handler  Handler
+	files    snapshot
+	stop     chan struct{}
+	done     chan struct{}
+	mutex    sync.Mutex
+	// pollMutex prevents concurrent scans from replacing newer snapshot by the older one
+	pollMutex sync.Mutex
+}
+
+// New creates a new watcher of the root directory
+func New(root string, interval time.Duration) *Watcher {
+	result := &Watcher{
+		root:     root,
+		interval: interval,
+	}
+
+	return result
+}
+
+// Start takes the initial snapshot of the directory and starts watching it.
+// Handler is called from the watcher's goroutine.
+func (w *Watcher) Start(handler Handler) {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+
+	if w.stop != nil {
+		return
+	}
+
+	w.handler = handler
+	w.files = w.scan()
+	w.stop = make(chan struct{})
+	w.done = make(chan struct{})
+
+	go w.run(w.stop, w.done)
+}
+
+// Stop stops watching. Handler isn't called after Stop returns.
+func (w *Watcher) Stop() {
+	w.mutex.Lock()
+	stop, done := w.stop, w.done
+	w.stop, w.done = nil, nil
+	w.mutex.Unlock()
+
+	if stop == nil {
+		return
+	}
+
+	close(stop)
+	<-done
+}
+
+func (w *Watcher) run(stop, done chan struct{}) {
+	defer close(done)
+
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-stop:
+			return
+		case <-ticker.C:
+			if events := w.Poll(); len(events) > 0 {
+				w.handler(events)
+			}
+		}
+	}
+}
+
+// Poll scans the directory immediately and returns changes since the previous scan
+func (w *Watcher) Poll() []Event {
+	w.pollMutex.Lock()
+	defer w.pollMutex.Unlock()
+
+	current := w.scan()
+
+	w.mutex.Lock()
+	previous := w.files
+	w.files = current
+	w.mutex.Unlock()
+
+	return diff(previous, current)
+}
+
+func (w *Watcher) scan() snapshot {
+	result := make(snapshot)
+
+	err := filepath.WalkDir(w.root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			// file may be removed during the scan
+			return nil
+		}
+
+		if path == w.root {
+			return nil
+		}
+
+		if strings.HasPrefix(d.Name(), ".") {
+			if d.IsDir() {
+				return filepath.SkipDir
+			}
+
+			return nil
+		}
+
+		info, err := d.Info()
+		if err != nil {
+			return nil
+		}
+
+		result[path] = fileInfo{
+			modTime: info.ModTime(),
+			size:    info.Size(),
+			isDir:   d.IsDir(),
+		}
+
+		return nil
+	})
+	if err != nil {
+		log.Printf("error scanning %s: %v", w.root, err)
+	}
+
+	return result
+}
+
+func diff(previous, current snapshot) []Event {
+	result := make([]Event, 0)
+
+	for path, info := range current {
+		old, found := previous[path]
+
+		switch {
+		case !found || old.isDir != info.isDir:
+			if found {
+				result = append(result, Event{Path: path, Op: OpRemove, IsDir: old.isDir})
+			}
+
+			result = append(result, Event{Path: path, Op: OpCreate, IsDir: info.isDir})
+		case !info.isDir && (!old.modTime.Equal(info.modTime) || old.size != info.size):
+			result = append(result, Event{Path: path, Op: OpWrite})
+		}
+	}
+
+	for path, info := range previous {
+		if _, found := current[path]; !found {
+			result = append(result, Event{Path: path, Op: OpRemove, IsDir: info.isDir})
+		}
+	}
+
+	sort.SliceStable(result, func(i, j int) bool {
+		return result[i].Path < result[j].Path
+	})
+
+	return collapse(result)
+}
+
+// collapse removes events of files inside of created/removed directories
+func collapse(events []Event) []Event {
+	result := make([]Event, 0, len(events))
+
+	var dirs []Event
+
+	for _, e := range events {
+		inside := false
+
+		for _, dir := range dirs {
+			if dir.Op == e.Op && strings.HasPrefix(e.Path, dir.Path+string(filepath.Separator)) {
+				inside = true
+
+				break
+			}
+		}
+
+		if inside {
+			continue
+		}
+
+		if e.IsDir && e.Op != OpWrite {
+			dirs = append(dirs, e)
+		}
+
+		result = append(result, e)
+	}
+
+	return result
+}
@@ -0,0 +1,93 @@
+package hswatcher
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func Test_Watcher_Poll(t *testing.T) {
+	dir := t.TempDir()
+
+	write := func(path, content string) {
+		if err := os.WriteFile(filepath.Join(dir, path), []byte(content), 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	write("modified.txt", "a")
+	write("removed.txt", "a")
+	write(".hidden", "a")
+
+	if err := os.MkdirAll(filepath.Join(dir, "removed", "sub"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+
+	write(filepath.Join("removed", "sub", "file.txt"), "a")
+
+	w := New(dir, DefaultInterval)
+	w.Start(func([]Event) {})
+
+	defer w.Stop()
+
+	write("modified.txt", "changed")
+	write("created.txt", "a")
+	write(".hidden", "changed")
+
+	if err := os.Remove(filepath.Join(dir, "removed.txt")); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := os.RemoveAll(filepath.Join(dir, "removed")); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := os.MkdirAll(filepath.Join(dir, "created", "sub"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+
+	expected := []Event{
+		{Path: filepath.Join(dir, "created"), Op: OpCreate, IsDir: true},
+		{Path: filepath.Join(dir, "created.txt"), Op: OpCreate},
+		{Path: filepath.Join(dir, "modified.txt"), Op: OpWrite},
+		{Path: filepath.Join(dir, "removed"), Op: OpRemove, IsDir: true},
+		{Path: filepath.Join(dir, "removed.txt"), Op: OpRemove},
+	}
+
+	if events := w.Poll(); !reflect.DeepEqual(events, expected) {
+		t.Fatalf("expected %v, got %v", expected, events)
+	}
+
+	if events := w.Poll(); len(events) != 0 {
+		t.Fatalf("unexpected events %v", events)
+	}
+}
+
+func Test_Watcher_CallsHandler(t *testing.T) {
+	dir := t.TempDir()
+
+	events := make(chan []Event, 1)
+
+	w := New(dir, 10*time.Millisecond)
+	w.Start(func(e []Event) {
+		events <- e
+	})
+
+	defer w.Stop()
+
+	path := filepath.Join(dir, "file.txt")
+	if err := os.WriteFile(path, nil, 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	select {
+	case e := <-events:
+		if len(e) != 1 || e[0].Path != path || e[0].Op != OpCreate {
+			t.Fatalf("unexpected events %v", e)
+		}
+	case <-time.After(10 * time.Second):
+		t.Fatal("timed out")
+	}
+}
@@ -0,0 +1,25 @@
+// Package plugin allows to add support for new file types to HellSpawner
+// without forking it.
+//
+// There are two kinds of plugins:
+//
+// Compiled-in plugins are Go packages, which call Register from their init
+// function. They provide a file type descriptor and an editor constructor, so
+// they may use the whole HellSpawner's UI toolkit. A plugin is linked into the
+// binary by a blank import placed in a file guarded by a build tag, e.g.
+// pkg/app/plugin_d2s.go:
+//
+//	//go:build hsplugin_d2s
+//
+//	package app
+//
+//	import _ "example.com/hellspawner-d2s"
+//
+// and enabled with `go build -tags hsplugin_d2s`.
+//
+// External plugins are executables placed in the plugins directory (see
+// config.Config.PluginsPath). HellSpawner starts each of them at startup and
+// talks to it with JSON-RPC over plugin's stdin and stdout (see Handler and
+// Serve for the protocol). An external plugin describes its file types and
+// converts files to text (and back), which is edited in a text editor.
+package plugin
@@ -0,0 +1,263 @@
+package plugin
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net/rpc"
+	"net/rpc/jsonrpc"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"sync"
+	"time"
+
+	"github.com/gucio321/HellSpawner/pkg/common/hsfiletypes"
+	"github.com/gucio321/HellSpawner/pkg/window/editor/external"
+)
+
+const (
+	// describeTimeout is a time given to an external plugin to describe itself after start
+	describeTimeout = 5 * time.Second
+	// callTimeout is a time given to an external plugin to answer a call (calls are made from the UI)
+	callTimeout = 10 * time.Second
+)
+
+// client talks to a running external plugin
+type client struct {
+	path string
+	cmd  *exec.Cmd
+	rpc  *rpc.Client
+	// timeout of calls; callTimeout if zero
+	timeout time.Duration
+
+	closeOnce sync.Once
+	closeErr  error
+}
+
+// LoadDir starts external plugins from the directory given. Plugins, which fail to start,
+// are skipped and their errors are returned. A missing directory means no plugins.
+func LoadDir(dir string) (result []*Plugin, errs []error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, nil
+		}
+
+		return nil, []error{fmt.Errorf("cannot read plugins directory: %w", err)}
+	}
+
+	for _, entry := range entries {
+		path := filepath.Join(dir, entry.Name())
+
+		if !isExecutable(entry) {
+			continue
+		}
+
+		p, err := Start(path)
+		if err != nil {
+			errs = append(errs, err)
+
+			continue
+		}
+
+		result = append(result, p)
+	}
+
+	return result, errs
+}
+
+func isExecutable(entry os.DirEntry) bool {
+	if entry.IsDir() {
+		return false
+	}
+
+	if runtime.GOOS == "windows" {
+		return filepath.Ext(entry.Name()) == ".exe"
+	}
+
+	info, err := entry.Info()
+	if err != nil {
+		return false
+	}
+
+	const executableBits = 0o111
+
+	return info.Mode().IsRegular() && info.Mode().Perm()&executableBits != 0
+}
+
+// Start starts an external plugin
+func Start(path string) (*Plugin, error) {
+	cmd := exec.Command(path) //nolint:gosec // plugins are executables chosen by user
+	cmd.Stderr = log.Writer()
+
+	stdin, err := cmd.StdinPipe()
+	if err != nil {
+		return nil, fmt.Errorf("plugin %s: %w", path, err)
+	}
+
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return nil, fmt.Errorf("plugin %s: %w", path, err)
+	}
+
+	if err := cmd.Start(); err != nil {
+		return nil, fmt.Errorf("cannot start plugin %s: %w", path, err)
+	}
+
+	return connect(path, cmd, stdio{stdout, stdin})
+}
+
+// connect creates a plugin talking to the external plugin over the connection given.
+// Cmd is plugin's process (if any), which is stopped when plugin is closed.
+func connect(path string, cmd *exec.Cmd, conn io.ReadWriteCloser) (*Plugin, error) {
+	c := &client{
+		path: path,
+		cmd:  cmd,
+		rpc:  jsonrpc.NewClient(conn),
+	}
+
+	p, err := c.plugin()
+	if err != nil {
+		return nil, errors.Join(err, c.close())
+	}
+
+	return p, nil
+}
+
+// call calls plugin's method. If plugin doesn't answer in time, an error is returned
+// and the reply must not be used (it may be written when the answer comes).
+func (c *client) call(method string, args, reply interface{}) error {
+	timeout := c.timeout
+	if timeout == 0 {
+		timeout = callTimeout
+	}
+
+	return c.callWithTimeout(method, args, reply, timeout)
+}
+
+func (c *client) callWithTimeout(method string, args, reply interface{}, timeout time.Duration) error {
+	call := c.rpc.Go(serviceName+"."+method, args, reply, make(chan *rpc.Call, 1))
+
+	select {
+	case <-call.Done:
+		if call.Error != nil {
+			return fmt.Errorf("plugin %s: %s: %w", filepath.Base(c.path), method, call.Error)
+		}
+	case <-time.After(timeout):
+		return fmt.Errorf("plugin %s didn't answer %s in %v", filepath.Base(c.path), method, timeout)
+	}
+
+	return nil
+}
+
+// plugin asks the external plugin for its description and creates a plugin of it
+func (c *client) plugin() (*Plugin, error) {
+	var description Description
+
+	if err := c.callWithTimeout("Describe", struct{}{}, &description, describeTimeout); err != nil {
+		return nil, err
+	}
+
+	result := &Plugin{
+		Name:  description.Name,
+		close: c.close,
+	}
+
+	if result.Name == "" {
+		result.Name = filepath.Base(c.path)
+	}
+
+	for _, info := range description.FileTypes {
+		result.FileTypes = append(result.FileTypes, c.fileType(info))
+	}
+
+	return result, nil
+}
+
+func (c *client) fileType(info FileTypeInfo) FileType {
+	result := FileType{
+		Descriptor: hsfiletypes.Descriptor{
+			Name:       info.Name,
+			Extensions: info.Extensions,
+		},
+		Editor: external.New(&codec{client: c, fileType: info.Name}),
+	}
+
+	if len(info.Magic) > 0 {
+		magic, offset := info.Magic, info.MagicOffset
+		result.Sniff = func(data []byte) bool {
+			return offset >= 0 && len(data) >= offset+len(magic) && bytes.Equal(data[offset:offset+len(magic)], magic)
+		}
+	}
+
+	if info.CanCreate {
+		result.Template = func() []byte {
+			var reply TemplateReply
+			if err := c.call("Template", TemplateArgs{FileType: info.Name}, &reply); err != nil {
+				log.Print(err)
+
+				return nil
+			}
+
+			return reply.Data
+		}
+	}
+
+	return result
+}
+
+// close stops the plugin. It may be called many times; the plugin is stopped by the first call.
+func (c *client) close() error {
+	c.closeOnce.Do(func() {
+		c.closeErr = c.stop()
+	})
+
+	return c.closeErr
+}
+
+func (c *client) stop() error {
+	err := c.rpc.Close()
+
+	if c.cmd == nil {
+		return err
+	}
+
+	// plugin should exit after its stdin is closed
+	done := make(chan error, 1)
+	go func() { done <- c.cmd.Wait() }()
+
+	select {
+	case <-done:
+		return err
+	case <-time.After(describeTimeout):
+		return errors.Join(err, c.cmd.Process.Kill())
+	}
+}
+
+// codec converts files of one type with the external plugin
+type codec struct {
+	client   *client
+	fileType string
+}
+
+func (c *codec) Decode(data []byte) (string, error) {
+	var reply DecodeReply
+	if err := c.client.call("Decode", DecodeArgs{FileType: c.fileType, Data: data}, &reply); err != nil {
+		return "", err
+	}
+
+	return reply.Text, nil
+}
+
+func (c *codec) Encode(text string) ([]byte, error) {
+	var reply EncodeReply
+	if err := c.client.call("Encode", EncodeArgs{FileType: c.fileType, Text: text}, &reply); err != nil {
+		return nil, err
+	}
+
+	return reply.Data, nil
+}
@@ -0,0 +1,130 @@
+package plugin
+
+import (
+	"errors"
+	"net"
+	"net/rpc/jsonrpc"
+	"strings"
+	"testing"
+	"time"
+)
+
+type testHandler struct{}
+
+func (testHandler) Describe() Description {
+	return Description{
+		Name: "test",
+		FileTypes: []FileTypeInfo{
+			{Name: "Test save", Extensions: []string{".tsav"}, Magic: []byte("TS"), MagicOffset: 1, CanCreate: true},
+		},
+	}
+}
+
+func (testHandler) Template(string) ([]byte, error) {
+	return []byte("_TS new"), nil
+}
+
+func (testHandler) Decode(_ string, data []byte) (string, error) {
+	return strings.ToUpper(string(data)), nil
+}
+
+func (testHandler) Encode(_, text string) ([]byte, error) {
+	if text == "" {
+		return nil, errors.New("empty")
+	}
+
+	return []byte(strings.ToLower(text)), nil
+}
+
+// slowHandler doesn't encode until it is released
+type slowHandler struct {
+	testHandler
+	release chan struct{}
+}
+
+func (h slowHandler) Encode(fileType, text string) ([]byte, error) {
+	<-h.release
+
+	return h.testHandler.Encode(fileType, text)
+}
+
+func Test_ExternalPlugin(t *testing.T) {
+	serverConn, clientConn := net.Pipe()
+
+	go func() {
+		if err := ServeConn(testHandler{}, serverConn); err != nil {
+			t.Error(err)
+		}
+	}()
+
+	c := &client{path: "test-plugin", rpc: jsonrpc.NewClient(clientConn)}
+
+	p, err := c.plugin()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	defer func() {
+		if err := p.Close(); err != nil {
+			t.Error(err)
+		}
+
+		if err := p.Close(); err != nil {
+			t.Errorf("closing plugin again: %v", err)
+		}
+	}()
+
+	if p.Name != "test" || len(p.FileTypes) != 1 {
+		t.Fatalf("unexpected plugin: %+v", p)
+	}
+
+	fileType := p.FileTypes[0]
+
+	if !fileType.Sniff([]byte("_TSxx")) || fileType.Sniff([]byte("TSxx")) || fileType.Sniff([]byte("_")) {
+		t.Error("magic isn't checked at its offset")
+	}
+
+	if data := fileType.Template(); string(data) != "_TS new" {
+		t.Errorf("unexpected template: %q", data)
+	}
+
+	codec := &codec{client: c, fileType: fileType.Name}
+
+	text, err := codec.Decode([]byte("abc"))
+	if err != nil || text != "ABC" {
+		t.Errorf("unexpected decoded text %q (%v)", text, err)
+	}
+
+	if _, err := codec.Encode(""); err == nil {
+		t.Error("plugin's error should be returned")
+	}
+}
+
+func Test_ExternalPlugin_Timeout(t *testing.T) {
+	serverConn, clientConn := net.Pipe()
+	release := make(chan struct{})
+
+	defer close(release)
+
+	go func() {
+		_ = ServeConn(slowHandler{release: release}, serverConn)
+	}()
+
+	const timeout = 50 * time.Millisecond
+
+	c := &client{path: "slow-plugin", rpc: jsonrpc.NewClient(clientConn), timeout: timeout}
+
+	defer c.rpc.Close()
+
+	codec := &codec{client: c, fileType: "Test save"}
+
+	start := time.Now()
+
+	if _, err := codec.Encode("abc"); err == nil {
+		t.Error("call should time out")
+	}
+
+	if elapsed := time.Since(start); elapsed > 10*timeout {
+		t.Errorf("call took %v, timeout is %v", elapsed, timeout)
+	}
+}
@@ -0,0 +1,69 @@
+package plugin
+
+import (
+	"sync"
+
+	"github.com/gucio321/HellSpawner/pkg/app/config"
+	"github.com/gucio321/HellSpawner/pkg/common"
+	"github.com/gucio321/HellSpawner/pkg/common/hsfiletypes"
+	"github.com/gucio321/HellSpawner/pkg/common/hsproject"
+	"github.com/gucio321/HellSpawner/pkg/window/editor"
+)
+
+// EditorConstructor creates an editor of the file given
+type EditorConstructor func(
+	config *config.Config,
+	pathEntry *common.PathEntry,
+	state []byte,
+	data *[]byte,
+	x, y float32,
+	project *hsproject.Project,
+) (editor.Editor, error)
+
+// FileType is a file type supported by a plugin
+type FileType struct {
+	hsfiletypes.Descriptor
+	Editor EditorConstructor
+}
+
+// Plugin represents a plugin
+type Plugin struct {
+	Name      string
+	FileTypes []FileType
+
+	// close stops external plugin's process
+	close func() error
+}
+
+// Close releases resources used by the plugin. Closing a closed plugin does nothing.
+func (p *Plugin) Close() error {
+	if p.close == nil {
+		return nil
+	}
+
+	return p.close()
+}
+
+var compiledIn = struct {
+	sync.Mutex
+	plugins []*Plugin
+}{}
+
+// Register registers a compiled-in plugin. It should be called from plugin's init function.
+func Register(p *Plugin) {
+	compiledIn.Lock()
+	defer compiledIn.Unlock()
+
+	compiledIn.plugins = append(compiledIn.plugins, p)
+}
+
+// CompiledIn returns plugins registered by Register
+func CompiledIn() []*Plugin {
+	compiledIn.Lock()
+	defer compiledIn.Unlock()
+
+	result := make([]*Plugin, len(compiledIn.plugins))
+	copy(result, compiledIn.plugins)
+
+	return result
+}
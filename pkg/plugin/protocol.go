@@ -0,0 +1,127 @@
+package plugin
+
+import (
+	"errors"
+	"io"
+	"net/rpc"
+	"net/rpc/jsonrpc"
+	"os"
+)
+
+// serviceName is a name of the JSON-RPC service exposed by external plugins
+const serviceName = "Plugin"
+
+// Description describes an external plugin
+type Description struct {
+	Name      string         `json:"name"`
+	FileTypes []FileTypeInfo `json:"fileTypes"`
+}
+
+// FileTypeInfo describes a file type of an external plugin
+type FileTypeInfo struct {
+	Name       string   `json:"name"`
+	Extensions []string `json:"extensions"`
+	// Magic, if set, must appear at MagicOffset of the file of this type
+	Magic       []byte `json:"magic,omitempty"`
+	MagicOffset int    `json:"magicOffset,omitempty"`
+	// CanCreate tells whether the plugin provides a template of a new file
+	CanCreate bool `json:"canCreate"`
+}
+
+// TemplateArgs are arguments of Plugin.Template
+type TemplateArgs struct {
+	FileType string `json:"fileType"`
+}
+
+// TemplateReply is a reply of Plugin.Template
+type TemplateReply struct {
+	Data []byte `json:"data"`
+}
+
+// DecodeArgs are arguments of Plugin.Decode
+type DecodeArgs struct {
+	FileType string `json:"fileType"`
+	Data     []byte `json:"data"`
+}
+
+// DecodeReply is a reply of Plugin.Decode
+type DecodeReply struct {
+	Text string `json:"text"`
+}
+
+// EncodeArgs are arguments of Plugin.Encode
+type EncodeArgs struct {
+	FileType string `json:"fileType"`
+	Text     string `json:"text"`
+}
+
+// EncodeReply is a reply of Plugin.Encode
+type EncodeReply struct {
+	Data []byte `json:"data"`
+}
+
+// Handler is implemented by external plugins. Each method is exposed as a JSON-RPC
+// method "Plugin.<name>"; byte slices are base64-encoded strings.
+type Handler interface {
+	// Describe returns plugin's name and file types
+	Describe() Description
+	// Template returns the content of a new file of the type given
+	Template(fileType string) ([]byte, error)
+	// Decode converts file's data to text, which may be edited by user
+	Decode(fileType string, data []byte) (string, error)
+	// Encode converts the edited text back to file's data
+	Encode(fileType, text string) ([]byte, error)
+}
+
+// service adapts Handler to net/rpc
+type service struct {
+	handler Handler
+}
+
+func (s *service) Describe(_ struct{}, reply *Description) error {
+	*reply = s.handler.Describe()
+
+	return nil
+}
+
+func (s *service) Template(args TemplateArgs, reply *TemplateReply) (err error) {
+	reply.Data, err = s.handler.Template(args.FileType)
+
+	return err
+}
+
+func (s *service) Decode(args DecodeArgs, reply *DecodeReply) (err error) {
+	reply.Text, err = s.handler.Decode(args.FileType, args.Data)
+
+	return err
+}
+
+func (s *service) Encode(args EncodeArgs, reply *EncodeReply) (err error) {
+	reply.Data, err = s.handler.Encode(args.FileType, args.Text)
+
+	return err
+}
+
+// stdio joins a reader and a writer into a connection
+type stdio struct {
+	io.Reader
+	io.WriteCloser
+}
+
+// Serve serves the handler over stdin and stdout. It is meant to be called by main function of an external plugin
+// and returns when HellSpawner closes plugin's stdin.
+func Serve(handler Handler) error {
+	return ServeConn(handler, stdio{os.Stdin, os.Stdout})
+}
+
+// ServeConn serves the handler over the connection given
+func ServeConn(handler Handler, conn io.ReadWriteCloser) error {
+	server := rpc.NewServer()
+	if err := server.RegisterName(serviceName, &service{handler}); err != nil {
+		return errors.Join(err, conn.Close())
+	}
+
+	server.ServeCodec(jsonrpc.NewServerCodec(conn))
+
+	return nil
+}
@@ -0,0 +1,171 @@
+package animdatawidget
+
+import (
+	"fmt"
+	"log"
+	"sync"
+
+	"github.com/AllenDang/giu"
+
+	"github.com/OpenDiablo2/OpenDiablo2/d2common/d2fileformats/d2cof"
+
+	"github.com/gucio321/HellSpawner/pkg/common/hsanimdata"
+)
+
+const (
+	issuesListW, issuesListH = 500, 400
+	fixButtonW               = 40
+)
+
+// cofsLoad is a result of loading project's COF files in background
+type cofsLoad struct {
+	mutex sync.Mutex
+	done  bool
+	cofs  map[string]*d2cof.COF
+}
+
+func (l *cofsLoad) finish(cofs map[string]*d2cof.COF) {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	l.done = true
+	l.cofs = cofs
+}
+
+func (l *cofsLoad) result() (done bool, cofs map[string]*d2cof.COF) {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	return l.done, l.cofs
+}
+
+// reloadCOFs loads COF files of the project in background; check is run, when they're loaded
+func (p *widget) reloadCOFs() {
+	state := p.getState()
+
+	state.issues = nil
+	state.cofs = nil
+	state.cofsLoading = nil
+
+	if p.project == nil {
+		log.Print("animation data check: no project loaded")
+
+		state.cofs = make(map[string]*d2cof.COF)
+		p.runCheck()
+
+		return
+	}
+
+	load := &cofsLoad{}
+	state.cofsLoading = load
+
+	project, closeMPQs := p.project.Detached()
+
+	go func() {
+		defer closeMPQs()
+
+		load.finish(project.LoadAnimationCOFs())
+	}()
+}
+
+// runCheck compares animation data with COF files already loaded
+func (p *widget) runCheck() {
+	state := p.getState()
+
+	state.issues = hsanimdata.Check(p.d2, state.cofs)
+}
+
+func (p *widget) fixIssue(issue *hsanimdata.Issue) {
+	if err := issue.Fix(); err != nil {
+		log.Print(err)
+	}
+}
+
+func (p *widget) buildCheckLayout() {
+	state := p.getState()
+
+	if state.cofsLoading != nil {
+		if done, cofs := state.cofsLoading.result(); done {
+			state.cofs = cofs
+			state.cofsLoading = nil
+			p.runCheck()
+		}
+	}
+
+	if state.cofs == nil {
+		if state.cofsLoading == nil {
+			p.reloadCOFs()
+		}
+
+		giu.Layout{
+			giu.Label("Loading COF files..."),
+			giu.Button("Back to entry preview").Size(actionBtnW, actionBtnH).OnClick(func() {
+				state.Mode = widgetModeList
+			}),
+		}.Build()
+
+		return
+	}
+
+	if state.issues == nil {
+		p.runCheck()
+	}
+
+	counts := make(map[hsanimdata.IssueType]int)
+	for _, issue := range state.issues {
+		counts[issue.Type]++
+	}
+
+	list := make([]giu.Widget, 0, len(state.issues))
+
+	for idx, issue := range state.issues {
+		fixButton := giu.Button(fmt.Sprintf("Fix##%sFixIssue%d", p.id, idx)).
+			Size(fixButtonW, 0).
+			Disabled(!issue.CanFix()).
+			OnClick(func() {
+				p.fixIssue(issue)
+				p.reloadMapKeys()
+				p.runCheck()
+			})
+
+		list = append(list, giu.Row(fixButton, giu.Label(issue.String())))
+	}
+
+	giu.Layout{
+		giu.Label(fmt.Sprintf("Missing records: %d, orphans: %d, frames mismatches: %d, speed mismatches: %d",
+			counts[hsanimdata.IssueMissingRecord],
+			counts[hsanimdata.IssueOrphanRecord],
+			counts[hsanimdata.IssueFramesMismatch],
+			counts[hsanimdata.IssueSpeedMismatch],
+		)),
+		giu.Separator(),
+		giu.Child().Border(false).
+			Size(issuesListW, issuesListH).
+			Layout(giu.Custom(func() {
+				if len(list) > 0 {
+					giu.Layout(list).Build()
+
+					return
+				}
+
+				giu.Label("No issues found").Build()
+			})),
+		giu.Separator(),
+		giu.Row(
+			giu.Button("Fix all").Size(actionBtnW, actionBtnH).OnClick(func() {
+				for _, issue := range state.issues {
+					if issue.CanFix() {
+						p.fixIssue(issue)
+					}
+				}
+
+				p.reloadMapKeys()
+				p.runCheck()
+			}),
+			giu.Button("Check again").Size(actionBtnW, actionBtnH).OnClick(p.reloadCOFs),
+		),
+		giu.Button("Back to entry preview").Size(actionBtnW, actionBtnH).OnClick(func() {
+			state.Mode = widgetModeList
+		}),
+	}.Build()
+}
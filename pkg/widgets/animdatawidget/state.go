@@ -4,9 +4,12 @@ import (
 	"fmt"
 	"github.com/gucio321/HellSpawner/pkg/app/assets"
 	"github.com/gucio321/HellSpawner/pkg/common"
+	"github.com/gucio321/HellSpawner/pkg/common/hsanimdata"
 	"sort"
 
 	"github.com/AllenDang/giu"
+
+	"github.com/OpenDiablo2/OpenDiablo2/d2common/d2fileformats/d2cof"
 )
 
 type widgetMode int32
@@ -14,6 +17,7 @@ type widgetMode int32
 const (
 	widgetModeList widgetMode = iota
 	widgetModeViewRecord
+	widgetModeCheck
 )
 
 type widgetState struct {
@@ -23,6 +27,12 @@ type widgetState struct {
 	RecordIdx  int32
 	deleteIcon *giu.Texture
 	addEntryState
+
+	// cache - will not be saved
+	issues      []*hsanimdata.Issue
+	cofs        map[string]*d2cof.COF
+	cofsLoading *cofsLoad
+	timeline    timelineState
 }
 
 // Dispose clears widget's state
@@ -33,6 +43,10 @@ func (s *widgetState) Dispose() {
 	s.RecordIdx = 0
 	s.addEntryState.Dispose()
 	s.deleteIcon = nil
+	s.issues = nil
+	s.cofs = nil
+	s.cofsLoading = nil
+	s.timeline.Dispose()
 }
 
 type addEntryState struct {
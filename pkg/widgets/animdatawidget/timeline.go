@@ -0,0 +1,467 @@
+package animdatawidget
+
+import (
+	"fmt"
+	"image"
+	"image/color"
+	"sync"
+	"time"
+
+	"github.com/AllenDang/cimgui-go/imgui"
+	"github.com/AllenDang/giu"
+
+	"github.com/OpenDiablo2/OpenDiablo2/d2common/d2fileformats/d2animdata"
+	"github.com/OpenDiablo2/OpenDiablo2/d2common/d2fileformats/d2dcc"
+)
+
+const (
+	timelineFramesPerRow = 24
+	timelineFrameW       = 22
+	timelineFrameH       = 22
+	timelineBorderSize   = 2
+	timelineDragDropType = "ANIMDATA_EVENT"
+	previewScale         = 2
+	previewButtonW       = 60
+	maxTimelineFrames    = 144 // number of event bytes in animation data record
+	maxAlpha             = 255
+)
+
+// timelineState holds state of record's timeline and animation preview
+type timelineState struct {
+	isPlaying bool
+	frame     int32
+	lastTick  time.Time
+	dragFrom  int
+
+	previewName      string
+	previewDirection int32
+	preview          *animationPreview
+	previewErr       string
+	// loading is a preview being loaded in background (nil if nothing is loaded)
+	loading *previewLoad
+}
+
+// Dispose clears timeline's state
+func (s *timelineState) Dispose() {
+	s.isPlaying = false
+	s.frame = 0
+	s.dragFrom = 0
+	s.previewName = ""
+	s.previewDirection = 0
+	s.releasePreview()
+	s.previewErr = ""
+	s.loading = nil
+}
+
+// releasePreview drops the preview and its textures, so that they can be freed
+func (s *timelineState) releasePreview() {
+	if s.preview != nil {
+		s.preview.textures = nil
+	}
+
+	s.preview = nil
+}
+
+// previewLoad is a result of loading a preview in background.
+// It is written only by the loading goroutine and read by the UI after it is done.
+type previewLoad struct {
+	mutex   sync.Mutex
+	done    bool
+	preview *animationPreview
+	err     string
+}
+
+func (l *previewLoad) finish(preview *animationPreview, err error) {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	l.done = true
+	l.preview = preview
+
+	if err != nil {
+		l.err = err.Error()
+	}
+}
+
+func (l *previewLoad) result() (done bool, preview *animationPreview, err string) {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	return l.done, l.preview, l.err
+}
+
+// animationPreview contains DCC layers of the animation matched to the record
+type animationPreview struct {
+	layers     []*d2dcc.DCC
+	directions int
+	frames     int
+	textures   []*giu.Texture
+	direction  int32
+}
+
+func eventName(event d2animdata.AnimationEvent) string {
+	table := map[d2animdata.AnimationEvent]string{
+		d2animdata.AnimationEventNone:    "None",
+		d2animdata.AnimationEventAttack:  "Attack",
+		d2animdata.AnimationEventMissile: "Missile",
+		d2animdata.AnimationEventSound:   "Sound",
+		d2animdata.AnimationEventSkill:   "Skill",
+	}
+
+	name, found := table[event]
+	if !found {
+		return fmt.Sprintf("Unknown (%d)", event)
+	}
+
+	return name
+}
+
+func eventColor(event d2animdata.AnimationEvent) color.RGBA {
+	table := map[d2animdata.AnimationEvent]color.RGBA{
+		d2animdata.AnimationEventAttack:  {R: 204, G: 51, B: 51, A: maxAlpha},
+		d2animdata.AnimationEventMissile: {R: 51, G: 102, B: 230, A: maxAlpha},
+		d2animdata.AnimationEventSound:   {R: 51, G: 179, B: 77, A: maxAlpha},
+		d2animdata.AnimationEventSkill:   {R: 153, G: 77, B: 204, A: maxAlpha},
+	}
+
+	return table[event]
+}
+
+// nextEvent returns event, which follows the given one when clicking on a timeline's frame
+func nextEvent(event d2animdata.AnimationEvent) d2animdata.AnimationEvent {
+	if event >= d2animdata.AnimationEventSkill {
+		return d2animdata.AnimationEventNone
+	}
+
+	return event + 1
+}
+
+// getRecord returns record currently shown in view record mode
+func (p *widget) getRecord() *d2animdata.AnimationDataRecord {
+	state := p.getState()
+
+	return p.d2.GetRecords(state.mapKeys[state.MapIndex])[state.RecordIdx]
+}
+
+// setEvent sets event on the given frame of the current record
+func (p *widget) setEvent(frame int, event d2animdata.AnimationEvent) {
+	record := p.getRecord()
+
+	if event == d2animdata.AnimationEventNone {
+		delete(record.Events(), frame)
+
+		return
+	}
+
+	record.SetEvent(frame, event)
+}
+
+func (p *widget) moveEvent(from, to int) {
+	if from == to {
+		return
+	}
+
+	event := p.getRecord().Event(from)
+	p.setEvent(from, d2animdata.AnimationEventNone)
+	p.setEvent(to, event)
+}
+
+func (p *widget) updatePlayback(record *d2animdata.AnimationDataRecord) {
+	state := &p.getState().timeline
+
+	numFrames := min(record.FramesPerDirection(), maxTimelineFrames)
+	if numFrames <= 0 {
+		state.isPlaying = false
+		state.frame = 0
+
+		return
+	}
+
+	//nolint:gosec // its for giu and has to be int32.
+	state.frame %= int32(numFrames)
+
+	if !state.isPlaying {
+		return
+	}
+
+	if record.Speed() == 0 {
+		state.isPlaying = false
+
+		return
+	}
+
+	frameDuration := time.Duration(record.FrameDurationMS() * float64(time.Millisecond))
+	elapsed := time.Since(state.lastTick)
+
+	if elapsed < frameDuration {
+		return
+	}
+
+	steps := int(elapsed / frameDuration)
+	state.lastTick = state.lastTick.Add(time.Duration(steps) * frameDuration)
+	//nolint:gosec // its for giu and has to be int32.
+	state.frame = int32((int(state.frame) + steps) % numFrames)
+}
+
+func (p *widget) makeTimelineLayout(record *d2animdata.AnimationDataRecord) giu.Layout {
+	state := &p.getState().timeline
+
+	p.updatePlayback(record)
+
+	numFrames := min(record.FramesPerDirection(), maxTimelineFrames)
+
+	legend := make([]giu.Widget, 0)
+	for event := d2animdata.AnimationEventAttack; event <= d2animdata.AnimationEventSkill; event++ {
+		legend = append(legend, giu.Style().SetColor(giu.StyleColorText, eventColor(event)).To(
+			giu.Label(fmt.Sprintf("[%c] %s", eventName(event)[0], eventName(event))),
+		))
+	}
+
+	playLabel := "Play"
+	if state.isPlaying {
+		playLabel = "Pause"
+	}
+
+	return giu.Layout{
+		giu.Label("Events timeline (click a frame to change its event, drag an event to move it):"),
+		giu.Row(legend...),
+		giu.Custom(func() {
+			p.buildTimelineFrames(record, numFrames)
+		}),
+		giu.Row(
+			giu.Button(fmt.Sprintf("%s##%sTimelinePlay", playLabel, p.id)).Size(previewButtonW, 0).OnClick(func() {
+				state.isPlaying = !state.isPlaying
+				state.lastTick = time.Now()
+			}),
+			giu.Custom(func() {
+				if numFrames > 0 {
+					//nolint:gosec // its for giu and has to be int32.
+					giu.SliderInt(&state.frame, 0, int32(numFrames-1)).Build()
+				}
+			}),
+		),
+		giu.Label(fmt.Sprintf("Frame %d: %s (%v ms)", state.frame, eventName(record.Event(int(state.frame))),
+			float64(state.frame)*record.FrameDurationMS())),
+	}
+}
+
+func (p *widget) buildTimelineFrames(record *d2animdata.AnimationDataRecord, numFrames int) {
+	state := &p.getState().timeline
+
+	for frame := 0; frame < numFrames; frame++ {
+		if frame%timelineFramesPerRow != 0 {
+			imgui.SameLine()
+		}
+
+		event := record.Event(frame)
+		label := " "
+		var numColors, numVars int32
+
+		if event != d2animdata.AnimationEventNone {
+			label = eventName(event)[:1]
+			imgui.PushStyleColorVec4(imgui.ColButton, giu.ToVec4Color(eventColor(event)))
+			numColors++
+		}
+
+		//nolint:gosec // frame is never greater than maxTimelineFrames
+		if int32(frame) == state.frame {
+			imgui.PushStyleColorVec4(imgui.ColBorder, imgui.Vec4{X: 1, Y: 0.8, Z: 0.1, W: 1})
+			imgui.PushStyleVarFloat(imgui.StyleVarFrameBorderSize, timelineBorderSize)
+			numColors++
+			numVars++
+		}
+
+		if imgui.ButtonV(fmt.Sprintf("%s##%sTimelineFrame%d", label, p.id, frame), imgui.Vec2{X: timelineFrameW, Y: timelineFrameH}) {
+			p.setEvent(frame, nextEvent(event))
+		}
+
+		imgui.PopStyleVarV(numVars)
+		imgui.PopStyleColorV(numColors)
+
+		if imgui.IsItemHovered() {
+			imgui.SetTooltip(fmt.Sprintf("Frame %d: %s", frame, eventName(event)))
+		}
+
+		if event != d2animdata.AnimationEventNone && imgui.BeginDragDropSource() {
+			state.dragFrom = frame
+			imgui.SetDragDropPayload(timelineDragDropType, 0, 0)
+			imgui.Text(eventName(event))
+			imgui.EndDragDropSource()
+		}
+
+		if imgui.BeginDragDropTarget() {
+			if imgui.AcceptDragDropPayload(timelineDragDropType) != nil {
+				p.moveEvent(state.dragFrom, frame)
+			}
+
+			imgui.EndDragDropTarget()
+		}
+	}
+}
+
+func (p *widget) makePreviewLayout(name string) giu.Layout {
+	state := &p.getState().timeline
+
+	if state.previewName != name {
+		p.loadPreview(name)
+	}
+
+	if state.loading != nil {
+		if done, preview, err := state.loading.result(); done {
+			state.preview, state.previewErr = preview, err
+			state.loading = nil
+		}
+	}
+
+	if state.previewErr != "" {
+		return giu.Layout{giu.Label(fmt.Sprintf("Preview unavailable: %s", state.previewErr))}
+	}
+
+	preview := state.preview
+	if preview == nil {
+		return giu.Layout{giu.Label("Loading preview...")}
+	}
+
+	if preview.direction != state.previewDirection || preview.textures == nil {
+		p.makePreviewTextures(preview, state.previewDirection)
+	}
+
+	if preview.directions == 0 {
+		return giu.Layout{giu.Label("Preview unavailable: animation has no directions")}
+	}
+
+	return giu.Layout{
+		giu.Row(
+			giu.Label("Direction: "),
+			//nolint:gosec // its for giu and has to be int32.
+			giu.SliderInt(&state.previewDirection, 0, int32(preview.directions-1)),
+		),
+		giu.Custom(func() {
+			if preview.frames == 0 || len(preview.textures) == 0 {
+				return
+			}
+
+			texture := preview.textures[int(state.frame)%preview.frames]
+			if texture == nil {
+				return
+			}
+
+			img := preview.imageSize(int(state.previewDirection))
+			giu.Image(texture).Size(float32(img.X*previewScale), float32(img.Y*previewScale)).Build()
+		}),
+	}
+}
+
+// loadPreview loads DCC layers of the animation in background
+func (p *widget) loadPreview(name string) {
+	state := &p.getState().timeline
+
+	state.previewName = name
+	state.previewDirection = 0
+	state.releasePreview()
+	state.previewErr = ""
+	state.loading = nil
+
+	if p.project == nil {
+		state.previewErr = "no project loaded"
+
+		return
+	}
+
+	// user could switch to another record in the meantime; result of
+	// the previous load is then dropped together with its previewLoad
+	load := &previewLoad{}
+	state.loading = load
+
+	project, closeMPQs := p.project.Detached()
+
+	go func() {
+		defer closeMPQs()
+
+		layers, err := project.LoadAnimationDCCs(name)
+		if err != nil {
+			load.finish(nil, err)
+
+			return
+		}
+
+		preview := &animationPreview{
+			layers: layers,
+		}
+
+		for _, layer := range layers {
+			preview.directions = max(preview.directions, layer.NumberOfDirections)
+			preview.frames = max(preview.frames, layer.FramesPerDirection)
+		}
+
+		load.finish(preview, nil)
+	}()
+}
+
+// imageSize returns a size of the box containing all the layers in the given direction
+func (a *animationPreview) imageSize(direction int) image.Point {
+	return a.bounds(direction).Size()
+}
+
+func (a *animationPreview) bounds(direction int) image.Rectangle {
+	var result image.Rectangle
+
+	for _, layer := range a.layers {
+		if direction >= len(layer.Directions) {
+			continue
+		}
+
+		box := layer.Directions[direction].Box
+		result = result.Union(image.Rect(box.Left, box.Top, box.Left+box.Width, box.Top+box.Height))
+	}
+
+	return result
+}
+
+// composeFrame draws all the layers of the given frame on a single image
+func (a *animationPreview) composeFrame(direction, frame int) *image.RGBA {
+	bounds := a.bounds(direction)
+	result := image.NewRGBA(image.Rect(0, 0, bounds.Dx(), bounds.Dy()))
+
+	for _, layer := range a.layers {
+		if direction >= len(layer.Directions) {
+			continue
+		}
+
+		dir := layer.Directions[direction]
+		if frame >= len(dir.Frames) {
+			continue
+		}
+
+		pixels := dir.Frames[frame].PixelData
+		offsetX, offsetY := dir.Box.Left-bounds.Min.X, dir.Box.Top-bounds.Min.Y
+
+		for y := 0; y < dir.Box.Height; y++ {
+			for x := 0; x < dir.Box.Width; x++ {
+				idx := x + (y * dir.Box.Width)
+				if idx >= len(pixels) || pixels[idx] == 0 {
+					continue
+				}
+
+				val := pixels[idx]
+				result.Set(offsetX+x, offsetY+y, color.RGBA{R: val, G: val, B: val, A: maxAlpha})
+			}
+		}
+	}
+
+	return result
+}
+
+func (p *widget) makePreviewTextures(preview *animationPreview, direction int32) {
+	preview.direction = direction
+	textures := make([]*giu.Texture, preview.frames)
+	preview.textures = textures
+
+	for frame := 0; frame < preview.frames; frame++ {
+		img := preview.composeFrame(int(direction), frame)
+
+		giu.EnqueueNewTextureFromRgba(img, func(t *giu.Texture) {
+			textures[frame] = t
+		})
+	}
+}
@@ -10,6 +10,8 @@ import (
 
 	"github.com/OpenDiablo2/OpenDiablo2/d2common/d2fileformats/d2animdata"
 
+	"github.com/gucio321/HellSpawner/pkg/common/hsanimdata"
+	"github.com/gucio321/HellSpawner/pkg/common/hsproject"
 	"github.com/gucio321/HellSpawner/pkg/widgets"
 )
 
@@ -21,15 +23,17 @@ const (
 )
 
 type widget struct {
-	id giu.ID
-	d2 *d2animdata.AnimationData
+	id      giu.ID
+	d2      *d2animdata.AnimationData
+	project *hsproject.Project
 }
 
 // Create creates a new widget
-func Create(state []byte, id string, d2 *d2animdata.AnimationData) giu.Widget {
+func Create(state []byte, id string, d2 *d2animdata.AnimationData, project *hsproject.Project) giu.Widget {
 	result := &widget{
-		id: giu.ID(id),
-		d2: d2,
+		id:      giu.ID(id),
+		d2:      d2,
+		project: project,
 	}
 
 	if state != nil && giu.Context.GetState(result.getStateID()) == nil {
@@ -53,6 +57,8 @@ func (p *widget) Build() {
 		p.buildAnimationsList()
 	case widgetModeViewRecord:
 		p.buildViewRecordLayout()
+	case widgetModeCheck:
+		p.buildCheckLayout()
 	}
 }
 
@@ -108,6 +114,11 @@ func (p *widget) buildAnimationsList() {
 					giu.Label("Nothing matches...").Build()
 				}),
 			}),
+		giu.Separator(),
+		giu.Button("Check against COF files").Size(actionBtnW, actionBtnH).OnClick(func() {
+			p.reloadCOFs()
+			state.Mode = widgetModeCheck
+		}),
 	}.Build()
 }
 
@@ -172,11 +183,19 @@ func (p *widget) buildViewRecordLayout() {
 		giu.Label(fmt.Sprintf("FPS: %v", record.FPS())),
 		giu.Label(fmt.Sprintf("Frame duration: %v (miliseconds)", record.FrameDurationMS())),
 		giu.Separator(),
+		p.makeTimelineLayout(record),
+		giu.Separator(),
+		p.makePreviewLayout(name),
+		giu.Separator(),
 		giu.Button("Back to entry preview").Size(actionBtnW, actionBtnH).OnClick(func() {
 			state.Mode = widgetModeList
 		}),
 		giu.Button("Add record").Size(actionBtnW, actionBtnH).OnClick(func() {
-			p.d2.PushRecord(name)
+			if _, err := hsanimdata.PushRecord(p.d2, name); err != nil {
+				log.Print(err)
+
+				return
+			}
 
 			// no -1, because current records hasn't new field yet
 			//nolint:gosec // its for giu and has to be int32.
@@ -235,12 +254,13 @@ func (p *widget) makeSearchLayout() giu.Layout {
 
 			giu.Row(
 				giu.Button("Add").Size(saveCancelButtonW, saveCancelButtonH).OnClick(func() {
-					err := p.d2.AddEntry(state.Name)
-					if err != nil {
+					// PushRecord creates the entry too
+					if _, err := hsanimdata.PushRecord(p.d2, state.Name); err != nil {
 						log.Print(err)
+
+						return
 					}
 
-					p.d2.PushRecord(state.Name)
 					p.reloadMapKeys()
 					p.viewRecord()
 				}),
@@ -0,0 +1,3 @@
+// Package colormapwidget provides a giu widget for viewing colormaps
+// (sequences of palette remap tables).
+package colormapwidget
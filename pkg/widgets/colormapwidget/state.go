@@ -0,0 +1,56 @@
+package colormapwidget
+
+import (
+	"fmt"
+
+	"github.com/AllenDang/giu"
+	"github.com/OpenDiablo2/OpenDiablo2/d2common/d2interface"
+)
+
+type widgetState struct {
+	Selected int32
+	// Clicked is an index of the last clicked color (-1 if none)
+	Clicked int32
+	grids   map[string]giu.Widget
+	// palette is a palette, which grids were created for
+	palette *[256]d2interface.Color
+	// generation changes with the palette, so that grids of the old palette aren't reused
+	generation int
+}
+
+// Dispose cleans viewer's state
+func (s *widgetState) Dispose() {
+	s.grids = make(map[string]giu.Widget)
+}
+
+func (p *widget) getStateID() giu.ID {
+	return giu.ID(fmt.Sprintf("widget_%s", p.id))
+}
+
+func (p *widget) getState() *widgetState {
+	var state *widgetState
+
+	s := giu.Context.GetState(p.getStateID())
+
+	if s != nil {
+		state = s.(*widgetState)
+	} else {
+		p.initState()
+		state = p.getState()
+	}
+
+	return state
+}
+
+func (p *widget) initState() {
+	state := &widgetState{
+		Clicked: -1,
+		grids:   make(map[string]giu.Widget),
+	}
+
+	p.setState(state)
+}
+
+func (p *widget) setState(s giu.Disposable) {
+	giu.Context.SetState(p.getStateID(), s)
+}
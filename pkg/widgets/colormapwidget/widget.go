@@ -0,0 +1,119 @@
+package colormapwidget
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+
+	"github.com/AllenDang/giu"
+
+	"github.com/OpenDiablo2/OpenDiablo2/d2common/d2interface"
+
+	"github.com/gucio321/HellSpawner/pkg/common/hsremap"
+	"github.com/gucio321/HellSpawner/pkg/widgets/palettegridwidget"
+)
+
+const (
+	numColors = 256
+	gridW     = 200
+	sliderW   = 400
+)
+
+type widget struct {
+	id      string
+	remaps  []*hsremap.Remap
+	palette *[numColors]d2interface.Color
+}
+
+// Create creates a new colormap viewer. Colors are shown using the palette given (nil means grayscale).
+func Create(state []byte, id string, remaps []*hsremap.Remap, palette *[numColors]d2interface.Color) giu.Widget {
+	result := &widget{
+		id:      id,
+		remaps:  remaps,
+		palette: palette,
+	}
+
+	if giu.Context.GetState(result.getStateID()) == nil && state != nil {
+		s := result.getState()
+		if err := json.Unmarshal(state, s); err != nil {
+			log.Printf("error decoding colormap viewer state: %v", err)
+		}
+	}
+
+	return result
+}
+
+// Build builds the widget
+func (p *widget) Build() {
+	state := p.getState()
+
+	if len(p.remaps) == 0 {
+		giu.Label("Colormap is empty").Build()
+
+		return
+	}
+
+	if state.palette != p.palette {
+		state.palette = p.palette
+		state.generation++
+		state.grids = make(map[string]giu.Widget)
+	}
+
+	//nolint:gosec // number of tables is small
+	if last := int32(len(p.remaps) - 1); state.Selected > last {
+		state.Selected = last
+	}
+
+	remap := p.remaps[state.Selected]
+
+	identity := &hsremap.Remap{}
+	for i := range identity.Indices {
+		identity.Indices[i] = byte(i)
+	}
+
+	clicked := "Click a color to see where it is remapped"
+	if state.Clicked >= 0 {
+		clicked = fmt.Sprintf("Color %d is remapped to %d", state.Clicked, remap.Indices[state.Clicked])
+	}
+
+	giu.Layout{
+		giu.Label(fmt.Sprintf("%d remap tables", len(p.remaps))),
+		giu.SliderInt(&state.Selected, 0, int32(len(p.remaps)-1)).Size(sliderW).Label("##" + p.id + "selectTable"), //nolint:gosec // see above
+		giu.Label(remap.Name),
+		giu.Row(
+			giu.Child().Size(gridW, gridW).Layout(
+				giu.Label("Palette"),
+				p.grid("palette", identity),
+			),
+			giu.Child().Size(gridW, gridW).Layout(
+				giu.Label("Remapped"),
+				p.grid(fmt.Sprintf("table%d", state.Selected), remap),
+			),
+		),
+		giu.Label(clicked),
+	}.Build()
+}
+
+// grid returns a palette grid of the palette remapped by the remap given
+func (p *widget) grid(key string, remap *hsremap.Remap) giu.Widget {
+	state := p.getState()
+
+	if grid, found := state.grids[key]; found {
+		return grid
+	}
+
+	remapped := remap.Apply(p.palette)
+
+	colors := make([]palettegridwidget.PaletteColor, numColors)
+	for i := range colors {
+		colors[i] = remapped[i]
+	}
+
+	grid := palettegridwidget.Create(fmt.Sprintf("%s%s_%d", p.id, key, state.generation), &colors).OnClick(func(idx int) {
+		state.Clicked = int32(idx) //nolint:gosec // palette index
+	})
+
+	state.grids[key] = grid
+
+	return grid
+}
@@ -9,6 +9,7 @@ import (
 	"github.com/AllenDang/giu"
 
 	"github.com/gucio321/HellSpawner/pkg/common"
+	"github.com/gucio321/HellSpawner/pkg/common/hsfiletypes"
 )
 
 // MakeImageButton is a hack for giu.ImageButton that creates image button
@@ -208,3 +209,21 @@ func OnDoubleClick(cb func()) giu.Widget {
 		}
 	})
 }
+
+// OpenAsMenu creates an "Open As" menu, which allows to open the file with an editor of any file type given,
+// regardless of what its content or extension looks like
+func OpenAsMenu(path *common.PathEntry, fileTypes []hsfiletypes.FileType,
+	cb func(path *common.PathEntry, fileType hsfiletypes.FileType),
+) giu.Widget {
+	if len(fileTypes) == 0 || cb == nil {
+		return giu.Layout{}
+	}
+
+	items := make(giu.Layout, len(fileTypes))
+
+	for idx, fileType := range fileTypes {
+		items[idx] = giu.MenuItem(fileType.String()).OnClick(func() { cb(path, fileType) })
+	}
+
+	return giu.Menu("Open As").Layout(items)
+}
@@ -0,0 +1,2 @@
+// Package d2swidget provides a read-only giu widget for inspecting character saves (.d2s)
+package d2swidget
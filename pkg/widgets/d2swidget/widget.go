@@ -0,0 +1,176 @@
+package d2swidget
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/AllenDang/giu"
+
+	"github.com/gucio321/HellSpawner/pkg/common/hsd2s"
+)
+
+// Names are names of skills (by ID) and items (by code) taken from project's excel tables.
+// Missing names are replaced with IDs/codes.
+type Names struct {
+	Skills map[int]string
+	Items  map[string]string
+}
+
+type widget struct {
+	id    string
+	save  *hsd2s.Save
+	names Names
+}
+
+// Create creates a new character save inspector
+func Create(id string, save *hsd2s.Save, names Names) giu.Widget {
+	return &widget{
+		id:    id,
+		save:  save,
+		names: names,
+	}
+}
+
+// Build builds the widget
+func (p *widget) Build() {
+	giu.TabBar().TabItems(
+		giu.TabItem("Character##"+p.id).Layout(p.characterLayout()),
+		giu.TabItem("Statistics##"+p.id).Layout(p.statsLayout()),
+		giu.TabItem("Skills##"+p.id).Layout(p.skillsLayout()),
+		giu.TabItem(fmt.Sprintf("Items (%d)##%s", len(p.save.Items), p.id)).Layout(p.itemsLayout()),
+	).Build()
+}
+
+// table creates a table of labels; the first row is a header
+func table(rows ...[]string) giu.Widget {
+	tableRows := make([]*giu.TableRowWidget, len(rows))
+
+	for i, row := range rows {
+		columns := make([]giu.Widget, len(row))
+		for j, value := range row {
+			columns[j] = giu.Label(value)
+		}
+
+		tableRows[i] = giu.TableRow(columns...)
+	}
+
+	return giu.Table().Freeze(0, 1).Rows(tableRows...)
+}
+
+func yesNo(b bool) string {
+	if b {
+		return "yes"
+	}
+
+	return "no"
+}
+
+func (p *widget) characterLayout() giu.Widget {
+	s := p.save
+
+	difficulties := []string{"Normal", "Nightmare", "Hell"}
+
+	checksum := "valid"
+	if !s.ChecksumValid {
+		checksum = "invalid (the game won't load the save)"
+	}
+
+	mercenary := "none"
+	if s.Mercenary.ID != 0 {
+		mercenary = fmt.Sprintf("type %d, name %d, experience %d, dead: %s",
+			s.Mercenary.Type, s.Mercenary.NameID, s.Mercenary.Experience, yesNo(s.Mercenary.Dead))
+	}
+
+	return table(
+		[]string{"Property", "Value"},
+		[]string{"Name", s.Name},
+		[]string{"Class", s.Class.String()},
+		[]string{"Level", strconv.Itoa(int(s.Level))},
+		[]string{"Hardcore", yesNo(s.Hardcore)},
+		[]string{"Died", yesNo(s.Died)},
+		[]string{"Expansion", yesNo(s.Expansion)},
+		[]string{"Ladder", yesNo(s.Ladder)},
+		[]string{"Acts completed", strconv.Itoa(int(s.Progression))},
+		[]string{"Last played", fmt.Sprintf("%s, act %d", difficulties[s.Difficulty], s.Act)},
+		[]string{"Last saved", s.LastPlayed.Format("2006-01-02 15:04:05")},
+		[]string{"Mercenary", mercenary},
+		[]string{"Version", strconv.Itoa(int(s.Version))},
+		[]string{"Checksum", checksum},
+	)
+}
+
+func (p *widget) statsLayout() giu.Widget {
+	rows := [][]string{{"Statistic", "Value"}}
+
+	for _, stat := range p.save.Stats {
+		rows = append(rows, []string{stat.Name(), strconv.FormatUint(uint64(stat.Value), 10)})
+	}
+
+	return table(rows...)
+}
+
+func (p *widget) skillsLayout() giu.Widget {
+	rows := [][]string{{"ID", "Skill", "Level"}}
+
+	for _, skill := range p.save.Skills {
+		name := p.names.Skills[skill.ID]
+		rows = append(rows, []string{strconv.Itoa(skill.ID), name, strconv.Itoa(int(skill.Level))})
+	}
+
+	return table(rows...)
+}
+
+func (p *widget) itemsLayout() giu.Widget {
+	rows := [][]string{{"Code", "Name", "Location", "Position", "Flags"}}
+
+	for _, item := range p.save.Items {
+		code, name := item.Code, p.names.Items[item.Code]
+		if item.Ear {
+			code, name = "", "ear"
+		}
+
+		location := item.Location.String()
+
+		switch item.Location {
+		case hsd2s.LocationStored:
+			location = item.Panel.String()
+		case hsd2s.LocationEquipped:
+			location = fmt.Sprintf("equipped (slot %d)", item.Slot)
+		}
+
+		rows = append(rows, []string{code, name, location, fmt.Sprintf("%d, %d", item.X, item.Y), itemFlags(&item)})
+	}
+
+	return giu.Layout{
+		giu.Label("Items following extended ones are found by searching for their markers (best effort), " +
+			"so that they may be missing or misread (flagged \"found by marker\").").Wrapped(true),
+		giu.Separator(),
+		table(rows...),
+	}
+}
+
+func itemFlags(item *hsd2s.Item) string {
+	flags := []struct {
+		set  bool
+		name string
+	}{
+		{item.Simple, "simple"},
+		{item.Identified, "identified"},
+		{item.Socketed, "socketed"},
+		{item.Ethereal, "ethereal"},
+		{item.Personalized, "personalized"},
+		{item.Runeword, "runeword"},
+		{item.Approximate, "found by marker"},
+	}
+
+	result := make([]string, 0, len(flags))
+
+	for _, f := range flags {
+		if f.set {
+			result = append(result, f.name)
+		}
+	}
+
+	return strings.Join(result, ", ")
+}
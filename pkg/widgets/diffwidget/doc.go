@@ -0,0 +1,3 @@
+// Package diffwidget provides a viewer of differences between two versions
+// of a file (text, tab separated table, string table or palette)
+package diffwidget
@@ -0,0 +1,31 @@
+package diffwidget
+
+import (
+	"fmt"
+
+	"github.com/AllenDang/giu"
+)
+
+type widgetState struct {
+	// AllLines is true, if all the lines (not only changed ones) should be shown
+	AllLines bool
+}
+
+func (s *widgetState) Dispose() {
+	s.AllLines = false
+}
+
+func (p *widget) getStateID() giu.ID {
+	return giu.ID(fmt.Sprintf("widget_%s", p.id))
+}
+
+func (p *widget) getState() *widgetState {
+	if s, ok := giu.Context.GetState(p.getStateID()).(*widgetState); ok {
+		return s
+	}
+
+	state := &widgetState{}
+	giu.Context.SetState(p.getStateID(), state)
+
+	return state
+}
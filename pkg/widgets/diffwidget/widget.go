@@ -0,0 +1,247 @@
+package diffwidget
+
+import (
+	"fmt"
+	"image"
+	"image/color"
+	"strconv"
+	"strings"
+
+	"github.com/AllenDang/giu"
+
+	"github.com/gucio321/HellSpawner/pkg/common/hsdiff"
+)
+
+const (
+	// contextLines is a number of unchanged lines shown around changed ones
+	contextLines = 3
+	numberW      = 50
+	statusW      = 80
+	swatchSize   = 16
+)
+
+type widget struct {
+	id           string
+	nameA, nameB string
+	diff         *hsdiff.FileDiff
+}
+
+// Create creates a new diff viewer. Names are names of compared versions (e.g. archive names)
+func Create(id, nameA, nameB string, diff *hsdiff.FileDiff) giu.Widget {
+	return &widget{
+		id:    id,
+		nameA: nameA,
+		nameB: nameB,
+		diff:  diff,
+	}
+}
+
+// StatusColor returns a background color of rows of the status given (nil for unchanged rows)
+func StatusColor(status hsdiff.Status) color.Color {
+	switch status {
+	case hsdiff.StatusAdded:
+		return color.RGBA{R: 0, G: 128, B: 0, A: 80}
+	case hsdiff.StatusRemoved:
+		return color.RGBA{R: 160, G: 0, B: 0, A: 80}
+	case hsdiff.StatusChanged:
+		return color.RGBA{R: 160, G: 160, B: 0, A: 80}
+	}
+
+	return nil
+}
+
+// Build builds the widget
+func (p *widget) Build() {
+	if p.diff.Identical {
+		giu.Label("Files are identical.").Build()
+
+		return
+	}
+
+	switch p.diff.Format {
+	case hsdiff.FormatText:
+		p.makeLinesLayout().Build()
+	case hsdiff.FormatTable:
+		p.makeTableLayout().Build()
+	case hsdiff.FormatStringTable:
+		p.makeKeysLayout().Build()
+	case hsdiff.FormatPalette:
+		p.makeColorsLayout().Build()
+	case hsdiff.FormatBinary:
+		giu.Label(fmt.Sprintf("Binary files differ (%d B -> %d B).", p.diff.SizeA, p.diff.SizeB)).Build()
+	}
+}
+
+func (p *widget) makeLinesLayout() giu.Layout {
+	state := p.getState()
+	changes := p.diff.Lines
+
+	visible := make([]bool, len(changes))
+
+	for i, c := range changes {
+		if state.AllLines {
+			visible[i] = true
+
+			continue
+		}
+
+		if c.Status == hsdiff.StatusUnchanged {
+			continue
+		}
+
+		for j := max(0, i-contextLines); j <= min(len(changes)-1, i+contextLines); j++ {
+			visible[j] = true
+		}
+	}
+
+	lineNumber := func(n int) string {
+		if n == 0 {
+			return ""
+		}
+
+		return strconv.Itoa(n)
+	}
+
+	rows := make([]*giu.TableRowWidget, 0)
+
+	for i, c := range changes {
+		if !visible[i] {
+			if i > 0 && visible[i-1] {
+				rows = append(rows, giu.TableRow(giu.Label("..."), giu.Label("..."), giu.Label("")))
+			}
+
+			continue
+		}
+
+		prefix := "  "
+
+		switch c.Status {
+		case hsdiff.StatusAdded:
+			prefix = "+ "
+		case hsdiff.StatusRemoved:
+			prefix = "- "
+		}
+
+		rows = append(rows, giu.TableRow(
+			giu.Label(lineNumber(c.LineA)),
+			giu.Label(lineNumber(c.LineB)),
+			giu.Label(prefix+strings.ReplaceAll(c.Text, "\t", "    ")),
+		).BgColor(StatusColor(c.Status)))
+	}
+
+	return giu.Layout{
+		giu.Checkbox("Show all lines##"+p.id, &state.AllLines),
+		giu.Table().FastMode(true).
+			Flags(giu.TableFlagsScrollX|giu.TableFlagsScrollY).
+			Columns(
+				giu.TableColumn(p.nameA).Flags(giu.TableColumnFlagsWidthFixed).InnerWidthOrWeight(numberW),
+				giu.TableColumn(p.nameB).Flags(giu.TableColumnFlagsWidthFixed).InnerWidthOrWeight(numberW),
+				giu.TableColumn("Line"),
+			).
+			Rows(rows...),
+	}
+}
+
+func (p *widget) makeTableLayout() giu.Layout {
+	diff := p.diff.Table
+	layout := giu.Layout{}
+
+	if len(diff.AddedColumns) > 0 {
+		layout = append(layout, giu.Label("Added columns: "+strings.Join(diff.AddedColumns, ", ")))
+	}
+
+	if len(diff.RemovedColumns) > 0 {
+		layout = append(layout, giu.Label("Removed columns: "+strings.Join(diff.RemovedColumns, ", ")))
+	}
+
+	if len(diff.Rows) == 0 {
+		return append(layout, giu.Label("Rows are the same."))
+	}
+
+	rows := make([]*giu.TableRowWidget, 0)
+
+	for _, row := range diff.Rows {
+		for i, cell := range row.Cells {
+			key, status := "", ""
+			if i == 0 {
+				key, status = row.Key, row.Status.String()
+			}
+
+			rows = append(rows, giu.TableRow(
+				giu.Label(key),
+				giu.Label(status),
+				giu.Label(cell.Column),
+				giu.Label(cell.Old),
+				giu.Label(cell.New),
+			).BgColor(StatusColor(row.Status)))
+		}
+	}
+
+	return append(layout, giu.Table().FastMode(true).
+		Columns(
+			giu.TableColumn("Row"),
+			giu.TableColumn("Status").Flags(giu.TableColumnFlagsWidthFixed).InnerWidthOrWeight(statusW),
+			giu.TableColumn("Column"),
+			giu.TableColumn(p.nameA),
+			giu.TableColumn(p.nameB),
+		).
+		Rows(rows...))
+}
+
+func (p *widget) makeKeysLayout() giu.Widget {
+	if len(p.diff.Keys) == 0 {
+		return giu.Label("String tables have the same keys and values.")
+	}
+
+	rows := make([]*giu.TableRowWidget, len(p.diff.Keys))
+
+	for i, c := range p.diff.Keys {
+		rows[i] = giu.TableRow(
+			giu.Label(c.Key),
+			giu.Label(c.Status.String()),
+			giu.Label(c.Old),
+			giu.Label(c.New),
+		).BgColor(StatusColor(c.Status))
+	}
+
+	return giu.Table().FastMode(true).
+		Columns(
+			giu.TableColumn("Key"),
+			giu.TableColumn("Status").Flags(giu.TableColumnFlagsWidthFixed).InnerWidthOrWeight(statusW),
+			giu.TableColumn(p.nameA),
+			giu.TableColumn(p.nameB),
+		).
+		Rows(rows...)
+}
+
+func (p *widget) makeColorsLayout() giu.Widget {
+	if len(p.diff.Colors) == 0 {
+		return giu.Label("Palettes have the same colors.")
+	}
+
+	rows := make([]*giu.TableRowWidget, len(p.diff.Colors))
+
+	for i, c := range p.diff.Colors {
+		rows[i] = giu.TableRow(
+			giu.Label(strconv.Itoa(c.Index)),
+			giu.Row(colorSwatch(c.Old), giu.Label(fmt.Sprintf("#%02x%02x%02x", c.Old.R, c.Old.G, c.Old.B))),
+			giu.Row(colorSwatch(c.New), giu.Label(fmt.Sprintf("#%02x%02x%02x", c.New.R, c.New.G, c.New.B))),
+		)
+	}
+
+	return giu.Table().FastMode(true).
+		Columns(
+			giu.TableColumn("Index").Flags(giu.TableColumnFlagsWidthFixed).InnerWidthOrWeight(numberW),
+			giu.TableColumn(p.nameA),
+			giu.TableColumn(p.nameB),
+		).
+		Rows(rows...)
+}
+
+func colorSwatch(c color.RGBA) giu.Widget {
+	return giu.Custom(func() {
+		pos := giu.GetCursorScreenPos()
+		giu.GetCanvas().AddRectFilled(pos, pos.Add(image.Pt(swatchSize, swatchSize)), color.RGBA{R: c.R, G: c.G, B: c.B, A: 255}, 0, 0)
+		giu.Dummy(swatchSize, swatchSize).Build()
+	})
+}
@@ -50,8 +50,15 @@ type widget struct {
 	palette *[256]d2interface.Color
 }
 
+// Viewer is a dt1 viewer widget, which tile group can be changed from outside (e.g. by shortcuts)
+type Viewer interface {
+	giu.Widget
+	TileGroup() int32
+	SetTileGroup(tileGroup int32)
+}
+
 // Create creates a new dt1 viewers widget
-func Create(state []byte, palette *[256]d2interface.Color, id string, dt1 *d2dt1.DT1) giu.Widget {
+func Create(state []byte, palette *[256]d2interface.Color, id string, dt1 *d2dt1.DT1) Viewer {
 	result := &widget{
 		id:      giu.ID(id),
 		dt1:     dt1,
@@ -565,9 +572,12 @@ func (p *widget) TileGroup() int32 {
 // SetTileGroup sets current tile group
 func (p *widget) SetTileGroup(tileGroup int32) {
 	state := p.getState()
-	if int(tileGroup) > len(state.tileGroups) {
-		tileGroup = int32(len(state.tileGroups))
-	} else if tileGroup < 0 {
+	if int(tileGroup) >= len(state.tileGroups) {
+		//nolint:gosec // number of tile groups is small
+		tileGroup = int32(len(state.tileGroups) - 1)
+	}
+
+	if tileGroup < 0 {
 		tileGroup = 0
 	}
 
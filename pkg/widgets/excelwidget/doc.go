@@ -0,0 +1,2 @@
+// Package excelwidget provides a giu widget for viewing compiled excel tables (.bin)
+package excelwidget
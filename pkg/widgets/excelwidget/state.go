@@ -0,0 +1,45 @@
+package excelwidget
+
+import (
+	"fmt"
+
+	"github.com/AllenDang/giu"
+)
+
+type widgetState struct {
+	rows []*giu.TableRowWidget
+}
+
+// Dispose cleans viewer's state
+func (s *widgetState) Dispose() {
+	s.rows = nil
+}
+
+func (p *widget) getStateID() giu.ID {
+	return giu.ID(fmt.Sprintf("widget_%s", p.id))
+}
+
+func (p *widget) getState() *widgetState {
+	var state *widgetState
+
+	s := giu.Context.GetState(p.getStateID())
+
+	if s != nil {
+		state = s.(*widgetState)
+	} else {
+		p.initState()
+		state = p.getState()
+	}
+
+	return state
+}
+
+func (p *widget) initState() {
+	state := &widgetState{rows: p.makeRows()}
+
+	p.setState(state)
+}
+
+func (p *widget) setState(s giu.Disposable) {
+	giu.Context.SetState(p.getStateID(), s)
+}
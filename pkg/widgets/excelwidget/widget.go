@@ -0,0 +1,85 @@
+package excelwidget
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/AllenDang/giu"
+
+	"github.com/gucio321/HellSpawner/pkg/common/hsexcel"
+)
+
+// maxColumns is a number of table's columns supported by imgui (without the column of indices)
+const maxColumns = 511
+
+type widget struct {
+	id    string
+	table *hsexcel.Table
+}
+
+// Create creates a new viewer of the compiled excel table
+func Create(id string, table *hsexcel.Table) giu.Widget {
+	return &widget{
+		id:    id,
+		table: table,
+	}
+}
+
+// Build builds the widget
+func (p *widget) Build() {
+	state := p.getState()
+
+	layout := "unknown layout; values are shown as raw numbers at their offsets"
+	if p.table.Layout != nil {
+		layout = "known layout"
+	}
+
+	info := fmt.Sprintf("%d records, %d bytes each (%s)", len(p.table.Records), p.table.RecordSize, layout)
+	if len(p.table.Header()) > maxColumns {
+		info += fmt.Sprintf("; only %d first columns are shown", maxColumns)
+	}
+
+	if len(p.table.Records) == 0 {
+		giu.Label(info).Build()
+
+		return
+	}
+
+	giu.Layout{
+		giu.Label(info),
+		giu.Separator(),
+		giu.Child().Border(false).Layout(
+			giu.Table().FastMode(true).Freeze(1, 1).Rows(state.rows...),
+		),
+	}.Build()
+}
+
+// makeRows creates table's rows; the first one is a header and the first column is record's index
+func (p *widget) makeRows() []*giu.TableRowWidget {
+	header := p.table.Header()
+	header = header[:min(len(header), maxColumns)]
+	rows := make([]*giu.TableRowWidget, len(p.table.Records)+1)
+
+	columns := make([]giu.Widget, len(header)+1)
+	columns[0] = giu.Label("#")
+
+	for i, name := range header {
+		columns[i+1] = giu.Label(name)
+	}
+
+	rows[0] = giu.TableRow(columns...)
+
+	for record := range p.table.Records {
+		values := p.table.Row(record)[:len(header)]
+		columns := make([]giu.Widget, len(values)+1)
+		columns[0] = giu.Label(strconv.Itoa(record))
+
+		for i, value := range values {
+			columns[i+1] = giu.Label(value)
+		}
+
+		rows[record+1] = giu.TableRow(columns...)
+	}
+
+	return rows
+}
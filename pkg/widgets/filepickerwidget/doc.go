@@ -0,0 +1,3 @@
+// Package filepickerwidget contains a picker of project's and MPQs' files
+// used in palette and remap select widgets
+package filepickerwidget
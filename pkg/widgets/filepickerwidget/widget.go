@@ -0,0 +1,64 @@
+package filepickerwidget
+
+import (
+	"fmt"
+
+	"github.com/AllenDang/giu"
+
+	"github.com/gucio321/HellSpawner/pkg/app/config"
+	"github.com/gucio321/HellSpawner/pkg/common"
+	"github.com/gucio321/HellSpawner/pkg/common/hsproject"
+	"github.com/gucio321/HellSpawner/pkg/window/toolwindow/mpqexplorer"
+	"github.com/gucio321/HellSpawner/pkg/window/toolwindow/projectexplorer"
+)
+
+var _ giu.Widget = &FilePicker{}
+
+// FilePicker lists project's files and files of game's MPQs. Selected file is passed to the callback
+type FilePicker struct {
+	mpqExplorer     *mpqexplorer.MPQExplorer
+	projectExplorer *projectexplorer.ProjectExplorer
+	err             error
+}
+
+// New creates a new file picker. If explorers cannot be created,
+// the picker displays the error instead of the files
+func New(project *hsproject.Project, cfg *config.Config, callback func(path *common.PathEntry)) *FilePicker {
+	result := &FilePicker{}
+
+	mpqExplorer, err := mpqexplorer.Create(callback, cfg, 0, 0)
+	if err != nil {
+		result.err = fmt.Errorf("error creating MPQ explorer: %w", err)
+
+		return result
+	}
+
+	projectExplorer, err := projectexplorer.Create(callback, nil, 0, 0)
+	if err != nil {
+		result.err = fmt.Errorf("error creating project explorer: %w", err)
+
+		return result
+	}
+
+	mpqExplorer.SetProject(project)
+	projectExplorer.SetProject(project)
+
+	result.mpqExplorer = mpqExplorer
+	result.projectExplorer = projectExplorer
+
+	return result
+}
+
+// Build builds the picker
+func (p *FilePicker) Build() {
+	if p.err != nil {
+		giu.Label(p.err.Error()).Wrapped(true).Build()
+
+		return
+	}
+
+	giu.Layout{
+		p.projectExplorer.GetProjectTreeNodes(),
+		giu.Layout(p.mpqExplorer.GetMpqTreeNodes()),
+	}.Build()
+}
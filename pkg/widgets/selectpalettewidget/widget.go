@@ -1,21 +1,17 @@
 package selectpalettewidget
 
 import (
-	"github.com/gucio321/HellSpawner/pkg/app/config"
-	"github.com/gucio321/HellSpawner/pkg/window/popup"
 	"log"
 	"path/filepath"
 
 	"github.com/AllenDang/giu"
 
-	"github.com/OpenDiablo2/OpenDiablo2/d2common/d2fileformats/d2dat"
-	"github.com/OpenDiablo2/OpenDiablo2/d2common/d2interface"
-
+	"github.com/gucio321/HellSpawner/pkg/app/config"
 	"github.com/gucio321/HellSpawner/pkg/common"
 	"github.com/gucio321/HellSpawner/pkg/common/hsfiletypes"
 	"github.com/gucio321/HellSpawner/pkg/common/hsproject"
-	"github.com/gucio321/HellSpawner/pkg/window/toolwindow/mpqexplorer"
-	"github.com/gucio321/HellSpawner/pkg/window/toolwindow/projectexplorer"
+	"github.com/gucio321/HellSpawner/pkg/widgets/filepickerwidget"
+	"github.com/gucio321/HellSpawner/pkg/window/popup"
 )
 
 const (
@@ -26,11 +22,10 @@ const (
 // SelectPaletteWidget represents an pop-up MPQ explorer, when we're
 // selectin DAT palette
 type SelectPaletteWidget struct {
-	mpqExplorer     *mpqexplorer.MPQExplorer
-	projectExplorer *projectexplorer.ProjectExplorer
-	id              string
-	saveCB          func(colors *[256]d2interface.Color)
-	closeCB         func()
+	picker  *filepickerwidget.FilePicker
+	id      string
+	saveCB  func(path *common.PathEntry)
+	closeCB func()
 }
 
 // NewSelectPaletteWidget creates a select palette widget
@@ -38,7 +33,7 @@ func NewSelectPaletteWidget(
 	id string,
 	project *hsproject.Project,
 	cfg *config.Config,
-	saveCB func(colors *[256]d2interface.Color),
+	saveCB func(path *common.PathEntry),
 	closeCB func(),
 ) *SelectPaletteWidget {
 	result := &SelectPaletteWidget{
@@ -63,41 +58,12 @@ func NewSelectPaletteWidget(
 		}
 
 		if ft == hsfiletypes.FileTypePalette {
-			// load new palette:
-			paletteData, err := path.GetFileBytes()
-			if err != nil {
-				log.Print(err)
-			}
-
-			palette, err := d2dat.Load(paletteData)
-			if err != nil {
-				log.Print(err)
-			}
-
-			colors := palette.GetColors()
-
-			saveCB(&colors)
+			saveCB(path)
 			closeCB()
 		}
 	}
 
-	mpqExplorer, err := mpqexplorer.Create(callback, cfg, 0, 0)
-	if err != nil {
-		log.Print(err)
-	}
-
-	mpqExplorer.SetProject(project)
-
-	result.mpqExplorer = mpqExplorer
-
-	projectExplorer, err := projectexplorer.Create(callback, 0, 0)
-	if err != nil {
-		log.Print(err)
-	}
-
-	projectExplorer.SetProject(project)
-
-	result.projectExplorer = projectExplorer
+	result.picker = filepickerwidget.New(project, cfg, callback)
 
 	return result
 }
@@ -109,8 +75,7 @@ func (p *SelectPaletteWidget) Build() {
 	giu.Layout{
 		popup.New("##" + p.id + "popUpSelectPalette").IsOpen(&isOpen).Layout(giu.Layout{
 			giu.Child().Size(paletteSelectW, paletteSelectH).Layout(giu.Layout{
-				p.projectExplorer.GetProjectTreeNodes(),
-				giu.Layout(p.mpqExplorer.GetMpqTreeNodes()),
+				p.picker,
 				giu.Separator(),
 				giu.Button("Don't use any palette##"+p.id+"selectPaletteDonotUseAny").
 					Size(actionButtonW, actionButtonH).
@@ -0,0 +1,3 @@
+// Package selectremapwidget contains palette remap select widget
+// used in dcc, dc6 and dt1 editors
+package selectremapwidget
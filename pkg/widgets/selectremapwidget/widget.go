@@ -0,0 +1,180 @@
+package selectremapwidget
+
+import (
+	"log"
+	"path/filepath"
+
+	"github.com/AllenDang/giu"
+
+	"github.com/OpenDiablo2/OpenDiablo2/d2common/d2fileformats/d2pl2"
+
+	"github.com/gucio321/HellSpawner/pkg/app/config"
+	"github.com/gucio321/HellSpawner/pkg/common"
+	"github.com/gucio321/HellSpawner/pkg/common/hsfiletypes"
+	"github.com/gucio321/HellSpawner/pkg/common/hsproject"
+	"github.com/gucio321/HellSpawner/pkg/common/hsremap"
+	"github.com/gucio321/HellSpawner/pkg/widgets/filepickerwidget"
+	"github.com/gucio321/HellSpawner/pkg/window/popup"
+)
+
+const (
+	remapSelectW, remapSelectH   = 400, 600
+	actionButtonW, actionButtonH = 200, 30
+	colorsTxtPath                = `data\global\excel\colors.txt`
+)
+
+// SelectRemapWidget represents a pop-up, which allows to select a PL2 transform or
+// a colormap applied to the image before palettizing it
+type SelectRemapWidget struct {
+	picker  *filepickerwidget.FilePicker
+	project *hsproject.Project
+	id      string
+	remaps  []*hsremap.Remap
+	source  string
+	saveCB  func(remap *hsremap.Remap)
+	closeCB func()
+}
+
+// NewSelectRemapWidget creates a select remap widget
+func NewSelectRemapWidget(
+	id string,
+	project *hsproject.Project,
+	cfg *config.Config,
+	saveCB func(remap *hsremap.Remap),
+	closeCB func(),
+) *SelectRemapWidget {
+	result := &SelectRemapWidget{
+		id:      id,
+		project: project,
+		saveCB:  saveCB,
+		closeCB: closeCB,
+	}
+
+	result.picker = filepickerwidget.New(project, cfg, result.onFileSelected)
+
+	return result
+}
+
+func (p *SelectRemapWidget) onFileSelected(path *common.PathEntry) {
+	data, err := path.GetFileBytes()
+	if err != nil {
+		log.Print(err)
+
+		return
+	}
+
+	ft, err := hsfiletypes.GetFileTypeFromExtension(filepath.Ext(path.FullPath), &data)
+	if err != nil {
+		log.Print(err)
+
+		return
+	}
+
+	var remaps []*hsremap.Remap
+
+	switch ft {
+	case hsfiletypes.FileTypePL2:
+		pl2, err := d2pl2.Load(data)
+		if err != nil {
+			log.Print(err)
+
+			return
+		}
+
+		remaps = hsremap.FromPL2(pl2)
+	case hsfiletypes.FileTypeColormap:
+		remaps, err = hsremap.FromColormap(data, p.colorNames())
+		if err != nil {
+			log.Print(err)
+
+			return
+		}
+	default:
+		return
+	}
+
+	p.remaps = remaps
+	p.source = path.Name
+}
+
+// colorNames returns names of item colormaps from project's colors.txt
+func (p *SelectRemapWidget) colorNames() []string {
+	if p.project == nil {
+		return nil
+	}
+
+	entry := p.project.GetFileFromGamePath(colorsTxtPath)
+	if entry == nil {
+		return nil
+	}
+
+	data, err := p.project.ReadFile(entry)
+	if err != nil {
+		log.Print(err)
+
+		return nil
+	}
+
+	return hsremap.ColorNames(data)
+}
+
+// Build builds a widget
+func (p *SelectRemapWidget) Build() {
+	// always true (we don't use this feature in this case
+	isOpen := true
+	giu.Layout{
+		popup.New("##" + p.id + "popUpSelectRemap").IsOpen(&isOpen).Layout(giu.Layout{
+			giu.Child().Size(remapSelectW, remapSelectH).Layout(giu.Layout{
+				giu.Custom(func() {
+					if p.remaps != nil {
+						p.makeRemapsList().Build()
+
+						return
+					}
+
+					giu.Layout{
+						giu.Label("Select a palette transform (.pl2) or a colormap (.dat):"),
+						p.picker,
+					}.Build()
+				}),
+				giu.Separator(),
+				giu.Button("Don't use any remap##"+p.id+"selectRemapDonotUseAny").
+					Size(actionButtonW, actionButtonH).
+					OnClick(func() {
+						p.saveCB(nil)
+						p.closeCB()
+					}),
+				giu.Button("Exit##"+p.id+"selectRemapExit").
+					Size(actionButtonW, actionButtonH).
+					OnClick(func() {
+						p.closeCB()
+					}),
+			}),
+		}),
+	}.Build()
+
+	if !isOpen {
+		p.closeCB()
+	}
+}
+
+func (p *SelectRemapWidget) makeRemapsList() giu.Layout {
+	result := giu.Layout{
+		giu.Label("Remaps from " + p.source + ":"),
+		giu.Button("Select another file##"+p.id+"selectRemapBack").
+			Size(actionButtonW, actionButtonH).
+			OnClick(func() {
+				p.remaps = nil
+			}),
+		giu.Separator(),
+	}
+
+	for _, remap := range p.remaps {
+		result = append(result, giu.Selectable(remap.Name+"##"+p.id+"selectRemap").OnClick(func() {
+			p.saveCB(remap)
+			p.closeCB()
+		}))
+	}
+
+	return result
+}
@@ -0,0 +1,9 @@
+package window
+
+import "github.com/gucio321/HellSpawner/pkg/common/hscommand"
+
+// CommandProvider is implemented by windows, which contribute their own commands to the command palette
+type CommandProvider interface {
+	// Commands returns commands available in the window's current state
+	Commands() []hscommand.Command
+}
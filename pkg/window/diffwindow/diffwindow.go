@@ -0,0 +1,51 @@
+// Package diffwindow contains a window showing differences between two versions of a file
+package diffwindow
+
+import (
+	g "github.com/AllenDang/giu"
+
+	"github.com/gucio321/HellSpawner/pkg/common/hsdiff"
+	"github.com/gucio321/HellSpawner/pkg/widgets/diffwidget"
+	"github.com/gucio321/HellSpawner/pkg/window"
+)
+
+const mainWindowW, mainWindowH = 700, 400
+
+var _ window.Renderable = (*DiffWindow)(nil)
+
+// DiffWindow represents a diff window
+type DiffWindow struct {
+	*window.Window
+	id   string
+	diff g.Widget
+}
+
+// Create creates a new diff window. ID identifies compared file (e.g. its path);
+// names are names of compared versions
+func Create(title, id, nameA, nameB string, diff *hsdiff.FileDiff, x, y float32) *DiffWindow {
+	result := &DiffWindow{
+		Window: window.New(title+"##DiffWindow"+id, x, y),
+		id:     id,
+		diff:   diffwidget.Create("DiffWindow"+id, nameA, nameB, diff),
+	}
+
+	result.Size(mainWindowW, mainWindowH)
+	result.Show()
+
+	return result
+}
+
+// GetID returns an identifier of the compared file
+func (w *DiffWindow) GetID() string {
+	return w.id
+}
+
+// Build builds the window
+func (w *DiffWindow) Build() {
+	w.IsOpen(&w.Visible).Layout(w.GetLayout())
+}
+
+// GetLayout returns window's layout
+func (w *DiffWindow) GetLayout() g.Widget {
+	return w.diff
+}
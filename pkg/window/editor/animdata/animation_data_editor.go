@@ -57,13 +57,13 @@ func (e *AnimationDataEditor) Build() {
 
 func (e *AnimationDataEditor) GetLayout() g.Widget {
 	uid := e.Path.GetUniqueID()
-	return animdatawidget.Create(e.state, uid, e.d2)
+	return animdatawidget.Create(e.state, uid, e.d2, e.Project)
 }
 
 // UpdateMainMenuLayout updates a main menu layout, to it contains anim data viewer's settings
 func (e *AnimationDataEditor) UpdateMainMenuLayout(l *g.Layout) {
 	m := g.Menu("Animation Data Editor").Layout(g.Layout{
-		g.MenuItem("Save\t\t\t\tCtrl+Shift+S").OnClick(e.Save),
+		e.SaveMenuItem(e.Save),
 		g.Separator(),
 		g.MenuItem("Add to project").OnClick(func() {}),
 		g.MenuItem("Remove from project").OnClick(func() {}),
@@ -0,0 +1,162 @@
+// Package colormap contains colormap viewer's data
+package colormap
+
+import (
+	"fmt"
+	"log"
+
+	g "github.com/AllenDang/giu"
+
+	"github.com/OpenDiablo2/OpenDiablo2/d2common/d2interface"
+
+	"github.com/gucio321/HellSpawner/pkg/app/config"
+	"github.com/gucio321/HellSpawner/pkg/common"
+	"github.com/gucio321/HellSpawner/pkg/common/hscommand"
+	"github.com/gucio321/HellSpawner/pkg/common/hsproject"
+	"github.com/gucio321/HellSpawner/pkg/common/hsremap"
+	"github.com/gucio321/HellSpawner/pkg/widgets/colormapwidget"
+	"github.com/gucio321/HellSpawner/pkg/widgets/selectpalettewidget"
+	"github.com/gucio321/HellSpawner/pkg/window/editor"
+)
+
+const colorsTxtPath = `data\global\excel\colors.txt`
+
+// static check, to ensure, if colormap viewer implemented editoWindow
+var _ editor.PaletteEditor = &Editor{}
+
+// Editor represents a colormap viewer
+type Editor struct {
+	*editor.EditorBase
+	config              *config.Config
+	remaps              []*hsremap.Remap
+	palette             *[256]d2interface.Color
+	palettePath         *common.PathEntry
+	selectPalette       bool
+	selectPaletteWidget g.Widget
+	state               []byte
+}
+
+// Create creates a new colormap viewer
+func Create(cfg *config.Config,
+	pathEntry *common.PathEntry,
+	state []byte,
+	data *[]byte, x, y float32, project *hsproject.Project,
+) (editor.Editor, error) {
+	remaps, err := hsremap.FromColormap(*data, colorNames(project))
+	if err != nil {
+		return nil, fmt.Errorf("error loading colormap: %w", err)
+	}
+
+	result := &Editor{
+		EditorBase: editor.New(pathEntry, x, y, project),
+		config:     cfg,
+		remaps:     remaps,
+		state:      state,
+	}
+
+	return result, nil
+}
+
+// colorNames returns names of item colormaps from project's colors.txt
+func colorNames(project *hsproject.Project) []string {
+	if project == nil {
+		return nil
+	}
+
+	entry := project.GetFileFromGamePath(colorsTxtPath)
+	if entry == nil {
+		return nil
+	}
+
+	data, err := project.ReadFile(entry)
+	if err != nil {
+		log.Print(err)
+
+		return nil
+	}
+
+	return hsremap.ColorNames(data)
+}
+
+// Build builds a colormap viewer
+func (e *Editor) Build() {
+	e.IsOpen(&e.Visible).
+		Flags(g.WindowFlagsAlwaysAutoResize).
+		Layout(e.GetLayout())
+}
+
+func (e *Editor) GetLayout() g.Widget {
+	if !e.selectPalette {
+		return colormapwidget.Create(e.state, e.Path.GetUniqueID(), e.remaps, e.palette)
+	}
+
+	if e.selectPaletteWidget == nil {
+		e.selectPaletteWidget = selectpalettewidget.NewSelectPaletteWidget(
+			e.Path.GetUniqueID()+"selectPalette",
+			e.Project,
+			e.config,
+			e.SetPalette,
+			func() {
+				e.selectPalette = false
+			},
+		)
+	}
+
+	return e.selectPaletteWidget
+}
+
+// Palette returns a palette file used by the viewer (nil if none)
+func (e *Editor) Palette() *common.PathEntry {
+	return e.palettePath
+}
+
+// SetPalette loads a palette from the file given and uses it in the viewer.
+// Nil path means no palette (grayscale).
+func (e *Editor) SetPalette(path *common.PathEntry) {
+	e.palettePath, e.palette = nil, nil
+
+	if path == nil {
+		return
+	}
+
+	colors, err := e.Project.LoadPalette(path)
+	if err != nil {
+		log.Print(err)
+
+		return
+	}
+
+	e.palettePath, e.palette = path, colors
+}
+
+// UpdateMainMenuLayout updates a main menu layout to it contain colormap viewer's options
+func (e *Editor) UpdateMainMenuLayout(l *g.Layout) {
+	m := g.Menu("Colormap Viewer").Layout(g.Layout{
+		g.MenuItem("Change Palette").OnClick(func() {
+			e.selectPalette = true
+		}),
+		g.Separator(),
+		g.MenuItem("Close").OnClick(func() {
+			e.Cleanup()
+		}),
+	})
+
+	*l = append(*l, m)
+}
+
+// Commands returns viewer's commands for the command palette
+func (e *Editor) Commands() []hscommand.Command {
+	return []hscommand.Command{
+		{Category: "Colormap Viewer", Name: "Change Palette", Run: func() { e.selectPalette = true }},
+	}
+}
+
+// GenerateSaveData returns nil; colormaps are read-only
+func (e *Editor) GenerateSaveData() []byte {
+	return nil
+}
+
+// Save does nothing; colormaps are read-only
+func (e *Editor) Save() {
+	e.EditorBase.Save(e)
+}
@@ -0,0 +1,145 @@
+// Package d2s contains character save inspector's data
+package d2s
+
+import (
+	"fmt"
+	"log"
+
+	g "github.com/AllenDang/giu"
+
+	"github.com/OpenDiablo2/OpenDiablo2/d2common/d2fileformats/d2txt"
+
+	"github.com/gucio321/HellSpawner/pkg/app/config"
+	"github.com/gucio321/HellSpawner/pkg/common"
+	"github.com/gucio321/HellSpawner/pkg/common/hsd2s"
+	"github.com/gucio321/HellSpawner/pkg/common/hsproject"
+	"github.com/gucio321/HellSpawner/pkg/widgets/d2swidget"
+	"github.com/gucio321/HellSpawner/pkg/window/editor"
+)
+
+const (
+	skillsTxtPath = `data\global\excel\skills.txt`
+)
+
+// itemTxtPaths are tables containing names of items
+var itemTxtPaths = []string{
+	`data\global\excel\armor.txt`,
+	`data\global\excel\weapons.txt`,
+	`data\global\excel\misc.txt`,
+}
+
+// static check, to ensure, if save inspector implemented editoWindow
+var _ editor.Editor = &Editor{}
+
+// Editor represents a character save inspector
+type Editor struct {
+	*editor.EditorBase
+	save  *hsd2s.Save
+	names d2swidget.Names
+}
+
+// Create creates a new character save inspector
+func Create(_ *config.Config,
+	pathEntry *common.PathEntry,
+	_ []byte,
+	data *[]byte, x, y float32, project *hsproject.Project,
+) (editor.Editor, error) {
+	save, err := hsd2s.Load(*data)
+	if err != nil {
+		return nil, fmt.Errorf("error loading character save: %w", err)
+	}
+
+	result := &Editor{
+		EditorBase: editor.New(pathEntry, x, y, project),
+		save:       save,
+		names: d2swidget.Names{
+			Skills: make(map[int]string),
+			Items:  make(map[string]string),
+		},
+	}
+
+	if !save.ChecksumValid {
+		log.Printf("%s: checksum of the save is invalid", pathEntry.Name)
+	}
+
+	result.loadNames()
+
+	return result, nil
+}
+
+// loadNames reads names of skills and items from project's excel tables (if present)
+func (e *Editor) loadNames() {
+	if dict := e.loadTable(skillsTxtPath); dict != nil {
+		// skill's ID is its row in skills.txt
+		for id := 0; dict.Next(); id++ {
+			e.names.Skills[id] = dict.String("skill")
+		}
+	}
+
+	for _, path := range itemTxtPaths {
+		dict := e.loadTable(path)
+		if dict == nil {
+			continue
+		}
+
+		for dict.Next() {
+			e.names.Items[dict.String("code")] = dict.String("name")
+		}
+	}
+}
+
+func (e *Editor) loadTable(gamePath string) *d2txt.DataDictionary {
+	if e.Project == nil {
+		return nil
+	}
+
+	entry := e.Project.GetFileFromGamePath(gamePath)
+	if entry == nil {
+		return nil
+	}
+
+	data, err := e.Project.ReadFile(entry)
+	if err != nil {
+		log.Print(err)
+
+		return nil
+	}
+
+	if len(data) == 0 {
+		return nil
+	}
+
+	return d2txt.LoadDataDictionary(data)
+}
+
+// Build builds a save inspector
+func (e *Editor) Build() {
+	e.IsOpen(&e.Visible).
+		Flags(g.WindowFlagsAlwaysAutoResize).
+		Layout(e.GetLayout())
+}
+
+func (e *Editor) GetLayout() g.Widget {
+	return d2swidget.Create(e.Path.GetUniqueID(), e.save, e.names)
+}
+
+// UpdateMainMenuLayout updates a main menu layout to it contain save inspector's options
+func (e *Editor) UpdateMainMenuLayout(l *g.Layout) {
+	m := g.Menu("Save Inspector").Layout(g.Layout{
+		g.MenuItem("Close").OnClick(func() {
+			e.Cleanup()
+		}),
+	})
+
+	*l = append(*l, m)
+}
+
+// GenerateSaveData returns nil; character saves are read-only
+func (e *Editor) GenerateSaveData() []byte {
+	return nil
+}
+
+// Save does nothing; character saves are read-only
+func (e *Editor) Save() {
+	e.EditorBase.Save(e)
+}
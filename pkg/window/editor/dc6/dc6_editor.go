@@ -3,6 +3,7 @@ package dc6
 
 import (
 	"fmt"
+	"log"
 
 	"github.com/gucio321/HellSpawner/pkg/app/config"
 
@@ -13,14 +14,17 @@ import (
 	"github.com/OpenDiablo2/OpenDiablo2/d2common/d2interface"
 
 	"github.com/gucio321/HellSpawner/pkg/common"
+	"github.com/gucio321/HellSpawner/pkg/common/hscommand"
 	"github.com/gucio321/HellSpawner/pkg/common/hsproject"
+	"github.com/gucio321/HellSpawner/pkg/common/hsremap"
 	"github.com/gucio321/HellSpawner/pkg/widgets/dc6widget"
 	"github.com/gucio321/HellSpawner/pkg/widgets/selectpalettewidget"
+	"github.com/gucio321/HellSpawner/pkg/widgets/selectremapwidget"
 	"github.com/gucio321/HellSpawner/pkg/window/editor"
 )
 
 // static check, to ensure, if dc6 editor implemented editoWindow
-var _ editor.Editor = &Editor{}
+var _ editor.PaletteEditor = &Editor{}
 
 // Editor represents a dc6 editor
 type Editor struct {
@@ -29,7 +33,12 @@ type Editor struct {
 	config              *config.Config
 	selectPalette       bool
 	palette             *[256]d2interface.Color
+	palettePath         *common.PathEntry
 	selectPaletteWidget g.Widget
+	selectRemap         bool
+	remap               *hsremap.Remap
+	selectRemapWidget   g.Widget
+	remappedPalette     *[256]d2interface.Color
 	state               []byte
 }
 
@@ -63,8 +72,27 @@ func (e *Editor) Build() {
 }
 
 func (e *Editor) GetLayout() g.Widget {
+	if e.selectRemap {
+		if e.selectRemapWidget == nil {
+			e.selectRemapWidget = selectremapwidget.NewSelectRemapWidget(
+				e.Path.GetUniqueID()+"selectRemap",
+				e.Project,
+				e.config,
+				func(remap *hsremap.Remap) {
+					e.remap = remap
+					e.remappedPalette = e.remap.Apply(e.palette)
+				},
+				func() {
+					e.selectRemap = false
+				},
+			)
+		}
+
+		return e.selectRemapWidget
+	}
+
 	if !e.selectPalette {
-		return dc6widget.Create(e.state, e.palette, e.Path.GetUniqueID(), e.dc6)
+		return dc6widget.Create(e.state, e.remappedPalette, e.Path.GetUniqueID(), e.dc6)
 	}
 
 	if e.selectPaletteWidget == nil {
@@ -72,9 +100,7 @@ func (e *Editor) GetLayout() g.Widget {
 			e.Path.GetUniqueID()+"selectPalette",
 			e.Project,
 			e.config,
-			func(palette *[256]d2interface.Color) {
-				e.palette = palette
-			},
+			e.SetPalette,
 			func() {
 				e.selectPalette = false
 			},
@@ -84,14 +110,39 @@ func (e *Editor) GetLayout() g.Widget {
 	return e.selectPaletteWidget
 }
 
+// Palette returns a palette file used by the editor (nil if none)
+func (e *Editor) Palette() *common.PathEntry {
+	return e.palettePath
+}
+
+// SetPalette loads a palette from the file given and uses it in the editor.
+// Nil path means no palette (grayscale).
+func (e *Editor) SetPalette(path *common.PathEntry) {
+	e.palettePath, e.palette = nil, nil
+
+	if path != nil {
+		colors, err := e.Project.LoadPalette(path)
+		if err != nil {
+			log.Print(err)
+		} else {
+			e.palettePath, e.palette = path, colors
+		}
+	}
+
+	e.remappedPalette = e.remap.Apply(e.palette)
+}
+
 // UpdateMainMenuLayout updates main menu to it contain DC6's editor menu
 func (e *Editor) UpdateMainMenuLayout(l *g.Layout) {
 	m := g.Menu("DC6 Editor").Layout(g.Layout{
 		g.MenuItem("Change Palette").OnClick(func() {
 			e.selectPalette = true
 		}),
+		g.MenuItem("Change Remap").OnClick(func() {
+			e.selectRemap = true
+		}),
 		g.Separator(),
-		g.MenuItem("Save\t\t\t\tCtrl+Shift+S").OnClick(e.Save),
+		e.SaveMenuItem(e.Save),
 		g.Separator(),
 		g.MenuItem("Add to project").OnClick(func() {}),
 		g.MenuItem("Remove from project").OnClick(func() {}),
@@ -107,6 +158,14 @@ func (e *Editor) UpdateMainMenuLayout(l *g.Layout) {
 	*l = append(*l, m)
 }
 
+// Commands returns editor's commands for the command palette
+func (e *Editor) Commands() []hscommand.Command {
+	return []hscommand.Command{
+		{Category: "DC6 Editor", Name: "Change Palette", Run: func() { e.selectPalette = true }},
+		{Category: "DC6 Editor", Name: "Change Remap", Run: func() { e.selectRemap = true }},
+	}
+}
+
 // GenerateSaveData generates save data
 func (e *Editor) GenerateSaveData() []byte {
 	data := e.dc6.Marshal()
@@ -3,6 +3,7 @@ package dcc
 
 import (
 	"fmt"
+	"log"
 
 	"github.com/gucio321/HellSpawner/pkg/app/config"
 
@@ -14,14 +15,17 @@ import (
 	"github.com/OpenDiablo2/OpenDiablo2/d2common/d2interface"
 
 	"github.com/gucio321/HellSpawner/pkg/common"
+	"github.com/gucio321/HellSpawner/pkg/common/hscommand"
 	"github.com/gucio321/HellSpawner/pkg/common/hsproject"
+	"github.com/gucio321/HellSpawner/pkg/common/hsremap"
 	"github.com/gucio321/HellSpawner/pkg/widgets/dccwidget"
 	"github.com/gucio321/HellSpawner/pkg/widgets/selectpalettewidget"
+	"github.com/gucio321/HellSpawner/pkg/widgets/selectremapwidget"
 	"github.com/gucio321/HellSpawner/pkg/window/editor"
 )
 
 // static check, to ensure, if dc6 editor implemented editoWindow
-var _ editor.Editor = &Editor{}
+var _ editor.PaletteEditor = &Editor{}
 
 // Editor represents a new dcc editor
 type Editor struct {
@@ -30,7 +34,12 @@ type Editor struct {
 	config              *config.Config
 	selectPalette       bool
 	palette             *[256]d2interface.Color
+	palettePath         *common.PathEntry
 	selectPaletteWidget g.Widget
+	selectRemap         bool
+	remap               *hsremap.Remap
+	selectRemapWidget   g.Widget
+	remappedPalette     *[256]d2interface.Color
 	state               []byte
 }
 
@@ -64,9 +73,28 @@ func (e *Editor) Build() {
 }
 
 func (e *Editor) GetLayout() g.Widget {
+	if e.selectRemap {
+		if e.selectRemapWidget == nil {
+			e.selectRemapWidget = selectremapwidget.NewSelectRemapWidget(
+				e.Path.GetUniqueID()+"selectRemap",
+				e.Project,
+				e.config,
+				func(remap *hsremap.Remap) {
+					e.remap = remap
+					e.remappedPalette = e.remap.Apply(e.palette)
+				},
+				func() {
+					e.selectRemap = false
+				},
+			)
+		}
+
+		return g.Layout{e.selectRemapWidget}
+	}
+
 	if !e.selectPalette {
 		return g.Layout{
-			dccwidget.Create(e.state, e.palette, e.Path.GetUniqueID(), e.dcc),
+			dccwidget.Create(e.state, e.remappedPalette, e.Path.GetUniqueID(), e.dcc),
 		}
 	}
 
@@ -75,9 +103,7 @@ func (e *Editor) GetLayout() g.Widget {
 			"##"+e.Path.GetUniqueID()+"SelectPaletteWidget",
 			e.Project,
 			e.config,
-			func(colors *[256]d2interface.Color) {
-				e.palette = colors
-			},
+			e.SetPalette,
 			func() {
 				e.selectPalette = false
 			},
@@ -87,12 +113,37 @@ func (e *Editor) GetLayout() g.Widget {
 	return g.Layout{e.selectPaletteWidget}
 }
 
+// Palette returns a palette file used by the editor (nil if none)
+func (e *Editor) Palette() *common.PathEntry {
+	return e.palettePath
+}
+
+// SetPalette loads a palette from the file given and uses it in the editor.
+// Nil path means no palette (grayscale).
+func (e *Editor) SetPalette(path *common.PathEntry) {
+	e.palettePath, e.palette = nil, nil
+
+	if path != nil {
+		colors, err := e.Project.LoadPalette(path)
+		if err != nil {
+			log.Print(err)
+		} else {
+			e.palettePath, e.palette = path, colors
+		}
+	}
+
+	e.remappedPalette = e.remap.Apply(e.palette)
+}
+
 // UpdateMainMenuLayout updates main menu to it contain editor's options
 func (e *Editor) UpdateMainMenuLayout(l *g.Layout) {
 	m := g.Menu("DCC Editor").Layout(g.Layout{
 		g.MenuItem("Change Palette").OnClick(func() {
 			e.selectPalette = true
 		}),
+		g.MenuItem("Change Remap").OnClick(func() {
+			e.selectRemap = true
+		}),
 		g.Separator(),
 		g.MenuItem("Add to project").OnClick(func() {}),
 		g.MenuItem("Remove from project").OnClick(func() {}),
@@ -108,6 +159,14 @@ func (e *Editor) UpdateMainMenuLayout(l *g.Layout) {
 	*l = append(*l, m)
 }
 
+// Commands returns editor's commands for the command palette
+func (e *Editor) Commands() []hscommand.Command {
+	return []hscommand.Command{
+		{Category: "DCC Editor", Name: "Change Palette", Run: func() { e.selectPalette = true }},
+		{Category: "DCC Editor", Name: "Change Remap", Run: func() { e.selectRemap = true }},
+	}
+}
+
 // GenerateSaveData generates data to save
 func (e *Editor) GenerateSaveData() []byte {
 	// https://github.com/gucio321/HellSpawner/issues/181
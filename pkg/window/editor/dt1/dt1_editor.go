@@ -3,6 +3,7 @@ package dt1
 
 import (
 	"fmt"
+	"log"
 
 	"github.com/gucio321/HellSpawner/pkg/app/config"
 
@@ -14,14 +15,21 @@ import (
 	"github.com/OpenDiablo2/OpenDiablo2/d2common/d2interface"
 
 	"github.com/gucio321/HellSpawner/pkg/common"
+	"github.com/gucio321/HellSpawner/pkg/common/hscommand"
 	"github.com/gucio321/HellSpawner/pkg/common/hsproject"
+	"github.com/gucio321/HellSpawner/pkg/common/hsremap"
+	"github.com/gucio321/HellSpawner/pkg/common/hsshortcut"
 	"github.com/gucio321/HellSpawner/pkg/widgets/dt1widget"
 	"github.com/gucio321/HellSpawner/pkg/widgets/selectpalettewidget"
+	"github.com/gucio321/HellSpawner/pkg/widgets/selectremapwidget"
 	"github.com/gucio321/HellSpawner/pkg/window/editor"
 )
 
 // static check, to ensure, if dt1 editor implemented editoWindow
-var _ editor.Editor = &Editor{}
+var (
+	_ editor.PaletteEditor    = &Editor{}
+	_ editor.ShortcutProvider = &Editor{}
+)
 
 // Editor represents a dt1 editor
 type Editor struct {
@@ -30,7 +38,12 @@ type Editor struct {
 	config              *config.Config
 	selectPalette       bool
 	palette             *[256]d2interface.Color
+	palettePath         *common.PathEntry
 	selectPaletteWidget g.Widget
+	selectRemap         bool
+	remap               *hsremap.Remap
+	selectRemapWidget   g.Widget
+	remappedPalette     *[256]d2interface.Color
 	state               []byte
 }
 
@@ -64,14 +77,27 @@ func (e *Editor) Build() {
 }
 
 func (e *Editor) GetLayout() g.Widget {
-	if !e.selectPalette {
-		return g.Layout{
-			dt1widget.Create(
-				e.state, e.palette,
-				e.Path.GetUniqueID(),
-				e.dt1,
-			),
+	if e.selectRemap {
+		if e.selectRemapWidget == nil {
+			e.selectRemapWidget = selectremapwidget.NewSelectRemapWidget(
+				e.Path.GetUniqueID()+"selectRemap",
+				e.Project,
+				e.config,
+				func(remap *hsremap.Remap) {
+					e.remap = remap
+					e.remappedPalette = e.remap.Apply(e.palette)
+				},
+				func() {
+					e.selectRemap = false
+				},
+			)
 		}
+
+		return g.Layout{e.selectRemapWidget}
+	}
+
+	if !e.selectPalette {
+		return g.Layout{e.viewer()}
 	}
 
 	// create mpq explorer if doesn't exist for now
@@ -80,9 +106,7 @@ func (e *Editor) GetLayout() g.Widget {
 			e.Path.GetUniqueID(),
 			e.Project,
 			e.config,
-			func(colors *[256]d2interface.Color) {
-				e.palette = colors
-			},
+			e.SetPalette,
 			func() {
 				e.selectPalette = false
 			},
@@ -92,14 +116,39 @@ func (e *Editor) GetLayout() g.Widget {
 	return g.Layout{e.selectPaletteWidget}
 }
 
+// Palette returns a palette file used by the editor (nil if none)
+func (e *Editor) Palette() *common.PathEntry {
+	return e.palettePath
+}
+
+// SetPalette loads a palette from the file given and uses it in the editor.
+// Nil path means no palette (grayscale).
+func (e *Editor) SetPalette(path *common.PathEntry) {
+	e.palettePath, e.palette = nil, nil
+
+	if path != nil {
+		colors, err := e.Project.LoadPalette(path)
+		if err != nil {
+			log.Print(err)
+		} else {
+			e.palettePath, e.palette = path, colors
+		}
+	}
+
+	e.remappedPalette = e.remap.Apply(e.palette)
+}
+
 // UpdateMainMenuLayout updates main menu layout to it contains editors options
 func (e *Editor) UpdateMainMenuLayout(l *g.Layout) {
 	m := g.Menu("DT1 Editor").Layout(g.Layout{
 		g.MenuItem("Change Palette").OnClick(func() {
 			e.selectPalette = true
 		}),
+		g.MenuItem("Change Remap").OnClick(func() {
+			e.selectRemap = true
+		}),
 		g.Separator(),
-		g.MenuItem("Save\t\t\t\tCtrl+Shift+S").OnClick(e.Save),
+		e.SaveMenuItem(e.Save),
 		g.Separator(),
 		g.MenuItem("Add to project").OnClick(func() {}),
 		g.MenuItem("Remove from project").OnClick(func() {}),
@@ -115,29 +164,51 @@ func (e *Editor) UpdateMainMenuLayout(l *g.Layout) {
 	*l = append(*l, m)
 }
 
-// KeyboardShortcuts register a new keyboard shortcut
-func (e *Editor) KeyboardShortcuts() []g.WindowShortcut {
-	// https://github.com/gucio321/HellSpawner/issues/329
-	return []g.WindowShortcut{
-		/*
-			// right arrow goes to the next tile group
-			giu.WindowShortcut{
-				Callback: func() {
-					e.dt1Viewer.SetTileGroup(e.dt1Viewer.TileGroup() + 1)
-				},
-				Key:      g.KeyRight,
-				Modifier: g.ModNone,
-			},
+// IDs of editor's actions
+const (
+	actionNextTileGroup     = "dt1.nextTileGroup"
+	actionPreviousTileGroup = "dt1.previousTileGroup"
+)
 
-			// left arrow goes to the previous tile group
-			giu.WindowShortcut{
-				Callback: func() {
-					e.dt1Viewer.SetTileGroup(e.dt1Viewer.TileGroup() - 1)
-				},
-				Key:      g.KeyLeft,
-				Modifier: g.ModNone,
-			},
-		*/
+// ShortcutActions returns actions of DT1 editor's keyboard shortcuts
+func ShortcutActions() []hsshortcut.Action {
+	// plain arrows are used by imgui's navigation (https://github.com/gucio321/HellSpawner/issues/329)
+	return []hsshortcut.Action{
+		{ID: actionNextTileGroup, Name: "DT1 Editor: Next Tile Group", Default: "Alt+Right"},
+		{ID: actionPreviousTileGroup, Name: "DT1 Editor: Previous Tile Group", Default: "Alt+Left"},
+	}
+}
+
+// ShortcutCallbacks returns functions run by editor's shortcuts
+func (e *Editor) ShortcutCallbacks() map[string]func() {
+	return map[string]func(){
+		actionNextTileGroup:     func() { e.changeTileGroup(1) },
+		actionPreviousTileGroup: func() { e.changeTileGroup(-1) },
+	}
+}
+
+func (e *Editor) changeTileGroup(delta int32) {
+	if e.selectPalette || e.selectRemap {
+		return
+	}
+
+	viewer := e.viewer()
+	viewer.SetTileGroup(viewer.TileGroup() + delta)
+}
+
+func (e *Editor) viewer() dt1widget.Viewer {
+	return dt1widget.Create(
+		e.state, e.remappedPalette,
+		e.Path.GetUniqueID(),
+		e.dt1,
+	)
+}
+
+// Commands returns editor's commands for the command palette
+func (e *Editor) Commands() []hscommand.Command {
+	return []hscommand.Command{
+		{Category: "DT1 Editor", Name: "Change Palette", Run: func() { e.selectPalette = true }},
+		{Category: "DT1 Editor", Name: "Change Remap", Run: func() { e.selectRemap = true }},
 	}
 }
 
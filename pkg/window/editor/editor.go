@@ -10,6 +10,7 @@ import (
 	"github.com/AllenDang/giu"
 
 	"github.com/gucio321/HellSpawner/pkg/common/hsproject"
+	"github.com/gucio321/HellSpawner/pkg/common/hsshortcut"
 
 	"github.com/gucio321/HellSpawner/pkg/common"
 	"github.com/gucio321/HellSpawner/pkg/window"
@@ -30,12 +31,20 @@ type Editor interface {
 	SetVisible(bool)
 	// GetID returns a unique identifier for this editor window
 	GetID() string
+	// GetPath returns the file opened in the editor
+	GetPath() *common.PathEntry
+	// SetPath changes the file opened in the editor (e.g. after the file was moved)
+	SetPath(path *common.PathEntry)
 	// BringToFront brings this editor to the front of the application, giving it focus
 	BringToFront()
 	// State returns the current state of this editor, in a JSON-serializable struct
 	State() state.EditorState
 	// Save writes any changes made in the editor to the file that is open in the editor.
 	Save()
+	// OnSave sets a callback called after the file is written by Save
+	OnSave(cb func(path *common.PathEntry))
+	// SetShortcuts sets the registry of keyboard shortcuts shown in editor's menu
+	SetShortcuts(shortcuts *hsshortcut.Registry)
 
 	Size(float32, float32) *giu.WindowWidget
 }
@@ -43,8 +52,10 @@ type Editor interface {
 // EditorBase represents an editor
 type EditorBase struct {
 	*window.Window
-	Path    *common.PathEntry
-	Project *hsproject.Project
+	Path      *common.PathEntry
+	Project   *hsproject.Project
+	onSave    func(path *common.PathEntry)
+	shortcuts *hsshortcut.Registry
 }
 
 // New creates a new editor
@@ -82,6 +93,23 @@ func (e *EditorBase) GetID() string {
 	return e.Path.GetUniqueID()
 }
 
+// GetPath returns the file opened in the editor
+func (e *EditorBase) GetPath() *common.PathEntry {
+	return e.Path
+}
+
+// SetPath changes the file opened in the editor. Window and widget's state are kept.
+func (e *EditorBase) SetPath(path *common.PathEntry) {
+	oldStateID := e.widgetStateID()
+
+	e.Path = path
+	e.SetTitle(generateWindowTitle(path))
+
+	if s, ok := giu.Context.GetState(oldStateID).(giu.Disposable); ok {
+		giu.Context.SetState(e.widgetStateID(), s)
+	}
+}
+
 // Save saves an editor
 func (e *EditorBase) Save(editor Saveable) {
 	if e.Path.Source != common.PathEntrySourceProject {
@@ -110,6 +138,15 @@ func (e *EditorBase) Save(editor Saveable) {
 		fmt.Println("failed to save file: ", err)
 		return
 	}
+
+	if e.onSave != nil {
+		e.onSave(e.Path)
+	}
+}
+
+// OnSave sets a callback called after the file is written by Save
+func (e *EditorBase) OnSave(cb func(path *common.PathEntry)) {
+	e.onSave = cb
 }
 
 // HasChanges returns true if editor has changed data
@@ -140,11 +177,14 @@ func generateWindowTitle(path *common.PathEntry) string {
 	return path.Name + "##" + path.GetUniqueID()
 }
 
+// widgetStateID returns ID of editor's main widget state
+func (e *EditorBase) widgetStateID() giu.ID {
+	return giu.ID(fmt.Sprintf("widget_%s", e.Path.GetUniqueID()))
+}
+
 // EncodeState returns widget's state (unique for each editor type) in byte slice format
 func (e *EditorBase) EncodeState() []byte {
-	id := giu.ID(fmt.Sprintf("widget_%s", e.Path.GetUniqueID()))
-
-	if s := giu.Context.GetState(id); s != nil {
+	if s := giu.Context.GetState(e.widgetStateID()); s != nil {
 		data, err := json.Marshal(s)
 		if err != nil {
 			log.Printf("error encoding state of editor at path %v: %v", e.Path, err)
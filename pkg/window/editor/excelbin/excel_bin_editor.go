@@ -0,0 +1,118 @@
+// Package excelbin contains compiled excel table viewer's data
+package excelbin
+
+import (
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+
+	g "github.com/AllenDang/giu"
+
+	"github.com/OpenDiablo2/dialog"
+
+	"github.com/gucio321/HellSpawner/pkg/app/config"
+	"github.com/gucio321/HellSpawner/pkg/common"
+	"github.com/gucio321/HellSpawner/pkg/common/hscommand"
+	"github.com/gucio321/HellSpawner/pkg/common/hsexcel"
+	"github.com/gucio321/HellSpawner/pkg/common/hsproject"
+	"github.com/gucio321/HellSpawner/pkg/widgets/excelwidget"
+	"github.com/gucio321/HellSpawner/pkg/window/editor"
+)
+
+// static check, to ensure, if excel viewer implemented editoWindow
+var _ editor.Editor = &Editor{}
+
+// Editor represents a compiled excel table viewer
+type Editor struct {
+	*editor.EditorBase
+	table *hsexcel.Table
+}
+
+// Create creates a new compiled excel table viewer
+func Create(_ *config.Config,
+	pathEntry *common.PathEntry,
+	_ []byte,
+	data *[]byte, x, y float32, project *hsproject.Project,
+) (editor.Editor, error) {
+	table, err := hsexcel.Load(pathEntry.Name, *data)
+	if err != nil {
+		return nil, fmt.Errorf("error loading excel table: %w", err)
+	}
+
+	result := &Editor{
+		EditorBase: editor.New(pathEntry, x, y, project),
+		table:      table,
+	}
+
+	return result, nil
+}
+
+// Build builds an excel viewer
+func (e *Editor) Build() {
+	e.IsOpen(&e.Visible).
+		Flags(g.WindowFlagsAlwaysAutoResize).
+		Layout(e.GetLayout())
+}
+
+func (e *Editor) GetLayout() g.Widget {
+	return excelwidget.Create(e.Path.GetUniqueID(), e.table)
+}
+
+// convertToText saves the table as a tab-separated .txt file chosen by user
+func (e *Editor) convertToText() {
+	text, err := e.table.ToText()
+	if err != nil {
+		dialog.Message("Cannot convert %s: %v", e.Path.Name, err).Error()
+
+		return
+	}
+
+	path := dialog.File().SetStartDir(e.Project.GetProjectFileContentPath())
+	path.Title("Save " + strings.TrimSuffix(e.Path.Name, filepath.Ext(e.Path.Name)) + ".txt")
+	path.Filter("Text file", "txt", "TXT")
+
+	filePath, err := path.Save()
+	if err != nil || filePath == "" {
+		return
+	}
+
+	if err := os.WriteFile(filePath, text, 0o644); err != nil { //nolint:gosec // text file should be readable
+		log.Printf("error writing %s: %v", filePath, err)
+	}
+}
+
+// UpdateMainMenuLayout updates a main menu layout to it contain excel viewer's options
+func (e *Editor) UpdateMainMenuLayout(l *g.Layout) {
+	m := g.Menu("Excel Viewer").Layout(g.Layout{
+		g.MenuItem("Convert to .txt...").Enabled(e.table.Layout != nil).OnClick(e.convertToText),
+		g.Separator(),
+		g.MenuItem("Close").OnClick(func() {
+			e.Cleanup()
+		}),
+	})
+
+	*l = append(*l, m)
+}
+
+// Commands returns viewer's commands for the command palette
+func (e *Editor) Commands() []hscommand.Command {
+	if e.table.Layout == nil {
+		return nil
+	}
+
+	return []hscommand.Command{
+		{Category: "Excel Viewer", Name: "Convert to .txt", Run: e.convertToText},
+	}
+}
+
+// GenerateSaveData returns nil; compiled tables are read-only
+func (e *Editor) GenerateSaveData() []byte {
+	return nil
+}
+
+// Save does nothing; compiled tables are read-only
+func (e *Editor) Save() {
+	e.EditorBase.Save(e)
+}
@@ -0,0 +1,182 @@
+// Package external contains an editor of files supported by external plugins.
+// Plugin converts the file to text, which is edited and converted back on save.
+package external
+
+import (
+	"bytes"
+	"errors"
+	"image/color"
+	"log"
+
+	g "github.com/AllenDang/giu"
+	"github.com/OpenDiablo2/dialog"
+
+	"github.com/gucio321/HellSpawner/pkg/app/config"
+	"github.com/gucio321/HellSpawner/pkg/common"
+	"github.com/gucio321/HellSpawner/pkg/common/hsproject"
+	"github.com/gucio321/HellSpawner/pkg/window/editor"
+)
+
+const (
+	mainWindowW, mainWindowH = 400, 300
+)
+
+var colorError = color.RGBA{R: 255, G: 80, B: 80, A: 255}
+
+// static check, to ensure, if external editor implemented editoWindow
+var _ editor.Editor = &Editor{}
+
+// Codec converts file's data to text and back
+type Codec interface {
+	Decode(data []byte) (string, error)
+	Encode(text string) ([]byte, error)
+}
+
+// Editor represents an editor of a file supported by an external plugin
+type Editor struct {
+	*editor.EditorBase
+
+	codec Codec
+	text  string
+
+	// encodedText and encoded cache the last conversion, as the plugin runs in another process
+	encodedText string
+	encoded     []byte
+	// failedText is the last text, which plugin couldn't encode
+	failedText string
+	err        error
+	// savedText is the text of the file on disk (as it was loaded or last saved)
+	savedText string
+}
+
+// New returns a constructor of editors using the codec given
+func New(codec Codec) func(*config.Config, *common.PathEntry, []byte, *[]byte, float32, float32, *hsproject.Project) (editor.Editor, error) {
+	return func(_ *config.Config,
+		pathEntry *common.PathEntry,
+		_ []byte,
+		data *[]byte, x, y float32, project *hsproject.Project,
+	) (editor.Editor, error) {
+		text, err := codec.Decode(*data)
+		if err != nil {
+			return nil, err
+		}
+
+		result := &Editor{
+			EditorBase:  editor.New(pathEntry, x, y, project),
+			codec:       codec,
+			text:        text,
+			encodedText: text,
+			encoded:     *data,
+			savedText:   text,
+		}
+
+		if w, h := result.CurrentSize(); w == 0 || h == 0 {
+			result.Size(mainWindowW, mainWindowH)
+		}
+
+		return result, nil
+	}
+}
+
+// Build builds an editor
+func (e *Editor) Build() {
+	e.IsOpen(&e.Visible).Layout(e.GetLayout())
+}
+
+// GetLayout returns editor's layout
+func (e *Editor) GetLayout() g.Widget {
+	return g.Layout{
+		g.Custom(func() {
+			if e.err == nil {
+				return
+			}
+
+			g.Style().SetColor(g.StyleColorText, colorError).To(g.Label(e.err.Error())).Build()
+		}),
+		g.InputTextMultiline(&e.text).Size(-1, -1).Flags(g.InputTextFlagsAllowTabInput),
+	}
+}
+
+// UpdateMainMenuLayout updates mainMenu layout to it contains editor's options
+func (e *Editor) UpdateMainMenuLayout(l *g.Layout) {
+	m := g.Menu("Plugin Editor").Layout(g.Layout{
+		g.MenuItem("Save").OnClick(e.Save),
+		g.Separator(),
+		g.MenuItem("Close").OnClick(func() {
+			e.Cleanup()
+		}),
+	})
+
+	*l = append(*l, m)
+}
+
+// GenerateSaveData generates data to be saved; nil if plugin couldn't encode the text
+func (e *Editor) GenerateSaveData() []byte {
+	switch {
+	case e.text == e.encodedText:
+		return e.encoded
+	case e.err != nil && e.text == e.failedText:
+		return nil
+	}
+
+	data, err := e.codec.Encode(e.text)
+
+	e.err = err
+	if err != nil {
+		log.Print(err)
+
+		e.failedText = e.text
+
+		return nil
+	}
+
+	e.encodedText, e.encoded = e.text, data
+
+	return data
+}
+
+// Save saves an editor
+func (e *Editor) Save() {
+	e.EditorBase.Save(e)
+
+	if e.err != nil || e.text != e.encodedText {
+		return
+	}
+
+	if data, err := e.Path.GetFileBytes(); err == nil && bytes.Equal(data, e.encoded) {
+		e.savedText = e.text
+	}
+}
+
+// HasChanges returns true if the text was edited since it was loaded or saved.
+// Unlike EditorBase.HasChanges, it doesn't ask the plugin to encode the text.
+func (e *Editor) HasChanges(_ editor.Saveable) bool {
+	return e.Path.Source == common.PathEntrySourceProject && e.text != e.savedText
+}
+
+// Cleanup hides an editor. If changes can't be saved, editor stays open.
+func (e *Editor) Cleanup() {
+	if e.HasChanges(e) {
+		if shouldSave := dialog.Message("There are unsaved changes to %s, save before closing this editor?",
+			e.Path.FullPath).YesNo(); shouldSave {
+			e.Save()
+
+			if e.HasChanges(e) {
+				dialog.Message("Could not save %s: %v", e.Path.FullPath, e.saveError()).Error()
+				e.SetVisible(true)
+
+				return
+			}
+		}
+	}
+
+	e.EditorBase.Cleanup()
+}
+
+func (e *Editor) saveError() error {
+	if e.err != nil {
+		return e.err
+	}
+
+	return errors.New("file wasn't written (see the console)")
+}
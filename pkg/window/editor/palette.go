@@ -0,0 +1,15 @@
+package editor
+
+import (
+	"github.com/gucio321/HellSpawner/pkg/common"
+)
+
+// PaletteEditor represents an editor, which displays images using a palette
+type PaletteEditor interface {
+	Editor
+	// Palette returns a palette file used by the editor (nil if none)
+	Palette() *common.PathEntry
+	// SetPalette changes editor's palette. Nil means no palette (grayscale).
+	// The palette is loaded from project's MPQs, so that it has to be called on the UI thread.
+	SetPalette(path *common.PathEntry)
+}
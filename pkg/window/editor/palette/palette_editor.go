@@ -72,7 +72,7 @@ func (e *Editor) GetLayout() g.Widget {
 // UpdateMainMenuLayout updates a main menu layout to it contain palette editor's options
 func (e *Editor) UpdateMainMenuLayout(l *g.Layout) {
 	m := g.Menu("Palette Editor").Layout(g.Layout{
-		g.MenuItem("Save\t\t\t\tCtrl+Shift+S").OnClick(e.Save),
+		e.SaveMenuItem(e.Save),
 		g.Separator(),
 		g.MenuItem("Add to project").OnClick(func() {}),
 		g.MenuItem("Remove from project").OnClick(func() {}),
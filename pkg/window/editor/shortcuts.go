@@ -0,0 +1,38 @@
+package editor
+
+import (
+	"github.com/AllenDang/giu"
+
+	"github.com/gucio321/HellSpawner/pkg/common/hsshortcut"
+)
+
+// ActionSave is an ID of the action, which saves the focused editor
+const ActionSave = "file.save"
+
+// ShortcutProvider represents an editor with its own keyboard shortcuts.
+// Actions of these shortcuts have to be listed in the shortcut registry
+// (editor's package should export them by ShortcutActions function).
+type ShortcutProvider interface {
+	Editor
+	// ShortcutCallbacks returns functions run by editor's actions (by actions' IDs)
+	ShortcutCallbacks() map[string]func()
+}
+
+// SetShortcuts sets the registry used to label editor's menu items with shortcuts
+func (e *EditorBase) SetShortcuts(shortcuts *hsshortcut.Registry) {
+	e.shortcuts = shortcuts
+}
+
+// ShortcutLabel returns a label of the shortcut of the action given (empty if there is none)
+func (e *EditorBase) ShortcutLabel(actionID string) string {
+	if e.shortcuts == nil {
+		return ""
+	}
+
+	return e.shortcuts.Label(actionID)
+}
+
+// SaveMenuItem returns "Save" item of editor's menu, labeled with the shortcut of save action
+func (e *EditorBase) SaveMenuItem(save func()) *giu.MenuItemWidget {
+	return giu.MenuItem("Save").Shortcut(e.ShortcutLabel(ActionSave)).OnClick(save)
+}
@@ -114,7 +114,7 @@ func (e *Editor) GetLayout() g.Widget {
 // UpdateMainMenuLayout updates mainMenu layout to it contains editor's options
 func (e *Editor) UpdateMainMenuLayout(l *g.Layout) {
 	m := g.Menu("Text Editor").Layout(g.Layout{
-		g.MenuItem("Save\t\t\t\tCtrl+Shift+S").OnClick(e.Save),
+		e.SaveMenuItem(e.Save),
 		g.Separator(),
 		g.MenuItem("Add to project").OnClick(func() {}),
 		g.MenuItem("Remove from project").OnClick(func() {}),
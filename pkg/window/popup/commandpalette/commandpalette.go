@@ -0,0 +1,149 @@
+// Package commandpalette contains a command palette - a dialog, which allows to
+// search for and run application's commands.
+package commandpalette
+
+import (
+	"strconv"
+
+	"github.com/AllenDang/cimgui-go/imgui"
+	g "github.com/AllenDang/giu"
+
+	"github.com/gucio321/HellSpawner/pkg/common/hscommand"
+	"github.com/gucio321/HellSpawner/pkg/window"
+	"github.com/gucio321/HellSpawner/pkg/window/popup"
+)
+
+const (
+	// maxResults limits number of commands shown at once
+	maxResults         = 100
+	mainWindowW        = 500
+	listH              = 300
+	shortcutColumnW    = 100
+	selectedScrollRate = 0.5
+)
+
+var _ window.Renderable = &CommandPalette{}
+
+// CommandPalette represents a command palette
+type CommandPalette struct {
+	*popup.Dialog
+
+	commands []hscommand.Command
+	query    string
+	results  []hscommand.Command
+	selected int
+	// focusInput is set when the palette is opened, to let user type immediately
+	focusInput bool
+	// scrollToSelected is set when the selection was moved with keyboard
+	scrollToSelected bool
+}
+
+// Create creates a new command palette
+func Create() *CommandPalette {
+	result := &CommandPalette{
+		Dialog: popup.New("Command Palette"),
+	}
+
+	result.Visible = false
+
+	return result
+}
+
+// Show opens the palette with the commands given
+func (p *CommandPalette) Show(commands []hscommand.Command) {
+	p.Dialog.Show()
+
+	p.commands = commands
+	p.query = ""
+	p.focusInput = true
+	p.search()
+}
+
+// Build builds the palette
+func (p *CommandPalette) Build() {
+	p.IsOpen(&p.Visible).Layout(p.GetLayout()).Build()
+}
+
+// GetLayout returns palette's layout
+func (p *CommandPalette) GetLayout() g.Widget {
+	return g.Layout{
+		g.Custom(func() {
+			if p.focusInput {
+				imgui.SetKeyboardFocusHere()
+
+				p.focusInput = false
+			}
+		}),
+		g.InputText(&p.query).Hint("Type a command or a file name").Size(mainWindowW).OnChange(p.search),
+		g.Custom(p.handleKeys),
+		g.Child().Size(mainWindowW, listH).Layout(p.makeResultsLayout()),
+	}
+}
+
+func (p *CommandPalette) search() {
+	p.results = hscommand.Search(p.commands, p.query, maxResults)
+	p.selected = 0
+}
+
+func (p *CommandPalette) handleKeys() {
+	switch {
+	case g.IsKeyPressed(g.KeyDown) && p.selected < len(p.results)-1:
+		p.selected++
+		p.scrollToSelected = true
+	case g.IsKeyPressed(g.KeyUp) && p.selected > 0:
+		p.selected--
+		p.scrollToSelected = true
+	case g.IsKeyPressed(g.KeyEnter):
+		p.run(p.selected)
+	}
+}
+
+func (p *CommandPalette) makeResultsLayout() g.Widget {
+	if len(p.results) == 0 {
+		return g.Label("No matching commands.")
+	}
+
+	rows := make([]*g.TableRowWidget, len(p.results))
+
+	for idx := range p.results {
+		command := &p.results[idx]
+
+		rows[idx] = g.TableRow(
+			g.Layout{
+				g.Selectable(command.Title() + "##CommandPalette" + strconv.Itoa(idx)).
+					Selected(idx == p.selected).
+					Flags(g.SelectableFlagsSpanAllColumns).
+					OnClick(func() { p.run(idx) }),
+				g.Custom(func() {
+					if idx == p.selected && p.scrollToSelected {
+						imgui.SetScrollHereYV(selectedScrollRate)
+
+						p.scrollToSelected = false
+					}
+				}),
+			},
+			g.Label(command.Shortcut),
+		)
+	}
+
+	return g.Table().
+		Columns(
+			g.TableColumn("Command"),
+			g.TableColumn("Shortcut").Flags(g.TableColumnFlagsWidthFixed).InnerWidthOrWeight(shortcutColumnW),
+		).
+		Rows(rows...)
+}
+
+// run closes the palette and runs the command
+func (p *CommandPalette) run(idx int) {
+	if idx < 0 || idx >= len(p.results) {
+		return
+	}
+
+	command := p.results[idx]
+	p.Visible = false
+
+	if command.Run != nil {
+		command.Run()
+	}
+}
@@ -0,0 +1,79 @@
+// Package newproject contains a dialog, which allows to choose a template of a new project
+package newproject
+
+import (
+	"fmt"
+
+	g "github.com/AllenDang/giu"
+
+	"github.com/gucio321/HellSpawner/pkg/common/hsproject"
+	"github.com/gucio321/HellSpawner/pkg/window"
+	"github.com/gucio321/HellSpawner/pkg/window/popup"
+)
+
+const (
+	mainWindowW      = 400
+	buttonW, buttonH = 100, 25
+)
+
+var _ window.Renderable = &Dialog{}
+
+// Dialog represents a new project dialog
+type Dialog struct {
+	*popup.Dialog
+
+	templates []*hsproject.Template
+	selected  int32
+	onCreate  func(template *hsproject.Template)
+}
+
+// Create creates a new project dialog. onCreate is called with the template chosen.
+func Create(onCreate func(template *hsproject.Template)) *Dialog {
+	result := &Dialog{
+		Dialog:    popup.New("New Project"),
+		templates: hsproject.Templates(),
+		onCreate:  onCreate,
+	}
+
+	result.Visible = false
+
+	return result
+}
+
+// Build builds the dialog
+func (p *Dialog) Build() {
+	p.IsOpen(&p.Visible).Layout(p.GetLayout()).Build()
+}
+
+// GetLayout returns dialog's layout
+func (p *Dialog) GetLayout() g.Widget {
+	names := make([]string, len(p.templates))
+	for i, template := range p.templates {
+		names[i] = template.Name
+	}
+
+	template := p.templates[p.selected]
+
+	return g.Layout{
+		g.Row(
+			g.Label("Template:"),
+			g.Combo("##NewProjectTemplate", template.Name, names, &p.selected).Size(mainWindowW/2),
+		),
+		g.Dummy(mainWindowW, 0),
+		g.Label(template.Description).Wrapped(true),
+		g.Label(fmt.Sprintf("Folders: %d, starter files: %d", len(template.Folders), len(template.Files))),
+		g.Custom(func() {
+			if len(template.Files) > 0 {
+				g.Label("Starter files are copied from game's MPQs found in auxiliary MPQs directory").Wrapped(true).Build()
+			}
+		}),
+		g.Separator(),
+		g.Row(
+			g.Button("Create...##NewProjectCreate").Size(buttonW, buttonH).OnClick(func() {
+				p.Cleanup()
+				p.onCreate(template)
+			}),
+			g.Button("Cancel##NewProjectCancel").Size(buttonW, buttonH).OnClick(p.Cleanup),
+		),
+	}
+}
@@ -11,6 +11,7 @@ import (
 	"github.com/OpenDiablo2/dialog"
 
 	"github.com/gucio321/HellSpawner/pkg/common/enum"
+	"github.com/gucio321/HellSpawner/pkg/common/hsshortcut"
 	"github.com/gucio321/HellSpawner/pkg/common/hsutil"
 	"github.com/gucio321/HellSpawner/pkg/window/popup"
 )
@@ -21,6 +22,8 @@ const (
 	btnW, btnH               = 30, 0
 )
 
+var colorError = color.RGBA{R: 255, G: 80, B: 80, A: 255}
+
 var _ window.Renderable = &Dialog{}
 
 // Dialog represents preferences dialog
@@ -31,15 +34,20 @@ type Dialog struct {
 	onConfigChanged    func(config *config.Config)
 	windowColorChanger func(c color.Color)
 	restartPrompt      bool
+
+	actions []hsshortcut.Action
+	// shortcuts are shortcuts being edited (by action IDs)
+	shortcuts map[string]string
 }
 
-// Create creates a new preferences dialog
-func Create(onConfigChanged func(config *config.Config), windowColorChanger func(c color.Color)) *Dialog {
+// Create creates a new preferences dialog; actions are these, which shortcuts can be changed
+func Create(onConfigChanged func(config *config.Config), windowColorChanger func(c color.Color), actions []hsshortcut.Action) *Dialog {
 	result := &Dialog{
 		Dialog:             popup.New("Preferences"),
 		onConfigChanged:    onConfigChanged,
 		windowColorChanger: windowColorChanger,
 		restartPrompt:      false,
+		actions:            actions,
 	}
 	result.Visible = false
 
@@ -58,8 +66,9 @@ func (p *Dialog) GetLayout() g.Widget {
 	}
 
 	locale := int32(p.config.Locale)
+	shortcutErrors := p.shortcutErrors()
 
-	return g.Layout{
+	general := g.TabItem("General##AppPreferencesGeneral").Layout(
 		g.Child().Size(mainWindowW, mainWindowH).Layout(
 			g.Label("Auxiliary MPQ Path"),
 			g.Row(
@@ -79,6 +88,12 @@ func (p *Dialog) GetLayout() g.Widget {
 				g.Button("...##AppPreferencesAbyssEnginePathBrowse").Size(btnW, btnH).OnClick(p.onBrowseAbyssEngineClicked),
 			),
 			g.Separator(),
+			g.Label("Plugins Path (applied after restart)"),
+			g.Row(
+				g.InputText(&p.config.PluginsPath).Size(textboxSize).Flags(g.InputTextFlagsReadOnly),
+				g.Button("...##AppPreferencesPluginsPathBrowse").Size(btnW, btnH).OnClick(p.onBrowsePluginsPathClicked),
+			),
+			g.Separator(),
 			g.Checkbox("Open most recent project on start-up", &p.config.OpenMostRecentOnStartup),
 			g.Separator(),
 			g.Checkbox("Save log output in a log file", &p.config.LoggingToFile),
@@ -121,8 +136,16 @@ func (p *Dialog) GetLayout() g.Widget {
 				}),
 			),
 		),
+	)
+
+	shortcuts := g.TabItem("Keyboard Shortcuts##AppPreferencesShortcuts").Layout(
+		g.Child().Size(mainWindowW, mainWindowH).Layout(p.shortcutsLayout(shortcutErrors)),
+	)
+
+	return g.Layout{
+		g.TabBar().TabItems(general, shortcuts),
 		g.Row(
-			g.Button("Save##AppPreferencesSave").OnClick(p.onSaveClicked),
+			g.Button("Save##AppPreferencesSave").Disabled(len(shortcutErrors) > 0).OnClick(p.onSaveClicked),
 			g.Button("Cancel##AppPreferencesCancel").OnClick(p.onCancelClicked),
 		),
 	}
@@ -133,6 +156,7 @@ func (p *Dialog) Show(cfg *config.Config) {
 	p.Dialog.Show()
 
 	p.config = cfg
+	p.resetShortcuts()
 }
 
 func (p *Dialog) onBrowseAuxMpqPathClicked() {
@@ -165,7 +189,17 @@ func (p *Dialog) onBrowseLogFilePathClicked() {
 	p.config.LogFilePath = path
 }
 
+func (p *Dialog) onBrowsePluginsPathClicked() {
+	path, err := dialog.Directory().Browse()
+	if err != nil || path == "" {
+		return
+	}
+
+	p.config.PluginsPath = path
+}
+
 func (p *Dialog) onSaveClicked() {
+	p.applyShortcuts()
 	p.onConfigChanged(p.config)
 	p.Visible = false
 }
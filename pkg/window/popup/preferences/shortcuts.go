@@ -0,0 +1,118 @@
+package preferences
+
+import (
+	"fmt"
+	"strings"
+
+	g "github.com/AllenDang/giu"
+
+	"github.com/gucio321/HellSpawner/pkg/common/hsshortcut"
+)
+
+const shortcutInputW = 110
+
+// resetShortcuts loads shortcuts being edited from the config
+func (p *Dialog) resetShortcuts() {
+	p.shortcuts = make(map[string]string, len(p.actions))
+
+	for _, action := range p.actions {
+		p.shortcuts[action.ID] = action.Default
+
+		if override, found := p.config.Shortcuts[action.ID]; found {
+			p.shortcuts[action.ID] = override
+		}
+	}
+}
+
+// shortcutErrors returns problems with shortcuts being edited (by action IDs)
+func (p *Dialog) shortcutErrors() map[string]string {
+	result := make(map[string]string)
+	parsed := make(map[string]hsshortcut.Shortcut, len(p.shortcuts))
+	names := make(map[string]string, len(p.actions))
+
+	for _, action := range p.actions {
+		names[action.ID] = action.Name
+
+		s, err := hsshortcut.Parse(p.shortcuts[action.ID])
+		if err != nil {
+			result[action.ID] = err.Error()
+
+			continue
+		}
+
+		parsed[action.ID] = s
+	}
+
+	for _, ids := range hsshortcut.Conflicts(parsed) {
+		for _, id := range ids {
+			others := make([]string, 0, len(ids)-1)
+
+			for _, other := range ids {
+				if other != id {
+					others = append(others, names[other])
+				}
+			}
+
+			result[id] = "conflicts with " + strings.Join(others, ", ")
+		}
+	}
+
+	return result
+}
+
+// applyShortcuts saves shortcuts being edited to the config; only these different from defaults are stored
+func (p *Dialog) applyShortcuts() {
+	overrides := make(map[string]string)
+
+	for _, action := range p.actions {
+		s, err := hsshortcut.Parse(p.shortcuts[action.ID])
+		if err != nil {
+			continue
+		}
+
+		if def, err := hsshortcut.Parse(action.Default); err == nil && def == s {
+			continue
+		}
+
+		overrides[action.ID] = s.String()
+	}
+
+	p.config.Shortcuts = overrides
+}
+
+func (p *Dialog) shortcutsLayout(errs map[string]string) g.Widget {
+	rows := make([]*g.TableRowWidget, 0, len(p.actions))
+
+	for _, action := range p.actions {
+		id := action.ID
+		binding := p.shortcuts[id]
+
+		var status g.Widget = g.Dummy(0, 0)
+		if err, found := errs[id]; found {
+			status = g.Style().SetColor(g.StyleColorText, colorError).To(g.Label(err))
+		}
+
+		rows = append(rows, g.TableRow(
+			g.Label(action.Name),
+			g.Layout{
+				g.Row(
+					g.InputText(&binding).Size(shortcutInputW).OnChange(func() {
+						p.shortcuts[id] = binding
+					}),
+					g.Button(fmt.Sprintf("Reset##AppPreferencesShortcutReset%s", id)).OnClick(func() {
+						p.shortcuts[id] = action.Default
+					}),
+				),
+				status,
+			},
+		))
+	}
+
+	return g.Layout{
+		g.Label("Shortcuts are written like Ctrl+Shift+S; leave empty to disable"),
+		g.Table().Columns(
+			g.TableColumn("Action"),
+			g.TableColumn("Shortcut"),
+		).Rows(rows...),
+	}
+}
@@ -7,6 +7,8 @@ import (
 	"strconv"
 	"strings"
 
+	"github.com/OpenDiablo2/dialog"
+
 	"github.com/gucio321/HellSpawner/pkg/app/assets"
 	"github.com/gucio321/HellSpawner/pkg/app/config"
 	"github.com/gucio321/HellSpawner/pkg/window"
@@ -29,6 +31,13 @@ const (
 	dummyW, dummyH             = 8, 0
 	inputTextSize              = 250
 	descriptionW, descriptionH = inputTextSize, 100
+	paletteRulesW              = mpqSelectW * 2
+	paletteRulesH              = 120
+	paletteRuleInputW          = 250
+	launchProfilesH            = 160
+	launchProfileInputW        = 400
+	launchProfileEnvH          = 50
+	browseBtnW                 = 30
 )
 
 var _ window.Renderable = &Dialog{}
@@ -45,6 +54,9 @@ type Dialog struct {
 	onProjectPropertiesChanged func(project *hsproject.Project)
 	auxMPQs, auxMPQNames       []string
 	mpqsToAdd                  []int
+	// launchProfilesEnv are launch profiles' environments (one variable per line)
+	launchProfilesEnv []string
+	presetIdx         int32
 
 	mpqSelectDialogVisible bool
 }
@@ -76,6 +88,15 @@ func Create(onProjectPropertiesChanged func(project *hsproject.Project)) *Dialog
 func (p *Dialog) Show(project *hsproject.Project, cfg *config.Config) {
 	p.config = cfg
 	p.project = *project
+	// rules are edited in place, so they can't share memory with the original project
+	p.project.PaletteRules = append([]hsproject.PaletteRule(nil), project.PaletteRules...)
+	p.project.LaunchProfiles = append([]hsproject.LaunchProfile(nil), project.LaunchProfiles...)
+	p.launchProfilesEnv = make([]string, len(p.project.LaunchProfiles))
+
+	for idx, profile := range p.project.LaunchProfiles {
+		p.launchProfilesEnv[idx] = strings.Join(profile.Env, "\n")
+	}
+
 	p.auxMPQs = cfg.GetAuxMPQs()
 	p.auxMPQNames = make([]string, len(p.auxMPQs))
 
@@ -98,6 +119,13 @@ func (p *Dialog) Build() {
 func (p *Dialog) GetLayout() g.Widget {
 	canSave := strings.TrimSpace(p.project.ProjectName) != ""
 
+	presets := hsproject.LaunchProfilePresets()
+	presetNames := make([]string, len(presets))
+
+	for idx, preset := range presets {
+		presetNames[idx] = preset.Name
+	}
+
 	if !p.mpqSelectDialogVisible {
 		p.IsOpen(&p.Visible).Layout(
 			g.Row(
@@ -156,6 +184,28 @@ func (p *Dialog) GetLayout() g.Widget {
 					g.Button("Add Auxiliary MPQ...##ProjectPropertiesAddAuxMpq").OnClick(p.onAddAuxMpqClicked),
 				),
 			),
+			g.Label("Palette rules (checked before the default ones):"),
+			g.Child().Size(paletteRulesW, paletteRulesH).Layout(
+				g.Custom(p.buildPaletteRules),
+			),
+			g.Button("Add palette rule##ProjectPropertiesAddPaletteRule").OnClick(func() {
+				p.project.PaletteRules = append(p.project.PaletteRules, hsproject.PaletteRule{
+					Pattern: `data\global\*`,
+					Palette: `data\global\palette\act1\pal.dat`,
+				})
+			}),
+			g.Label("Launch profiles (${project}, ${content}, ${exportedMPQ} and ${locale} are replaced):"),
+			g.Child().Size(paletteRulesW, launchProfilesH).Layout(
+				g.Custom(p.buildLaunchProfiles),
+			),
+			g.Row(
+				g.Combo("##ProjectPropertiesLaunchProfilePreset", presetNames[p.presetIdx], presetNames, &p.presetIdx).
+					Size(inputTextSize),
+				g.Button("Add launch profile##ProjectPropertiesAddLaunchProfile").OnClick(func() {
+					p.project.LaunchProfiles = append(p.project.LaunchProfiles, presets[p.presetIdx])
+					p.launchProfilesEnv = append(p.launchProfilesEnv, "")
+				}),
+			),
 			g.Row(
 				g.Custom(func() {
 					const halfOpacity = 0.5
@@ -249,11 +299,88 @@ func (p *Dialog) GetLayout() g.Widget {
 	}
 }
 
+func (p *Dialog) buildPaletteRules() {
+	for idx := range p.project.PaletteRules {
+		if idx >= len(p.project.PaletteRules) {
+			break
+		}
+
+		rule := &p.project.PaletteRules[idx]
+
+		g.Row(
+			g.ImageButton(p.removeIconTexture).Size(imgBtnW, imgBtnH).OnClick(func() {
+				p.project.PaletteRules = append(p.project.PaletteRules[:idx], p.project.PaletteRules[idx+1:]...)
+			}),
+			g.InputText(&rule.Pattern).Size(paletteRuleInputW),
+			g.Label("->"),
+			g.InputText(&rule.Palette).Size(paletteRuleInputW),
+		).Build()
+	}
+}
+
+func (p *Dialog) buildLaunchProfiles() {
+	steps := hsproject.PreLaunchSteps()
+	stepNames := make([]string, len(steps))
+
+	for idx, step := range steps {
+		stepNames[idx] = step.String()
+	}
+
+	for idx := range p.project.LaunchProfiles {
+		if idx >= len(p.project.LaunchProfiles) {
+			break
+		}
+
+		profile := &p.project.LaunchProfiles[idx]
+		id := "##ProjectPropertiesLaunchProfile" + strconv.Itoa(idx)
+		preLaunch := int32(profile.PreLaunch)
+
+		g.Row(
+			g.ImageButton(p.removeIconTexture).Size(imgBtnW, imgBtnH).OnClick(func() {
+				p.project.LaunchProfiles = append(p.project.LaunchProfiles[:idx], p.project.LaunchProfiles[idx+1:]...)
+				p.launchProfilesEnv = append(p.launchProfilesEnv[:idx], p.launchProfilesEnv[idx+1:]...)
+			}),
+			g.TreeNode(profile.Name+id).Layout(
+				g.InputText(&profile.Name).Label("Name"+id+"Name").Size(launchProfileInputW),
+				g.Row(
+					g.InputText(&profile.Executable).Label("Executable"+id+"Executable").Size(launchProfileInputW),
+					g.Button("..."+id+"Browse").Size(browseBtnW, 0).OnClick(func() {
+						if path, err := dialog.File().Load(); err == nil && path != "" {
+							profile.Executable = path
+						}
+					}),
+				),
+				g.InputText(&profile.Args).Label("Arguments"+id+"Args").Size(launchProfileInputW),
+				g.InputText(&profile.WorkDir).Label("Working directory"+id+"WorkDir").Size(launchProfileInputW),
+				g.Label("Environment (KEY=value, one per line):"),
+				g.InputTextMultiline(&p.launchProfilesEnv[idx]).Size(launchProfileInputW, launchProfileEnvH),
+				g.Combo("Before launch"+id+"PreLaunch", profile.PreLaunch.String(), stepNames, &preLaunch).
+					Size(inputTextSize).
+					OnChange(func() {
+						profile.PreLaunch = steps[preLaunch]
+					}),
+			),
+		).Build()
+	}
+}
+
 func (p *Dialog) onSaveClicked() {
 	if strings.TrimSpace(p.project.ProjectName) == "" {
 		return
 	}
 
+	for idx := range p.project.LaunchProfiles {
+		env := make([]string, 0)
+
+		for _, line := range strings.Split(p.launchProfilesEnv[idx], "\n") {
+			if line = strings.TrimSpace(line); line != "" {
+				env = append(env, line)
+			}
+		}
+
+		p.project.LaunchProfiles[idx].Env = env
+	}
+
 	p.onProjectPropertiesChanged(&p.project)
 	p.Visible = false
 }
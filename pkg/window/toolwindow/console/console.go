@@ -4,9 +4,11 @@ package console
 import (
 	"fmt"
 	"os"
+	"sync"
 
 	"github.com/gucio321/HellSpawner/pkg/app/state"
 
+	"github.com/AllenDang/cimgui-go/imgui"
 	g "github.com/AllenDang/giu"
 
 	"github.com/gucio321/HellSpawner/pkg/window/toolwindow"
@@ -25,6 +27,15 @@ type Console struct {
 	outputText string
 	fontFixed  *g.FontInfo
 	logFile    *os.File
+
+	// pending is a text written since the last frame; Write may be called from
+	// any goroutine, so outputText is updated only by the UI
+	pending      string
+	pendingMutex sync.Mutex
+
+	// input is a code typed by user; onInput runs it
+	input   string
+	onInput func(code string)
 }
 
 // Create creates a new console
@@ -48,19 +59,65 @@ func (c *Console) Build() {
 		Layout(c.GetLayout())
 }
 
+// SetInputHandler enables an input line; code typed there is passed to the function given
+func (c *Console) SetInputHandler(onInput func(code string)) {
+	c.onInput = onInput
+}
+
 func (c *Console) GetLayout() g.Widget {
 	return g.Style().SetFont(c.fontFixed).To(
+		g.Custom(c.flushPending),
+		g.Custom(c.buildInput),
 		g.InputTextMultiline(&c.outputText).
 			Size(lineW, lineH).
-			Flags(g.InputTextFlagsReadOnly | g.InputTextFlagsNoUndoRedo),
+			Flags(g.InputTextFlagsReadOnly|g.InputTextFlagsNoUndoRedo),
 	)
 }
 
+// flushPending adds text written since the last frame to console's output
+func (c *Console) flushPending() {
+	c.pendingMutex.Lock()
+	defer c.pendingMutex.Unlock()
+
+	if c.pending == "" {
+		return
+	}
+
+	c.outputText = c.pending + c.outputText
+	c.pending = ""
+}
+
+func (c *Console) buildInput() {
+	if c.onInput == nil {
+		return
+	}
+
+	imgui.SetNextItemWidth(lineW)
+
+	if !imgui.InputTextWithHint("##ConsoleInput", "JavaScript, e.g. files(\"data/**/*.dc6\").length", &c.input,
+		imgui.InputTextFlagsEnterReturnsTrue, nil) {
+		return
+	}
+
+	code := c.input
+	c.input = ""
+
+	// keep typing in the input after running the code
+	imgui.SetKeyboardFocusHereV(-1)
+
+	if code != "" {
+		c.onInput(code)
+	}
+}
+
 // Write writes input on console, stdout and (if exists) to the log file
 func (c *Console) Write(p []byte) (n int, err error) {
 	msg := string(p) // convert message from byte slice into string
 
-	c.outputText = msg + c.outputText // append message
+	c.pendingMutex.Lock()
+	defer c.pendingMutex.Unlock()
+
+	c.pending = msg + c.pending // append message
 
 	fmt.Print(msg) // print to terminal
 
@@ -0,0 +1,108 @@
+package mpqcompare
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	g "github.com/AllenDang/giu"
+	"github.com/OpenDiablo2/OpenDiablo2/d2common/d2fileformats/d2dc6"
+	"github.com/OpenDiablo2/OpenDiablo2/d2common/d2fileformats/d2dcc"
+	"github.com/OpenDiablo2/OpenDiablo2/d2common/d2interface"
+
+	"github.com/gucio321/HellSpawner/pkg/common"
+	"github.com/gucio321/HellSpawner/pkg/common/hsdiff"
+	"github.com/gucio321/HellSpawner/pkg/common/hsfiletypes"
+	"github.com/gucio321/HellSpawner/pkg/widgets/dc6widget"
+	"github.com/gucio321/HellSpawner/pkg/widgets/dccwidget"
+	"github.com/gucio321/HellSpawner/pkg/widgets/diffwidget"
+)
+
+// makeDiffView creates a view of differences between two versions of the file (a or b is nil,
+// if the file doesn't exist in the source)
+func (m *MPQCompare) makeDiffView(change *hsdiff.FileChange, a, b []byte) g.Widget {
+	data := b
+	if data == nil {
+		data = a
+	}
+
+	header := g.Label(fmt.Sprintf("%s (%s)", change.Path, change.Status))
+
+	fileType, _ := hsfiletypes.GetFileTypeFromExtension(filepath.Ext(change.Path), &data)
+	if fileType == hsfiletypes.FileTypeDC6 || fileType == hsfiletypes.FileTypeDCC {
+		return g.Layout{header, m.makeImageDiff(change, fileType, a, b)}
+	}
+
+	diff, err := hsdiff.Diff(change.Path, a, b)
+	if err != nil {
+		return g.Layout{header, g.Label(err.Error())}
+	}
+
+	return g.Layout{
+		header,
+		diffwidget.Create("MPQCompareDiff"+change.Path, m.sources[0].Name(), m.sources[1].Name(), diff),
+	}
+}
+
+// makeImageDiff shows both versions of the image side by side
+func (m *MPQCompare) makeImageDiff(change *hsdiff.FileChange, fileType hsfiletypes.FileType, a, b []byte) g.Widget {
+	palette := m.resolvePalette(change.Path)
+
+	images := make([]g.Widget, 2)
+
+	for i, data := range [][]byte{a, b} {
+		id := fmt.Sprintf("MPQCompare%d_%s", i, change.Path)
+
+		switch {
+		case data == nil:
+			images[i] = g.Label("(file doesn't exist)")
+		case fileType == hsfiletypes.FileTypeDC6:
+			dc6, err := d2dc6.Load(data)
+			if err != nil {
+				images[i] = g.Label(fmt.Sprintf("Error loading DC6: %v", err))
+
+				continue
+			}
+
+			images[i] = dc6widget.Create(nil, palette, id, dc6)
+		default:
+			dcc, err := d2dcc.Load(data)
+			if err != nil {
+				images[i] = g.Label(fmt.Sprintf("Error loading DCC: %v", err))
+
+				continue
+			}
+
+			images[i] = dccwidget.Create(nil, palette, id, dcc)
+		}
+	}
+
+	return g.Table().
+		Flags(g.TableFlagsBordersInnerV|g.TableFlagsScrollX|g.TableFlagsScrollY).
+		Columns(
+			g.TableColumn(m.sources[0].Name()),
+			g.TableColumn(m.sources[1].Name()),
+		).
+		Rows(g.TableRow(images...))
+}
+
+// resolvePalette returns a palette of the file using project's palette rules; returns nil
+// (images are shown in grayscale), if palette can't be found
+func (m *MPQCompare) resolvePalette(path string) *[256]d2interface.Color {
+	entry := &common.PathEntry{
+		FullPath: `data\` + strings.ReplaceAll(path, "/", `\`),
+		Source:   common.PathEntrySourceMPQ,
+	}
+
+	paletteEntry := m.project.ResolvePalette(entry)
+	if paletteEntry == nil {
+		return nil
+	}
+
+	palette, err := m.project.LoadPalette(paletteEntry)
+	if err != nil {
+		return nil
+	}
+
+	return palette
+}
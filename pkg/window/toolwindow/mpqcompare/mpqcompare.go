@@ -0,0 +1,422 @@
+// Package mpqcompare contains a tool window, which compares two MPQ archives
+// (or an archive and project's content) and shows differences of their files.
+package mpqcompare
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log"
+	"path/filepath"
+	"strings"
+	"sync"
+	"sync/atomic"
+
+	g "github.com/AllenDang/giu"
+	"github.com/OpenDiablo2/OpenDiablo2/d2common/d2fileformats/d2mpq"
+
+	"github.com/gucio321/HellSpawner/pkg/app/config"
+	"github.com/gucio321/HellSpawner/pkg/app/state"
+	"github.com/gucio321/HellSpawner/pkg/common/hsdiff"
+	"github.com/gucio321/HellSpawner/pkg/common/hsproject"
+	"github.com/gucio321/HellSpawner/pkg/widgets/diffwidget"
+	"github.com/gucio321/HellSpawner/pkg/window/toolwindow"
+)
+
+const (
+	mainWindowW, mainWindowH = 800, 500
+	comboW                   = 200
+	filterW                  = 200
+	progressBarH             = 20
+	cancelW                  = 70
+	defaultSplit             = 200
+	sizeColumnW              = 80
+)
+
+const projectSourceName = "Project"
+
+var _ toolwindow.ToolWindow = (*MPQCompare)(nil)
+
+// MPQCompare represents a compare tool window
+type MPQCompare struct {
+	*toolwindow.ToolWindowBase
+	config  *config.Config
+	project *hsproject.Project
+
+	sourceA, sourceB int32
+	filter           string
+	showAdded        bool
+	showRemoved      bool
+	showChanged      bool
+	split            float32
+
+	comparison *comparison
+	sources    [2]hsdiff.Source
+	closers    []io.Closer
+	changes    []hsdiff.FileChange
+	selected   string
+	diffView   g.Widget
+}
+
+// comparison compares sources in the background
+type comparison struct {
+	cancel context.CancelFunc
+	done   atomic.Int32
+	total  atomic.Int32
+
+	mutex    sync.Mutex
+	finished bool
+	// abandoned is true if nobody waits for results; sources are closed, when the comparison finishes
+	abandoned bool
+	sources   [2]hsdiff.Source
+	closers   []io.Closer
+	changes   []hsdiff.FileChange
+	err       error
+}
+
+// Create creates a new compare window
+func Create(cfg *config.Config, x, y float32) *MPQCompare {
+	result := &MPQCompare{
+		ToolWindowBase: toolwindow.New("MPQ Compare", state.ToolWindowTypeMPQCompare, x, y),
+		config:         cfg,
+		split:          defaultSplit,
+		showAdded:      true,
+		showRemoved:    true,
+		showChanged:    true,
+	}
+
+	if w, h := result.CurrentSize(); w == 0 || h == 0 {
+		result.Size(mainWindowW, mainWindowH)
+	}
+
+	return result
+}
+
+// SetProject sets window's project
+func (m *MPQCompare) SetProject(project *hsproject.Project) {
+	m.project = project
+	m.sourceA, m.sourceB = 0, 0
+
+	if project != nil && len(project.AuxiliaryMPQs) > 0 {
+		// by default, compare the first archive with the project
+		m.sourceA = 1
+	}
+
+	m.Reset()
+}
+
+// Reset cancels the comparison and clears its results
+func (m *MPQCompare) Reset() {
+	if m.comparison != nil {
+		m.comparison.abandon()
+		m.comparison = nil
+	}
+
+	m.closeSources()
+	m.changes = nil
+	m.selected = ""
+	m.diffView = nil
+}
+
+// abandon cancels the comparison and makes sure, that its sources will be closed
+func (c *comparison) abandon() {
+	c.cancel()
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	c.abandoned = true
+
+	if c.finished {
+		closeAll(c.closers)
+	}
+}
+
+func closeAll(closers []io.Closer) {
+	for _, c := range closers {
+		if err := c.Close(); err != nil {
+			log.Print(err)
+		}
+	}
+}
+
+func (m *MPQCompare) closeSources() {
+	closeAll(m.closers)
+
+	m.closers = nil
+	m.sources = [2]hsdiff.Source{}
+}
+
+// Build builds the window
+func (m *MPQCompare) Build() {
+	m.IsOpen(&m.Visible).
+		Size(mainWindowW, mainWindowH).
+		Layout(m.GetLayout())
+}
+
+// GetLayout returns window's layout
+func (m *MPQCompare) GetLayout() g.Widget {
+	if m.project == nil {
+		return g.Label("No project loaded...")
+	}
+
+	if m.comparison != nil {
+		m.checkComparison()
+	}
+
+	sources := m.sourceNames()
+
+	if int(m.sourceA) >= len(sources) || int(m.sourceB) >= len(sources) {
+		m.sourceA, m.sourceB = 0, 0
+	}
+
+	layout := g.Layout{
+		g.Row(
+			g.Combo("##MPQCompareSourceA", sources[m.sourceA], sources, &m.sourceA).Size(comboW),
+			g.Label("->"),
+			g.Combo("##MPQCompareSourceB", sources[m.sourceB], sources, &m.sourceB).Size(comboW),
+			g.Button("Compare##MPQCompare").Disabled(m.comparison != nil || m.sourceA == m.sourceB).OnClick(m.startComparison),
+		),
+	}
+
+	if c := m.comparison; c != nil {
+		done, total := c.done.Load(), c.total.Load()
+
+		progress := float32(0)
+		if total > 0 {
+			progress = float32(done) / float32(total)
+		}
+
+		return append(layout, g.Row(
+			g.ProgressBar(progress).Size(-cancelW, progressBarH).Overlay(fmt.Sprintf("%d/%d", done, total)),
+			g.Button("Cancel##MPQCompareCancel").OnClick(func() {
+				c.cancel()
+			}),
+		))
+	}
+
+	if m.sources[0] == nil {
+		return layout
+	}
+
+	return append(layout,
+		m.makeFilterLayout(),
+		g.SplitLayout(g.DirectionHorizontal, &m.split,
+			m.makeChangesLayout(),
+			m.makeDiffLayout(),
+		),
+	)
+}
+
+// sourceNames returns names of sources, which can be compared: the project and its auxiliary MPQs
+func (m *MPQCompare) sourceNames() []string {
+	result := []string{projectSourceName}
+
+	return append(result, m.project.AuxiliaryMPQs...)
+}
+
+// openSource opens the source of the index given (see sourceNames)
+func (m *MPQCompare) openSource(idx int32) (hsdiff.Source, io.Closer, error) {
+	if idx == 0 {
+		return hsdiff.NewDirSource(projectSourceName, m.project.GetProjectFileContentPath()), nil, nil
+	}
+
+	path := filepath.Join(m.config.AuxiliaryMpqPath, m.project.AuxiliaryMPQs[idx-1])
+
+	mpq, err := d2mpq.FromFile(path)
+	if err != nil {
+		return nil, nil, fmt.Errorf("error loading mpq %s: %w", path, err)
+	}
+
+	files, err := m.project.ListMPQFiles(mpq, m.config)
+	if err != nil {
+		return nil, nil, fmt.Errorf("error listing files of %s: %w", path, err)
+	}
+
+	return hsdiff.NewMPQSource(mpq, files), mpq, nil
+}
+
+func (m *MPQCompare) startComparison() {
+	m.Reset()
+
+	ctx, cancel := context.WithCancel(context.Background())
+
+	c := &comparison{cancel: cancel}
+	m.comparison = c
+
+	indices := [2]int32{m.sourceA, m.sourceB}
+
+	go func() {
+		defer func() {
+			c.mutex.Lock()
+			defer c.mutex.Unlock()
+
+			c.finished = true
+
+			if c.abandoned {
+				closeAll(c.closers)
+			}
+		}()
+
+		var sources [2]hsdiff.Source
+
+		closers := make([]io.Closer, 0)
+
+		for i, idx := range indices {
+			source, closer, err := m.openSource(idx)
+			if closer != nil {
+				closers = append(closers, closer)
+			}
+
+			if err != nil {
+				c.mutex.Lock()
+				c.closers, c.err = closers, err
+				c.mutex.Unlock()
+
+				return
+			}
+
+			sources[i] = source
+		}
+
+		changes, err := hsdiff.Compare(ctx, sources[0], sources[1], func(done, total int) {
+			c.done.Store(int32(done))
+			c.total.Store(int32(total))
+		})
+
+		c.mutex.Lock()
+		c.sources, c.closers, c.changes, c.err = sources, closers, changes, err
+		c.mutex.Unlock()
+	}()
+}
+
+// checkComparison takes results of the comparison, when it is finished
+func (m *MPQCompare) checkComparison() {
+	c := m.comparison
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if !c.finished {
+		return
+	}
+
+	m.comparison = nil
+	m.closers = c.closers
+
+	if c.err != nil {
+		log.Printf("comparison failed: %v", c.err)
+		m.closeSources()
+
+		return
+	}
+
+	m.sources = c.sources
+	m.changes = c.changes
+}
+
+func (m *MPQCompare) makeFilterLayout() g.Widget {
+	counts := make(map[hsdiff.Status]int)
+	for _, c := range m.changes {
+		counts[c.Status]++
+	}
+
+	label := func(status hsdiff.Status) string {
+		return fmt.Sprintf("%s (%d)##MPQCompareShow%s", status, counts[status], status)
+	}
+
+	return g.Row(
+		g.Checkbox(label(hsdiff.StatusAdded), &m.showAdded),
+		g.Checkbox(label(hsdiff.StatusRemoved), &m.showRemoved),
+		g.Checkbox(label(hsdiff.StatusChanged), &m.showChanged),
+		g.InputText(&m.filter).Hint("Filter").Size(filterW),
+	)
+}
+
+func (m *MPQCompare) isVisible(change *hsdiff.FileChange) bool {
+	show := map[hsdiff.Status]bool{
+		hsdiff.StatusAdded:   m.showAdded,
+		hsdiff.StatusRemoved: m.showRemoved,
+		hsdiff.StatusChanged: m.showChanged,
+	}
+
+	if !show[change.Status] {
+		return false
+	}
+
+	return m.filter == "" || strings.Contains(change.Path, strings.ToLower(m.filter))
+}
+
+func (m *MPQCompare) makeChangesLayout() g.Widget {
+	if len(m.changes) == 0 {
+		return g.Label(fmt.Sprintf("%s and %s have the same files.", m.sources[0].Name(), m.sources[1].Name()))
+	}
+
+	rows := make([]*g.TableRowWidget, 0, len(m.changes))
+
+	for idx := range m.changes {
+		change := &m.changes[idx]
+		if !m.isVisible(change) {
+			continue
+		}
+
+		rows = append(rows, g.TableRow(
+			g.Selectable(change.Status.String()+"##MPQCompareChange"+change.Path).
+				Selected(m.selected == change.Path).
+				Flags(g.SelectableFlagsSpanAllColumns).
+				OnClick(func() { m.openDiff(change) }),
+			g.Label(change.Path),
+			g.Label(formatSize(change.SizeA, change.PathA != "")),
+			g.Label(formatSize(change.SizeB, change.PathB != "")),
+		).BgColor(diffwidget.StatusColor(change.Status)))
+	}
+
+	return g.Table().FastMode(true).Freeze(0, 1).
+		Columns(
+			g.TableColumn("Status").Flags(g.TableColumnFlagsWidthFixed).InnerWidthOrWeight(sizeColumnW),
+			g.TableColumn("Path"),
+			g.TableColumn(m.sources[0].Name()).Flags(g.TableColumnFlagsWidthFixed).InnerWidthOrWeight(sizeColumnW),
+			g.TableColumn(m.sources[1].Name()).Flags(g.TableColumnFlagsWidthFixed).InnerWidthOrWeight(sizeColumnW),
+		).
+		Rows(rows...)
+}
+
+func formatSize(size int, exists bool) string {
+	if !exists {
+		return "-"
+	}
+
+	return fmt.Sprintf("%d B", size)
+}
+
+func (m *MPQCompare) makeDiffLayout() g.Widget {
+	if m.diffView == nil {
+		return g.Label("Select a file to see its changes.")
+	}
+
+	return m.diffView
+}
+
+// openDiff reads both versions of the file and creates its diff view
+func (m *MPQCompare) openDiff(change *hsdiff.FileChange) {
+	m.selected = change.Path
+
+	var data [2][]byte
+
+	for i, path := range []string{change.PathA, change.PathB} {
+		if path == "" {
+			continue
+		}
+
+		d, err := m.sources[i].ReadFile(path)
+		if err != nil {
+			m.diffView = g.Label(err.Error())
+
+			return
+		}
+
+		data[i] = d
+	}
+
+	m.diffView = m.makeDiffView(change, data[0], data[1])
+}
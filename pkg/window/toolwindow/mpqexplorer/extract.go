@@ -0,0 +1,322 @@
+package mpqexplorer
+
+import (
+	"fmt"
+	"log"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"sync"
+	"sync/atomic"
+
+	g "github.com/AllenDang/giu"
+	"github.com/OpenDiablo2/OpenDiablo2/d2common/d2fileformats/d2mpq"
+	"github.com/OpenDiablo2/OpenDiablo2/d2common/d2interface"
+	"github.com/OpenDiablo2/dialog"
+
+	"github.com/gucio321/HellSpawner/pkg/common"
+	"github.com/gucio321/HellSpawner/pkg/common/hsutil"
+)
+
+const (
+	extractPopupID      = "Extract folder##MPQExplorerExtract"
+	extractFilterW      = 200
+	extractProgressBarH = 20
+	extractCancelW      = 70
+)
+
+// extractRequest is a folder waiting for user to confirm the extraction
+type extractRequest struct {
+	entry *common.PathEntry
+	// dir is a destination directory; if empty, files are extracted into the project
+	dir string
+}
+
+// extractedFile is a single file to extract
+type extractedFile struct {
+	source      string
+	destination string
+}
+
+// extractor extracts files from MPQ in the background
+type extractor struct {
+	name      string
+	mpqFile   string
+	files     []extractedFile
+	overwrite bool
+	done      atomic.Int32
+	cancel    chan struct{}
+
+	mutex     sync.Mutex
+	finished  bool
+	written   int
+	conflicts []extractedFile
+}
+
+// matchesFilter returns true if the file (given by its path relative to the extracted folder)
+// matches the filter. Filter is a list of glob patterns (e.g. "*.txt") or extensions (e.g. ".tbl")
+// separated by semicolons, commas or spaces. Empty filter matches all the files.
+func matchesFilter(relPath, filter string) bool {
+	patterns := strings.FieldsFunc(filter, func(r rune) bool { return r == ';' || r == ',' || r == ' ' })
+	if len(patterns) == 0 {
+		return true
+	}
+
+	relPath = strings.ToLower(strings.ReplaceAll(relPath, "\\", "/"))
+	name := path.Base(relPath)
+
+	for _, pattern := range patterns {
+		pattern = strings.ToLower(strings.ReplaceAll(pattern, "\\", "/"))
+
+		if strings.HasPrefix(pattern, ".") && !strings.ContainsAny(pattern, "*?[") {
+			if strings.HasSuffix(name, pattern) {
+				return true
+			}
+
+			continue
+		}
+
+		subject := name
+		if strings.Contains(pattern, "/") {
+			subject = relPath
+		}
+
+		if ok, err := path.Match(pattern, subject); err == nil && ok {
+			return true
+		}
+	}
+
+	return false
+}
+
+// projectPathOf returns path of the MPQ's file in the project
+func (m *MPQExplorer) projectPathOf(pathEntry *common.PathEntry) string {
+	pathToFile := pathEntry.FullPath
+	if strings.HasPrefix(pathEntry.FullPath, "data") {
+		// strip "data" from the beginning of the path if it exists
+		pathToFile = pathToFile[4:]
+	}
+
+	pathToFile = path.Join(m.project.GetProjectFileContentPath(), pathToFile)
+	pathToFile = strings.ReplaceAll(pathToFile, "\\", "/")
+
+	return pathToFile
+}
+
+func (m *MPQExplorer) onExtractToProjectClicked(pathEntry *common.PathEntry) {
+	m.extractRequest = &extractRequest{entry: pathEntry}
+}
+
+func (m *MPQExplorer) onExtractToClicked(pathEntry *common.PathEntry) {
+	dir, err := dialog.Directory().Title("Extract " + pathEntry.Name).Browse()
+	if err != nil || dir == "" {
+		return
+	}
+
+	m.extractRequest = &extractRequest{entry: pathEntry, dir: dir}
+}
+
+// extractedPath returns a destination of the MPQ's file extracted from the folder into the directory given.
+// Paths in the destination directory are relative to the folder's parent,
+// so that the folder itself is extracted (not only its content).
+func extractedPath(folder, file, dir string) string {
+	parent := ""
+	if idx := strings.LastIndex(strings.TrimRight(folder, `\`), `\`); idx >= 0 {
+		parent = folder[:idx+1]
+	}
+
+	return filepath.Join(dir, filepath.FromSlash(strings.ReplaceAll(strings.TrimPrefix(file, parent), `\`, "/")))
+}
+
+// collectFiles returns the files of the requested folder, which match the filter
+func (m *MPQExplorer) collectFiles(request *extractRequest, filter string) []extractedFile {
+	result := make([]extractedFile, 0)
+
+	var walk func(entry *common.PathEntry)
+
+	walk = func(entry *common.PathEntry) {
+		if entry.IsDirectory {
+			for _, child := range entry.Children {
+				walk(child)
+			}
+
+			return
+		}
+
+		rel := strings.TrimPrefix(entry.FullPath, request.entry.FullPath)
+		if !matchesFilter(strings.TrimLeft(rel, `\`), filter) {
+			return
+		}
+
+		destination := m.projectPathOf(entry)
+		if request.dir != "" {
+			destination = extractedPath(request.entry.FullPath, entry.FullPath, request.dir)
+		}
+
+		result = append(result, extractedFile{source: entry.FullPath, destination: destination})
+	}
+
+	walk(request.entry)
+
+	return result
+}
+
+func (m *MPQExplorer) startExtraction(request *extractRequest) {
+	files := m.collectFiles(request, m.extractFilter)
+	if len(files) == 0 {
+		log.Printf("no files in %s match %q", request.entry.FullPath, m.extractFilter)
+
+		return
+	}
+
+	m.runExtractor(request.entry.Name, request.entry.MPQFile, files, false)
+}
+
+// runExtractor starts extracting files in the background. Existing files are overwritten only if overwrite is set,
+// otherwise they are reported as conflicts.
+func (m *MPQExplorer) runExtractor(name, mpqFile string, files []extractedFile, overwrite bool) {
+	m.extractor = &extractor{
+		name:      name,
+		mpqFile:   mpqFile,
+		files:     files,
+		overwrite: overwrite,
+		cancel:    make(chan struct{}),
+	}
+
+	go m.extractor.run()
+}
+
+func (e *extractor) run() {
+	defer func() {
+		e.mutex.Lock()
+		e.finished = true
+		e.mutex.Unlock()
+	}()
+
+	mpq, err := d2mpq.FromFile(e.mpqFile)
+	if err != nil {
+		log.Printf("failed to load mpq %s: %v", e.mpqFile, err)
+
+		return
+	}
+
+	defer func() {
+		if err := mpq.Close(); err != nil {
+			log.Print(err)
+		}
+	}()
+
+	for _, f := range e.files {
+		select {
+		case <-e.cancel:
+			return
+		default:
+		}
+
+		e.extract(mpq, f)
+		e.done.Add(1)
+	}
+}
+
+func (e *extractor) extract(mpq d2interface.Archive, f extractedFile) {
+	if _, err := os.Stat(f.destination); err == nil && !e.overwrite {
+		// only the paths are kept; the file is read again if user decides to overwrite it
+		e.mutex.Lock()
+		e.conflicts = append(e.conflicts, f)
+		e.mutex.Unlock()
+
+		return
+	}
+
+	data, err := mpq.ReadFile(f.source)
+	if err != nil {
+		log.Printf("failed to read file %s when extracting: %s", f.source, err)
+
+		return
+	}
+
+	if hsutil.CreateFileAtPath(f.destination, data) {
+		e.mutex.Lock()
+		e.written++
+		e.mutex.Unlock()
+	}
+}
+
+// checkExtraction finishes the extraction, when the worker is done
+func (m *MPQExplorer) checkExtraction() {
+	e := m.extractor
+
+	e.mutex.Lock()
+	finished, written, conflicts := e.finished, e.written, e.conflicts
+	e.mutex.Unlock()
+
+	if !finished {
+		return
+	}
+
+	m.extractor = nil
+
+	log.Printf("extracted %d files of %s", written, e.name)
+
+	if written > 0 {
+		m.project.InvalidateFileStructure()
+	}
+
+	if len(conflicts) > 0 {
+		m.filesToOverwrite = append(m.filesToOverwrite, overwriteBatch{mpqFile: e.mpqFile, files: conflicts})
+	}
+}
+
+func (m *MPQExplorer) makeExtractRequestLayout() g.Widget {
+	isOpen := true
+	request := m.extractRequest
+
+	destination := "the project"
+	if request.dir != "" {
+		destination = request.dir
+	}
+
+	return g.Layout{
+		g.Custom(func() { g.OpenPopup(extractPopupID) }),
+		g.PopupModal(extractPopupID).IsOpen(&isOpen).Layout(
+			g.Label(fmt.Sprintf("Extract %s into %s", request.entry.FullPath, destination)),
+			g.Row(
+				g.Label("Filter:"),
+				g.InputText(&m.extractFilter).Hint("e.g. *.txt;*.tbl").Size(extractFilterW),
+			),
+			g.Row(
+				g.Button("Extract##MPQExplorerExtract").OnClick(func() {
+					m.extractRequest = nil
+					m.startExtraction(request)
+				}),
+				g.Button("Cancel##MPQExplorerExtractCancel").OnClick(func() {
+					m.extractRequest = nil
+				}),
+			),
+		),
+		g.Custom(func() {
+			if !isOpen {
+				m.extractRequest = nil
+			}
+		}),
+	}
+}
+
+func (m *MPQExplorer) makeExtractionProgressLayout() g.Widget {
+	e := m.extractor
+	done, total := e.done.Load(), len(e.files)
+
+	return g.Row(
+		g.ProgressBar(float32(done)/float32(total)).
+			Size(-extractCancelW, extractProgressBarH).
+			Overlay(fmt.Sprintf("%s: %d/%d", e.name, done, total)),
+		g.Button("Cancel##MPQExplorerExtractionCancel").OnClick(func() {
+			select {
+			case <-e.cancel:
+			default:
+				close(e.cancel)
+			}
+		}),
+	)
+}
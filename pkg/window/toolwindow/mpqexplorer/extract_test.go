@@ -0,0 +1,23 @@
+package mpqexplorer
+
+import (
+	"path/filepath"
+	"testing"
+)
+
+func Test_extractedPath(t *testing.T) {
+	tests := []struct {
+		name, folder, file, expected string
+	}{
+		{"folder", `data\global\excel`, `data\global\excel\armor.txt`, "excel/armor.txt"},
+		{"folder with trailing separator", `data\global\excel\`, `data\global\excel\armor.txt`, "excel/armor.txt"},
+		{"nested file", `data\global\`, `data\global\excel\armor.txt`, "global/excel/armor.txt"},
+		{"top level folder", `data\`, `data\global\excel\armor.txt`, "data/global/excel/armor.txt"},
+	}
+
+	for _, test := range tests {
+		if got := extractedPath(test.folder, test.file, "out"); got != filepath.Join("out", filepath.FromSlash(test.expected)) {
+			t.Errorf("%s: unexpected path %s", test.name, got)
+		}
+	}
+}
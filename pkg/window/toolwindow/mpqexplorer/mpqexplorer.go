@@ -3,9 +3,9 @@
 package mpqexplorer
 
 import (
+	"fmt"
 	"log"
 	"os"
-	"path"
 	"path/filepath"
 	"strings"
 	"sync"
@@ -18,6 +18,7 @@ import (
 	"github.com/OpenDiablo2/OpenDiablo2/d2common/d2fileformats/d2mpq"
 
 	"github.com/gucio321/HellSpawner/pkg/common"
+	"github.com/gucio321/HellSpawner/pkg/common/hsfiletypes"
 	"github.com/gucio321/HellSpawner/pkg/common/hsproject"
 	"github.com/gucio321/HellSpawner/pkg/common/hsutil"
 	"github.com/gucio321/HellSpawner/pkg/widgets"
@@ -26,11 +27,16 @@ import (
 
 const (
 	mainWindowW, mainWindowH = 300, 400
+	overwriteListW           = 400
+	overwriteListH           = 150
 )
 
 // FileSelectedCallback represents file selected callback
 type FileSelectedCallback func(path *common.PathEntry)
 
+// OpenAsCallback represents callback on file chosen to be opened as a specific file type
+type OpenAsCallback func(path *common.PathEntry, fileType hsfiletypes.FileType)
+
 var _ toolwindow.ToolWindow = (*MPQExplorer)(nil)
 
 // MPQExplorer represents a mpq explorer
@@ -41,12 +47,22 @@ type MPQExplorer struct {
 	fileSelectedCallback FileSelectedCallback
 	nodeCache            []g.Widget
 
-	filesToOverwrite []fileToOverwrite
+	// openAsFileTypes are file types offered in the "Open As" menu
+	openAsFileTypes []hsfiletypes.FileType
+	openAsCallback  OpenAsCallback
+
+	// filesToOverwrite are batches of files, which already exist; user is asked once per batch
+	filesToOverwrite []overwriteBatch
+
+	extractRequest *extractRequest
+	extractFilter  string
+	extractor      *extractor
 }
 
-type fileToOverwrite struct {
-	Path string
-	Data []byte
+// overwriteBatch are files of the MPQ, which already exist in the destination
+type overwriteBatch struct {
+	mpqFile string
+	files   []extractedFile
 }
 
 // Create creates a new explorer
@@ -64,6 +80,12 @@ func Create(fileSelectedCallback FileSelectedCallback, cfg *config.Config, x, y
 	return result, nil
 }
 
+// SetOpenAs sets file types, which files can be opened as, and a callback called when the user chooses one
+func (m *MPQExplorer) SetOpenAs(fileTypes []hsfiletypes.FileType, cb OpenAsCallback) {
+	m.openAsFileTypes = fileTypes
+	m.openAsCallback = cb
+}
+
 // SetProject sets explorer's project
 func (m *MPQExplorer) SetProject(project *hsproject.Project) {
 	m.project = project
@@ -81,31 +103,73 @@ func (m *MPQExplorer) GetLayout() g.Widget {
 		return g.Label("No project loaded...")
 	}
 
-	needToShowOverwritePrompt := len(m.filesToOverwrite) > 0
-	if needToShowOverwritePrompt {
+	if m.extractor != nil {
+		m.checkExtraction()
+	}
+
+	if len(m.filesToOverwrite) > 0 {
+		return m.makeOverwritePromptLayout()
+	}
+
+	layout := g.Layout{}
+
+	if m.extractRequest != nil {
+		layout = append(layout, m.makeExtractRequestLayout())
+	}
+
+	if m.extractor != nil {
+		layout = append(layout, m.makeExtractionProgressLayout())
+	}
+
+	return append(layout, g.Child().
+		Border(false).
+		Flags(g.WindowFlagsHorizontalScrollbar).
+		Layout(m.GetMpqTreeNodes()...),
+	)
+}
+
+func (m *MPQExplorer) makeOverwritePromptLayout() g.Widget {
+	needToShowOverwritePrompt := true
+	batch := m.filesToOverwrite[0]
+
+	// files are extracted again (only one extraction runs at once)
+	canOverwrite := m.extractor == nil
+	overwrite := func() {
+		m.filesToOverwrite = m.filesToOverwrite[1:]
+		m.runExtractor(filepath.Base(batch.mpqFile), batch.mpqFile, batch.files, true)
+	}
+
+	skip := func() {
+		m.filesToOverwrite = m.filesToOverwrite[1:]
+	}
+
+	if len(batch.files) == 1 {
 		return g.Layout{
 			g.PopupModal("Overwrite File?").IsOpen(&needToShowOverwritePrompt).Layout(g.Layout{
-				g.Label("File at " + m.filesToOverwrite[0].Path + " already exists. Overwrite?"),
+				g.Label("File at " + batch.files[0].destination + " already exists. Overwrite?"),
 				g.Row(
-					g.Button("Overwrite").OnClick(func() {
-						success := hsutil.CreateFileAtPath(m.filesToOverwrite[0].Path, m.filesToOverwrite[0].Data)
-						if success {
-							m.project.InvalidateFileStructure()
-						}
-						m.filesToOverwrite = m.filesToOverwrite[1:]
-					}),
-					g.Button("Cancel").OnClick(func() {
-						m.filesToOverwrite = m.filesToOverwrite[1:]
-					}),
+					g.Button("Overwrite").Disabled(!canOverwrite).OnClick(overwrite),
+					g.Button("Cancel").OnClick(skip),
 				),
 			}),
 		}
 	}
 
-	return g.Child().
-		Border(false).
-		Flags(g.WindowFlagsHorizontalScrollbar).
-		Layout(m.GetMpqTreeNodes()...)
+	paths := make([]string, len(batch.files))
+	for i, f := range batch.files {
+		paths[i] = f.destination
+	}
+
+	return g.Layout{
+		g.PopupModal("Overwrite Files?").IsOpen(&needToShowOverwritePrompt).Layout(g.Layout{
+			g.Label(fmt.Sprintf("%d files already exist. Overwrite?", len(batch.files))),
+			g.Child().Size(overwriteListW, overwriteListH).Layout(g.Label(strings.Join(paths, "\n"))),
+			g.Row(
+				g.Button("Overwrite All").Disabled(!canOverwrite).OnClick(overwrite),
+				g.Button("Skip All").OnClick(skip),
+			),
+		}),
+	}
 }
 
 // GetMpqTreeNodes returns mpq tree
@@ -151,6 +215,7 @@ func (m *MPQExplorer) renderNodes(pathEntry *common.PathEntry) g.Widget {
 			g.Selectable(pathEntry.Name + id),
 			widgets.OnDoubleClick(func() { m.fileSelectedCallback(pathEntry) }),
 			g.ContextMenu().Layout(g.Layout{
+				widgets.OpenAsMenu(pathEntry, m.openAsFileTypes, m.openAsCallback),
 				g.Selectable("Copy to Project").OnClick(func() {
 					m.copyToProject(pathEntry)
 				}),
@@ -174,34 +239,35 @@ func (m *MPQExplorer) renderNodes(pathEntry *common.PathEntry) g.Widget {
 
 	wg.Wait()
 
-	return g.TreeNode(pathEntry.Name).Layout(nodes...)
+	contextMenu := g.ContextMenu().Layout(g.Layout{
+		g.Selectable("Extract to Project...").OnClick(func() {
+			m.onExtractToProjectClicked(pathEntry)
+		}),
+		g.Selectable("Extract to...").OnClick(func() {
+			m.onExtractToClicked(pathEntry)
+		}),
+	})
+
+	//nolint:staticcheck // context menu needs to be attached to the tree node, even if it is closed
+	return g.TreeNode(pathEntry.Name).Event(contextMenu.Build).Layout(nodes...)
 }
 
 func (m *MPQExplorer) copyToProject(pathEntry *common.PathEntry) {
-	data, err := pathEntry.GetFileBytes()
-	if err != nil {
-		log.Printf("failed to read file %s when copying to project: %s", pathEntry.FullPath, err)
-		return
-	}
-
-	pathToFile := pathEntry.FullPath
-	if strings.HasPrefix(pathEntry.FullPath, "data") {
-		// strip "data" from the beginning of the path if it exists
-		pathToFile = pathToFile[4:]
-	}
-
-	pathToFile = path.Join(m.project.GetProjectFileContentPath(), pathToFile)
-	pathToFile = strings.ReplaceAll(pathToFile, "\\", "/")
+	pathToFile := m.projectPathOf(pathEntry)
 
 	if _, err := os.Stat(pathToFile); err == nil {
 		// file already exists
-		fileInfo := fileToOverwrite{
-			Path: pathToFile,
-			Data: data,
-		}
+		m.filesToOverwrite = append(m.filesToOverwrite, overwriteBatch{
+			mpqFile: pathEntry.MPQFile,
+			files:   []extractedFile{{source: pathEntry.FullPath, destination: pathToFile}},
+		})
 
-		m.filesToOverwrite = append(m.filesToOverwrite, fileInfo)
+		return
+	}
 
+	data, err := pathEntry.GetFileBytes()
+	if err != nil {
+		log.Printf("failed to read file %s when copying to project: %s", pathEntry.FullPath, err)
 		return
 	}
 
@@ -0,0 +1,271 @@
+// Package problems contains a tool window, which validates project's files
+// and lists problems found.
+package problems
+
+import (
+	"context"
+	"fmt"
+	"image/color"
+	"log"
+	"path/filepath"
+	"sync"
+	"sync/atomic"
+
+	g "github.com/AllenDang/giu"
+
+	"github.com/gucio321/HellSpawner/pkg/app/state"
+	"github.com/gucio321/HellSpawner/pkg/common"
+	"github.com/gucio321/HellSpawner/pkg/common/hsproject"
+	"github.com/gucio321/HellSpawner/pkg/window/toolwindow"
+)
+
+const (
+	mainWindowW, mainWindowH = 600, 200
+	progressBarH             = 20
+	cancelW                  = 70
+	severityColumnW          = 70
+	fileColumnW              = 250
+)
+
+var _ toolwindow.ToolWindow = (*Problems)(nil)
+
+// OpenFileFunc opens the file given
+type OpenFileFunc func(path *common.PathEntry)
+
+// Problems represents a problems tool window
+type Problems struct {
+	*toolwindow.ToolWindowBase
+	project  *hsproject.Project
+	openFile OpenFileFunc
+
+	showWarnings bool
+	validation   *validation
+	validated    bool
+	problems     []hsproject.Problem
+	selected     int
+}
+
+// validation validates the project in the background
+type validation struct {
+	cancel context.CancelFunc
+	done   atomic.Int32
+	total  atomic.Int32
+
+	mutex    sync.Mutex
+	finished bool
+	problems []hsproject.Problem
+	err      error
+}
+
+// Create creates a new problems window
+func Create(openFile OpenFileFunc, x, y float32) *Problems {
+	result := &Problems{
+		ToolWindowBase: toolwindow.New("Problems", state.ToolWindowTypeProblems, x, y),
+		openFile:       openFile,
+		showWarnings:   true,
+		selected:       -1,
+	}
+
+	if w, h := result.CurrentSize(); w == 0 || h == 0 {
+		result.Size(mainWindowW, mainWindowH)
+	}
+
+	return result
+}
+
+// SetProject sets window's project
+func (p *Problems) SetProject(project *hsproject.Project) {
+	p.project = project
+
+	p.Reset()
+}
+
+// Reset cancels the validation and clears problems found
+func (p *Problems) Reset() {
+	if p.validation != nil {
+		p.validation.cancel()
+		p.validation = nil
+	}
+
+	p.validated = false
+	p.problems = nil
+	p.selected = -1
+}
+
+// Validate starts a validation of the project
+func (p *Problems) Validate() {
+	if p.project == nil {
+		return
+	}
+
+	p.Reset()
+
+	ctx, cancel := context.WithCancel(context.Background())
+
+	v := &validation{cancel: cancel}
+	p.validation = v
+
+	// references are read from MPQs, which handles can't be shared with the UI
+	project, closeMPQs := p.project.Detached()
+
+	go func() {
+		defer closeMPQs()
+
+		problems, err := project.Validate(ctx, func(done, total int) {
+			v.done.Store(int32(done))
+			v.total.Store(int32(total))
+		})
+
+		v.mutex.Lock()
+		v.finished, v.problems, v.err = true, problems, err
+		v.mutex.Unlock()
+	}()
+}
+
+// checkValidation takes results of the validation, when it is finished
+func (p *Problems) checkValidation() {
+	v := p.validation
+
+	v.mutex.Lock()
+	defer v.mutex.Unlock()
+
+	if !v.finished {
+		return
+	}
+
+	p.validation = nil
+
+	if v.err != nil {
+		log.Printf("validation failed: %v", v.err)
+
+		return
+	}
+
+	p.validated = true
+	p.problems = v.problems
+
+	log.Printf("project validated: %s", p.summary())
+}
+
+func (p *Problems) summary() string {
+	errors, warnings := 0, 0
+
+	for _, problem := range p.problems {
+		if problem.Severity == hsproject.ProblemError {
+			errors++
+		} else {
+			warnings++
+		}
+	}
+
+	return fmt.Sprintf("%d errors, %d warnings", errors, warnings)
+}
+
+// Build builds the window
+func (p *Problems) Build() {
+	p.IsOpen(&p.Visible).
+		Layout(p.GetLayout())
+}
+
+// GetLayout returns window's layout
+func (p *Problems) GetLayout() g.Widget {
+	if p.project == nil {
+		return g.Label("No project loaded...")
+	}
+
+	if p.validation != nil {
+		p.checkValidation()
+	}
+
+	if v := p.validation; v != nil {
+		done, total := v.done.Load(), v.total.Load()
+
+		progress := float32(0)
+		if total > 0 {
+			progress = float32(done) / float32(total)
+		}
+
+		return g.Row(
+			g.ProgressBar(progress).Size(-cancelW, progressBarH).Overlay(fmt.Sprintf("Validating %d/%d", done, total)),
+			g.Button("Cancel##ProblemsCancel").OnClick(func() {
+				v.cancel()
+			}),
+		)
+	}
+
+	summary := "Project hasn't been validated yet."
+	if p.validated {
+		summary = p.summary()
+	}
+
+	return g.Layout{
+		g.Row(
+			g.Button("Validate##ProblemsValidate").OnClick(p.Validate),
+			g.Checkbox("Show warnings##ProblemsShowWarnings", &p.showWarnings),
+			g.Label(summary),
+		),
+		p.makeProblemsLayout(),
+	}
+}
+
+func (p *Problems) makeProblemsLayout() g.Widget {
+	if len(p.problems) == 0 {
+		return g.Layout{}
+	}
+
+	contentPath := p.project.GetProjectFileContentPath()
+	rows := make([]*g.TableRowWidget, 0, len(p.problems))
+
+	for idx := range p.problems {
+		problem := &p.problems[idx]
+		if problem.Severity == hsproject.ProblemWarning && !p.showWarnings {
+			continue
+		}
+
+		name := problem.Path
+		if rel, err := filepath.Rel(contentPath, problem.Path); err == nil {
+			name = rel
+		}
+
+		rows = append(rows, g.TableRow(
+			g.Selectable(fmt.Sprintf("%s##Problem%d", problem.Severity, idx)).
+				Selected(p.selected == idx).
+				Flags(g.SelectableFlagsSpanAllColumns).
+				OnClick(func() {
+					p.selected = idx
+					p.onProblemClicked(problem)
+				}),
+			g.Label(name),
+			g.Label(problem.Message),
+		).BgColor(severityColor(problem.Severity)))
+	}
+
+	return g.Table().FastMode(true).Freeze(0, 1).
+		Columns(
+			g.TableColumn("Severity").Flags(g.TableColumnFlagsWidthFixed).InnerWidthOrWeight(severityColumnW),
+			g.TableColumn("File").Flags(g.TableColumnFlagsWidthFixed).InnerWidthOrWeight(fileColumnW),
+			g.TableColumn("Problem"),
+		).
+		Rows(rows...)
+}
+
+func severityColor(severity hsproject.ProblemSeverity) color.Color {
+	if severity == hsproject.ProblemError {
+		return color.RGBA{R: 128, A: 64}
+	}
+
+	return color.RGBA{R: 128, G: 128, A: 64}
+}
+
+func (p *Problems) onProblemClicked(problem *hsproject.Problem) {
+	entry := p.project.FindPathEntry(problem.Path)
+	if entry == nil {
+		entry = &common.PathEntry{
+			Name:     filepath.Base(problem.Path),
+			FullPath: problem.Path,
+			Source:   common.PathEntrySourceProject,
+		}
+	}
+
+	p.openFile(entry)
+}
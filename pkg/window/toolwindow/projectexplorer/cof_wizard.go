@@ -0,0 +1,159 @@
+package projectexplorer
+
+import (
+	"fmt"
+	"strings"
+
+	g "github.com/AllenDang/giu"
+
+	"github.com/OpenDiablo2/OpenDiablo2/d2common/d2enum"
+
+	"github.com/gucio321/HellSpawner/pkg/common"
+	"github.com/gucio321/HellSpawner/pkg/common/hscof"
+)
+
+const (
+	cofWizardPopupID     = "New COF from DCC layers##ProjectExplorerCOFWizard"
+	cofWizardInputW      = 80
+	cofWizardComboW      = 120
+	cofWizardListW       = 350
+	cofWizardListH       = 150
+	cofWizardButtonW     = 100
+	cofWizardButtonH     = 25
+	cofWizardDefaultMode = "NU"
+	cofWizardDefaultWC   = "HTH"
+)
+
+// cofWizard holds a state of "new COF from DCC layers" pop up
+type cofWizard struct {
+	target      *common.PathEntry
+	kind        int32
+	token       string
+	mode        string
+	weaponClass string
+	layers      []hscof.Layer
+	scanned     bool
+	err         string
+}
+
+func (m *ProjectExplorer) onNewCOFFromDCCsClicked(pathEntry *common.PathEntry) {
+	m.cofWizard = &cofWizard{
+		target:      pathEntry,
+		mode:        cofWizardDefaultMode,
+		weaponClass: cofWizardDefaultWC,
+	}
+}
+
+func (m *ProjectExplorer) makeCOFWizardLayout() g.Layout {
+	w := m.cofWizard
+	isOpen := true
+	kinds := hscof.AnimationKinds()
+
+	resetScan := func() {
+		w.scanned = false
+		w.layers = nil
+		w.err = ""
+	}
+
+	return g.Layout{
+		g.Custom(func() { g.OpenPopup(cofWizardPopupID) }),
+		g.PopupModal(cofWizardPopupID).IsOpen(&isOpen).Layout(
+			g.Label(fmt.Sprintf("Scanning data\\global\\%s\\<token>\\<layer>\\ for DCC files", kinds[w.kind])),
+			g.Row(
+				g.Label("Kind:"),
+				g.Combo("##COFWizardKind", kinds[w.kind], kinds, &w.kind).Size(cofWizardComboW).OnChange(resetScan),
+			),
+			g.Row(
+				g.Label("Token:"),
+				g.InputText(&w.token).Size(cofWizardInputW).OnChange(resetScan),
+				g.Label("Mode:"),
+				g.InputText(&w.mode).Size(cofWizardInputW).OnChange(resetScan),
+				g.Label("Weapon class:"),
+				g.InputText(&w.weaponClass).Size(cofWizardInputW).OnChange(resetScan),
+			),
+			g.Separator(),
+			g.Child().Size(cofWizardListW, cofWizardListH).Layout(m.makeCOFWizardLayersList()),
+			g.Custom(func() {
+				if w.err != "" {
+					g.Label(w.err).Wrapped(true).Build()
+				}
+			}),
+			g.Separator(),
+			g.Row(
+				g.Button("Scan##COFWizardScan").Size(cofWizardButtonW, cofWizardButtonH).OnClick(m.onCOFWizardScanClicked),
+				g.Button("Create##COFWizardCreate").Size(cofWizardButtonW, cofWizardButtonH).
+					Disabled(len(w.layers) == 0).
+					OnClick(m.onCOFWizardCreateClicked),
+				g.Button("Cancel##COFWizardCancel").Size(cofWizardButtonW, cofWizardButtonH).OnClick(func() {
+					m.cofWizard = nil
+				}),
+			),
+		),
+		g.Custom(func() {
+			if !isOpen {
+				m.cofWizard = nil
+			}
+		}),
+	}
+}
+
+func (m *ProjectExplorer) makeCOFWizardLayersList() g.Layout {
+	w := m.cofWizard
+
+	if !w.scanned {
+		return g.Layout{g.Label("Press Scan to look for layers...")}
+	}
+
+	if len(w.layers) == 0 {
+		return g.Layout{g.Label("No matching DCC files found")}
+	}
+
+	result := g.Layout{}
+
+	for _, l := range w.layers {
+		result = append(result, g.Label(fmt.Sprintf("%s (%s): %d directions, %d frames - %s",
+			l.Type, l.Type.Name(), l.Directions, l.FramesPerDirection, l.Path)))
+	}
+
+	return result
+}
+
+func (m *ProjectExplorer) onCOFWizardScanClicked() {
+	w := m.cofWizard
+	w.scanned = true
+	w.err = ""
+
+	if strings.TrimSpace(w.token) == "" {
+		w.err = "Token cannot be empty"
+
+		return
+	}
+
+	layers, err := m.project.FindDCCLayers(hscof.AnimationKinds()[w.kind], w.token, w.mode, w.weaponClass)
+	if err != nil {
+		w.err = err.Error()
+
+		return
+	}
+
+	w.layers = layers
+}
+
+func (m *ProjectExplorer) onCOFWizardCreateClicked() {
+	w := m.cofWizard
+
+	cof, err := hscof.New(w.layers, d2enum.WeaponClassFromString(strings.ToLower(w.weaponClass)))
+	if err != nil {
+		w.err = err.Error()
+
+		return
+	}
+
+	if err := m.project.CreateNewCOF(w.target, cof, w.token, w.mode, w.weaponClass); err != nil {
+		w.err = err.Error()
+
+		return
+	}
+
+	m.cofWizard = nil
+}
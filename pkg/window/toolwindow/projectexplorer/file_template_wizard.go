@@ -0,0 +1,126 @@
+package projectexplorer
+
+import (
+	"fmt"
+	"strconv"
+
+	g "github.com/AllenDang/giu"
+
+	"github.com/gucio321/HellSpawner/pkg/common"
+	"github.com/gucio321/HellSpawner/pkg/common/hsproject"
+)
+
+const (
+	fileTemplateWizardPopupID = "New file from template##ProjectExplorerFileTemplateWizard"
+	fileTemplateWizardInputW  = 250
+	fileTemplateWizardButtonW = 100
+	fileTemplateWizardButtonH = 25
+)
+
+// fileTemplateWizard holds a state of "new file from template" pop up
+type fileTemplateWizard struct {
+	target   *common.PathEntry
+	template *hsproject.FileTemplate
+	// texts and numbers are values of template's parameters (depending on parameter's kind)
+	texts   []string
+	numbers []int32
+	err     string
+}
+
+func (m *ProjectExplorer) fileTemplatesMenu(pathEntry *common.PathEntry) g.Widget {
+	items := g.Layout{}
+
+	for _, template := range hsproject.FileTemplates() {
+		label := fmt.Sprintf("%s (%s)...", template.Name, template.FileType.FileExtension())
+		items = append(items, g.MenuItem(label).OnClick(func() { m.onNewFileFromTemplateClicked(pathEntry, template) }))
+	}
+
+	return g.Menu("From template").Layout(items)
+}
+
+func (m *ProjectExplorer) onNewFileFromTemplateClicked(pathEntry *common.PathEntry, template *hsproject.FileTemplate) {
+	w := &fileTemplateWizard{
+		target:   pathEntry,
+		template: template,
+		texts:    make([]string, len(template.Parameters)),
+		numbers:  make([]int32, len(template.Parameters)),
+	}
+
+	for i, param := range template.Parameters {
+		w.texts[i] = param.Default
+
+		if n, err := strconv.ParseInt(param.Default, 10, 32); err == nil {
+			w.numbers[i] = int32(n)
+		}
+	}
+
+	m.fileTemplateWizard = w
+}
+
+func (m *ProjectExplorer) makeFileTemplateWizardLayout() g.Layout {
+	w := m.fileTemplateWizard
+	isOpen := true
+
+	params := g.Layout{}
+
+	for i, param := range w.template.Parameters {
+		id := fmt.Sprintf("##FileTemplateWizardParam%d", i)
+
+		var input g.Widget = g.InputText(&w.texts[i]).Label(id).Size(fileTemplateWizardInputW)
+		if param.Number {
+			input = g.InputInt(&w.numbers[i]).Label(id).Size(fileTemplateWizardInputW)
+		}
+
+		params = append(params, g.Row(g.Label(param.Name+":"), input))
+	}
+
+	return g.Layout{
+		g.Custom(func() { g.OpenPopup(fileTemplateWizardPopupID) }),
+		g.PopupModal(fileTemplateWizardPopupID).IsOpen(&isOpen).Layout(
+			g.Label(fmt.Sprintf("%s (%s)", w.template.Name, w.template.FileType)),
+			g.Separator(),
+			params,
+			g.Custom(func() {
+				if w.err != "" {
+					g.Label(w.err).Wrapped(true).Build()
+				}
+			}),
+			g.Separator(),
+			g.Row(
+				g.Button("Create##FileTemplateWizardCreate").
+					Size(fileTemplateWizardButtonW, fileTemplateWizardButtonH).
+					OnClick(m.onFileTemplateWizardCreateClicked),
+				g.Button("Cancel##FileTemplateWizardCancel").
+					Size(fileTemplateWizardButtonW, fileTemplateWizardButtonH).
+					OnClick(func() {
+						m.fileTemplateWizard = nil
+					}),
+			),
+		),
+		g.Custom(func() {
+			if !isOpen {
+				m.fileTemplateWizard = nil
+			}
+		}),
+	}
+}
+
+func (m *ProjectExplorer) onFileTemplateWizardCreateClicked() {
+	w := m.fileTemplateWizard
+	values := make(map[string]string, len(w.template.Parameters))
+
+	for i, param := range w.template.Parameters {
+		values[param.Name] = w.texts[i]
+		if param.Number {
+			values[param.Name] = strconv.Itoa(int(w.numbers[i]))
+		}
+	}
+
+	if err := m.project.CreateNewFileFromTemplate(w.template, values, w.target); err != nil {
+		w.err = err.Error()
+
+		return
+	}
+
+	m.fileTemplateWizard = nil
+}
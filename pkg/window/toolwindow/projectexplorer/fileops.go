@@ -0,0 +1,202 @@
+package projectexplorer
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/AllenDang/cimgui-go/imgui"
+	g "github.com/AllenDang/giu"
+	"github.com/OpenDiablo2/dialog"
+
+	"github.com/gucio321/HellSpawner/pkg/common"
+)
+
+const dragDropType = "PROJECT_PATHS"
+
+// PathMovedCallback is called when a file or directory is moved (or renamed)
+type PathMovedCallback func(oldPath, newPath string)
+
+// isSelected returns true if the path is selected
+func (m *ProjectExplorer) isSelected(path string) bool {
+	return m.selection[path]
+}
+
+// selectedPaths returns selected paths. Paths inside of selected directories are skipped.
+func (m *ProjectExplorer) selectedPaths() []string {
+	result := make([]string, 0, len(m.selection))
+
+	for path := range m.selection {
+		result = append(result, path)
+	}
+
+	sort.Strings(result)
+
+	idx := 0
+
+	for _, path := range result {
+		if idx > 0 && strings.HasPrefix(path, result[idx-1]+string(filepath.Separator)) {
+			continue
+		}
+
+		result[idx] = path
+		idx++
+	}
+
+	return result[:idx]
+}
+
+// targetPaths returns paths, which the context menu's action applies to:
+// all the selected paths if the entry is selected, or the entry only.
+func (m *ProjectExplorer) targetPaths(entry *common.PathEntry) []string {
+	if m.isSelected(entry.FullPath) {
+		return m.selectedPaths()
+	}
+
+	return []string{entry.FullPath}
+}
+
+// handleItemEvents handles selection and drag and drop of the last item (entry's tree node)
+func (m *ProjectExplorer) handleItemEvents(entry *common.PathEntry) {
+	if imgui.IsItemClicked() && !entry.IsRoot {
+		if g.IsKeyDown(g.KeyLeftControl) || g.IsKeyDown(g.KeyRightControl) {
+			m.selection[entry.FullPath] = !m.selection[entry.FullPath]
+			if !m.selection[entry.FullPath] {
+				delete(m.selection, entry.FullPath)
+			}
+		} else {
+			m.selection = map[string]bool{entry.FullPath: true}
+		}
+	}
+
+	if !entry.IsRoot && imgui.BeginDragDropSource() {
+		m.dragged = m.targetPaths(entry)
+		imgui.SetDragDropPayload(dragDropType, 0, 0)
+
+		if len(m.dragged) == 1 {
+			imgui.Text(entry.Name)
+		} else {
+			imgui.Text(fmt.Sprintf("%d items", len(m.dragged)))
+		}
+
+		imgui.EndDragDropSource()
+	}
+
+	if entry.IsDirectory && imgui.BeginDragDropTarget() {
+		if imgui.AcceptDragDropPayload(dragDropType) != nil {
+			m.movePaths(m.dragged, entry.FullPath)
+			m.dragged = nil
+		}
+
+		imgui.EndDragDropTarget()
+	}
+}
+
+// fileOperationsMenu returns context menu's items of file operations
+func (m *ProjectExplorer) fileOperationsMenu(entry *common.PathEntry) g.Layout {
+	targets := m.targetPaths(entry)
+
+	layout := g.Layout{
+		g.MenuItem("Copy").OnClick(func() { m.setClipboard(targets, false) }),
+		g.MenuItem("Cut").OnClick(func() { m.setClipboard(targets, true) }),
+	}
+
+	if entry.IsDirectory {
+		layout = append(layout,
+			g.MenuItem("Paste").Enabled(len(m.clipboard) > 0).OnClick(func() { m.paste(entry.FullPath) }),
+		)
+	}
+
+	layout = append(layout,
+		g.MenuItem("Duplicate").OnClick(func() { m.duplicatePaths(targets) }),
+	)
+
+	if len(targets) > 1 {
+		layout = append(layout,
+			g.MenuItem(fmt.Sprintf("Delete %d selected items...", len(targets))).OnClick(func() { m.deletePaths(targets) }),
+		)
+	}
+
+	return layout
+}
+
+func (m *ProjectExplorer) setClipboard(paths []string, cut bool) {
+	m.clipboard = paths
+	m.clipboardCut = cut
+}
+
+func (m *ProjectExplorer) paste(dir string) {
+	if m.clipboardCut {
+		m.movePaths(m.clipboard, dir)
+		m.clipboard = nil
+
+		return
+	}
+
+	for _, path := range m.clipboard {
+		if _, err := m.project.CopyFile(path, dir); err != nil {
+			logErr("Could not paste: %v", err)
+
+			return
+		}
+	}
+}
+
+func (m *ProjectExplorer) movePaths(paths []string, dir string) {
+	for _, path := range paths {
+		newPath, err := m.project.MoveFile(path, dir)
+		if err != nil {
+			logErr("Could not move: %v", err)
+
+			return
+		}
+
+		m.onPathMoved(path, newPath)
+	}
+}
+
+// onPathMoved updates selection and notifies about the path moved
+func (m *ProjectExplorer) onPathMoved(oldPath, newPath string) {
+	if oldPath == newPath {
+		return
+	}
+
+	if m.selection[oldPath] {
+		delete(m.selection, oldPath)
+		m.selection[newPath] = true
+	}
+
+	if m.pathMovedCallback != nil {
+		m.pathMovedCallback(oldPath, newPath)
+	}
+}
+
+func (m *ProjectExplorer) duplicatePaths(paths []string) {
+	for _, path := range paths {
+		if _, err := m.project.DuplicateFile(path); err != nil {
+			logErr("Could not duplicate: %v", err)
+
+			return
+		}
+	}
+}
+
+func (m *ProjectExplorer) deletePaths(paths []string) {
+	if !dialog.Message("Are you sure you want to delete %d items:\n%s", len(paths), strings.Join(paths, "\n")).YesNo() {
+		return
+	}
+
+	for _, path := range paths {
+		if err := os.RemoveAll(path); err != nil {
+			dialog.Message("Could not delete:\n%s", path).Error()
+
+			break
+		}
+
+		delete(m.selection, path)
+	}
+
+	m.project.InvalidateFileStructure()
+}
@@ -0,0 +1,211 @@
+package projectexplorer
+
+import (
+	"fmt"
+	"log"
+
+	g "github.com/AllenDang/giu"
+	"github.com/OpenDiablo2/dialog"
+
+	"github.com/gucio321/HellSpawner/pkg/common"
+	"github.com/gucio321/HellSpawner/pkg/common/hsproject"
+)
+
+const (
+	importPopupID     = "Import files##ProjectExplorerImport"
+	filePickerPopupID = "Import file(s)##ProjectExplorerImportFilePicker"
+	importButtonW     = 100
+	importButtonH     = 25
+	filePickerListW   = 400
+	filePickerListH   = 150
+)
+
+// importFilePicker collects files to import into dir.
+// The file dialog picks a single file, so that files are picked one by one and imported at once.
+type importFilePicker struct {
+	dir   string
+	paths []string
+}
+
+// add asks user for a file and adds it to the picked ones
+func (p *importFilePicker) add() {
+	path, err := dialog.File().Title("Import file").Load()
+	if err != nil || path == "" {
+		return
+	}
+
+	for _, picked := range p.paths {
+		if picked == path {
+			return
+		}
+	}
+
+	p.paths = append(p.paths, path)
+}
+
+func (m *ProjectExplorer) onImportFileClicked(pathEntry *common.PathEntry) {
+	picker := &importFilePicker{dir: pathEntry.FullPath}
+
+	picker.add()
+
+	if len(picker.paths) == 0 {
+		return
+	}
+
+	m.filePicker = picker
+}
+
+func (m *ProjectExplorer) onImportFolderClicked(pathEntry *common.PathEntry) {
+	path, err := dialog.Directory().Title("Import folder").Browse()
+	if err != nil || path == "" {
+		return
+	}
+
+	m.importFiles([]string{path}, pathEntry.FullPath)
+}
+
+// ImportFiles imports files and directories (e.g. dropped onto the window) into the selected directory,
+// or into the project's root if there is no single directory selected.
+func (m *ProjectExplorer) ImportFiles(paths []string) {
+	if m.project == nil {
+		return
+	}
+
+	dir := m.project.GetProjectFileContentPath()
+
+	if selected := m.selectedPaths(); len(selected) == 1 {
+		if entry := m.project.FindPathEntry(selected[0]); entry != nil && entry.IsDirectory {
+			dir = entry.FullPath
+		}
+	}
+
+	m.importFiles(paths, dir)
+}
+
+// importFiles imports files into the directory given. If some files already exist,
+// user is asked what to do with them.
+func (m *ProjectExplorer) importFiles(paths []string, dir string) {
+	plan, err := m.project.PlanImport(paths, dir)
+	if err != nil {
+		logErr("Could not import files: %v", err)
+
+		return
+	}
+
+	if plan.Conflicts() > 0 {
+		m.pendingImport = plan
+
+		return
+	}
+
+	m.runImport(plan, hsproject.ImportSkip)
+}
+
+func (m *ProjectExplorer) runImport(plan hsproject.ImportPlan, policy hsproject.ImportConflictPolicy) {
+	result, err := m.project.Import(plan, policy)
+	if err != nil {
+		logErr("Could not import files: %v", err)
+	}
+
+	if result == nil {
+		return
+	}
+
+	log.Printf("imported %d files, %d skipped", result.Imported, result.Skipped)
+
+	for _, path := range result.Unknown {
+		log.Printf("%s: unknown file type, HellSpawner will not be able to open it", path)
+	}
+}
+
+func (m *ProjectExplorer) makeImportFilePickerLayout() g.Layout {
+	isOpen := true
+	picker := m.filePicker
+
+	rows := make([]*g.TableRowWidget, len(picker.paths))
+
+	for i, path := range picker.paths {
+		rows[i] = g.TableRow(
+			g.Label(path),
+			g.Button(fmt.Sprintf("Remove##ProjectExplorerImportFilePickerRemove%d", i)).OnClick(func() {
+				picker.paths = append(picker.paths[:i], picker.paths[i+1:]...)
+				if len(picker.paths) == 0 {
+					m.filePicker = nil
+				}
+			}),
+		)
+	}
+
+	return g.Layout{
+		g.Custom(func() { g.OpenPopup(filePickerPopupID) }),
+		g.PopupModal(filePickerPopupID).IsOpen(&isOpen).Layout(
+			g.Label(fmt.Sprintf("%d files will be imported into %s", len(picker.paths), picker.dir)),
+			g.Table().Size(filePickerListW, filePickerListH).Rows(rows...),
+			g.Separator(),
+			g.Row(
+				g.Button("Add file...##ProjectExplorerImportFilePickerAdd").
+					Size(importButtonW, importButtonH).
+					OnClick(picker.add),
+				g.Button("Import##ProjectExplorerImportFilePickerImport").
+					Size(importButtonW, importButtonH).
+					OnClick(func() {
+						m.filePicker = nil
+						m.importFiles(picker.paths, picker.dir)
+					}),
+				g.Button("Cancel##ProjectExplorerImportFilePickerCancel").
+					Size(importButtonW, importButtonH).
+					OnClick(func() {
+						m.filePicker = nil
+					}),
+			),
+		),
+		g.Custom(func() {
+			if !isOpen {
+				m.filePicker = nil
+			}
+		}),
+	}
+}
+
+func (m *ProjectExplorer) makeImportLayout() g.Layout {
+	isOpen := true
+	plan := m.pendingImport
+
+	buttons := make([]g.Widget, 0)
+
+	for _, policy := range []hsproject.ImportConflictPolicy{
+		hsproject.ImportOverwrite,
+		hsproject.ImportSkip,
+		hsproject.ImportRename,
+	} {
+		buttons = append(buttons, g.Button(policy.String()+"##ProjectExplorerImport"+policy.String()).
+			Size(importButtonW, importButtonH).
+			OnClick(func() {
+				m.pendingImport = nil
+				m.runImport(plan, policy)
+			}),
+		)
+	}
+
+	buttons = append(buttons, g.Button("Cancel##ProjectExplorerImportCancel").
+		Size(importButtonW, importButtonH).
+		OnClick(func() {
+			m.pendingImport = nil
+		}),
+	)
+
+	return g.Layout{
+		g.Custom(func() { g.OpenPopup(importPopupID) }),
+		g.PopupModal(importPopupID).IsOpen(&isOpen).Layout(
+			g.Label(fmt.Sprintf("%d of %d imported files already exist in the project.", plan.Conflicts(), len(plan))),
+			g.Label("Rename imports them under new names (e.g. \"name copy.ext\")."),
+			g.Separator(),
+			g.Row(buttons...),
+		),
+		g.Custom(func() {
+			if !isOpen {
+				m.pendingImport = nil
+			}
+		}),
+	}
+}
@@ -2,6 +2,7 @@
 package projectexplorer
 
 import (
+	"fmt"
 	"log"
 	"os"
 	"path/filepath"
@@ -39,6 +40,9 @@ const (
 // FileSelectedCallback represents callback on project file selected
 type FileSelectedCallback func(path *common.PathEntry)
 
+// OpenAsCallback represents callback on project file chosen to be opened as a specific file type
+type OpenAsCallback func(path *common.PathEntry, fileType hsfiletypes.FileType)
+
 var _ toolwindow.ToolWindow = (*ProjectExplorer)(nil)
 
 // ProjectExplorer represents a project explorer
@@ -49,17 +53,39 @@ type ProjectExplorer struct {
 	fileSelectedCallback FileSelectedCallback
 	nodeCache            map[string][]g.Widget
 	refreshIconTexture   *g.Texture
+	cofWizard            *cofWizard
+	fileTemplateWizard   *fileTemplateWizard
+
+	pathMovedCallback PathMovedCallback
+	// selection contains full paths of selected files and directories
+	selection    map[string]bool
+	clipboard    []string
+	clipboardCut bool
+	// dragged are paths being dragged
+	dragged []string
+	// filePicker collects files to import ("Import File(s)...")
+	filePicker *importFilePicker
+	// pendingImport is an import waiting for user's decision about conflicts
+	pendingImport hsproject.ImportPlan
+	// newFileTypes are additional (e.g. plugins') file types, which may be created
+	newFileTypes []hsfiletypes.FileType
+	// openAsFileTypes are file types offered in the "Open As" menu
+	openAsFileTypes []hsfiletypes.FileType
+	openAsCallback  OpenAsCallback
 }
 
 // Create creates a new project explorer
 func Create(
 	fileSelectedCallback FileSelectedCallback,
+	pathMovedCallback PathMovedCallback,
 	x, y float32,
 ) (*ProjectExplorer, error) {
 	result := &ProjectExplorer{
 		ToolWindowBase:       toolwindow.New("Project Explorer", state.ToolWindowTypeProjectExplorer, x, y),
 		nodeCache:            make(map[string][]g.Widget),
 		fileSelectedCallback: fileSelectedCallback,
+		pathMovedCallback:    pathMovedCallback,
+		selection:            make(map[string]bool),
 	}
 
 	result.Visible = false
@@ -75,9 +101,22 @@ func Create(
 	return result, nil
 }
 
+// AddNewFileTypes adds file types to the "New" menu
+func (m *ProjectExplorer) AddNewFileTypes(fileTypes ...hsfiletypes.FileType) {
+	m.newFileTypes = append(m.newFileTypes, fileTypes...)
+}
+
+// SetOpenAs sets file types, which files can be opened as, and a callback called when the user chooses one
+func (m *ProjectExplorer) SetOpenAs(fileTypes []hsfiletypes.FileType, cb OpenAsCallback) {
+	m.openAsFileTypes = fileTypes
+	m.openAsCallback = cb
+}
+
 // SetProject sets explored project
 func (m *ProjectExplorer) SetProject(project *hsproject.Project) {
 	m.project = project
+	m.selection = make(map[string]bool)
+	m.clipboard = nil
 }
 
 // Build builds explorer
@@ -99,11 +138,29 @@ func (m *ProjectExplorer) GetLayout() g.Widget {
 		Flags(g.WindowFlagsHorizontalScrollbar).
 		Layout(m.GetProjectTreeNodes())
 
-	return g.Layout{
+	layout := g.Layout{
 		header,
 		g.Separator(),
 		tree,
 	}
+
+	if m.cofWizard != nil {
+		layout = append(layout, m.makeCOFWizardLayout())
+	}
+
+	if m.fileTemplateWizard != nil {
+		layout = append(layout, m.makeFileTemplateWizardLayout())
+	}
+
+	if m.filePicker != nil {
+		layout = append(layout, m.makeImportFilePickerLayout())
+	}
+
+	if m.pendingImport != nil {
+		layout = append(layout, m.makeImportLayout())
+	}
+
+	return layout
 }
 
 func (m *ProjectExplorer) makeRefreshButtonLayout() g.Layout {
@@ -215,13 +272,17 @@ func (m *ProjectExplorer) createFileTreeItem(pathEntry *common.PathEntry) g.Widg
 		}
 	} else {
 		layout = append(layout,
-			g.Selectable(pathEntry.Name+id),
+			g.Selectable(pathEntry.Name+id).Selected(m.isSelected(pathEntry.FullPath)),
+			g.Custom(func() { m.handleItemEvents(pathEntry) }),
 			widgets.OnDoubleClick(func() { m.fileSelectedCallback(pathEntry) }),
 		)
 	}
 
 	layout = append(layout,
 		g.ContextMenu().Layout(g.Layout{
+			widgets.OpenAsMenu(pathEntry, m.openAsFileTypes, m.openAsCallback),
+			m.fileOperationsMenu(pathEntry),
+			g.Separator(),
 			g.MenuItem("Rename").OnClick(func() { m.onRenameFileClicked(pathEntry) }),
 			g.MenuItem("Delete...").OnClick(func() { m.onDeleteFileClicked(pathEntry) }),
 		}),
@@ -271,6 +332,7 @@ func (m *ProjectExplorer) createDirectoryTreeItem(pathEntry *common.PathEntry, l
 					log.Print(err)
 				}
 			}),
+			g.MenuItem("Animation from DCC layers (.cof)...").OnClick(func() { m.onNewCOFFromDCCsClicked(pathEntry) }),
 			g.MenuItem("Palette (.dat)").OnClick(func() {
 				if err := m.project.CreateNewFile(hsfiletypes.FileTypePalette, pathEntry); err != nil {
 					log.Print(err)
@@ -291,11 +353,18 @@ func (m *ProjectExplorer) createDirectoryTreeItem(pathEntry *common.PathEntry, l
 					log.Print(err)
 				}
 			}),
+			m.newFileTypesMenu(pathEntry),
+			g.Separator(),
+			m.fileTemplatesMenu(pathEntry),
 		}),
+		g.MenuItem("Import File(s)...").OnClick(func() { m.onImportFileClicked(pathEntry) }),
+		g.MenuItem("Import Folder...").OnClick(func() { m.onImportFolderClicked(pathEntry) }),
 	}
 
 	if !pathEntry.IsRoot {
 		contextMenuLayout = append(contextMenuLayout,
+			g.Separator(),
+			m.fileOperationsMenu(pathEntry),
 			g.Separator(),
 			g.MenuItem("Rename").OnClick(func() { m.onRenameFileClicked(pathEntry) }),
 			g.MenuItem("Delete Folder...").OnClick(func() { m.onDeleteFolderClicked(pathEntry) }),
@@ -308,11 +377,45 @@ func (m *ProjectExplorer) createDirectoryTreeItem(pathEntry *common.PathEntry, l
 		g.Custom(func() { imgui.PopID() }),
 	}
 
+	if pathEntry.IsRoot {
+		contextMenuLayout = append(contextMenuLayout,
+			g.Separator(),
+			g.MenuItem("Paste").Enabled(len(m.clipboard) > 0).OnClick(func() { m.paste(pathEntry.FullPath) }),
+		)
+	}
+
+	var flags g.TreeNodeFlags
+	if m.isSelected(pathEntry.FullPath) {
+		flags |= g.TreeNodeFlagsSelected
+	}
+
+	//nolint:staticcheck // EventHandler doesn't support drag and drop
+	node := g.TreeNode(id).Flags(flags).Event(func() { m.handleItemEvents(pathEntry) })
+
 	if layout == nil {
-		return g.TreeNode(id).Layout(menuLayout)
+		return node.Layout(menuLayout)
+	}
+
+	return node.Layout(append(menuLayout, layout...))
+}
+
+func (m *ProjectExplorer) newFileTypesMenu(pathEntry *common.PathEntry) g.Widget {
+	if len(m.newFileTypes) == 0 {
+		return g.Layout{}
+	}
+
+	result := g.Layout{g.Separator()}
+
+	for _, fileType := range m.newFileTypes {
+		label := fmt.Sprintf("%s (%s)", fileType, fileType.FileExtension())
+		result = append(result, g.MenuItem(label).OnClick(func() {
+			if err := m.project.CreateNewFile(fileType, pathEntry); err != nil {
+				log.Print(err)
+			}
+		}))
 	}
 
-	return g.TreeNode(id).Layout(append(menuLayout, layout...))
+	return result
 }
 
 func (m *ProjectExplorer) onDeleteFolderClicked(entry *common.PathEntry) {
@@ -404,6 +507,7 @@ func (m *ProjectExplorer) onFileRenamed(entry *common.PathEntry) {
 	}
 
 	m.project.InvalidateFileStructure()
+	m.onPathMoved(oldPath, newPath)
 }
 
 func logErr(fmtErr string, args ...interface{}) {
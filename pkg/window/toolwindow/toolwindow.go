@@ -17,6 +17,8 @@ type ToolWindow interface {
 	State() state.ToolWindowState
 	Pos(x, y float32) *window.Window
 	Size(float32, float32) *giu.WindowWidget
+	Place(x, y, w, h float32)
+	ApplyPlacement()
 	CurrentSize() (float32, float32)
 }
 
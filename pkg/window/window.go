@@ -1,6 +1,7 @@
 package window
 
 import (
+	"github.com/AllenDang/cimgui-go/imgui"
 	"github.com/AllenDang/giu"
 	"github.com/gucio321/HellSpawner/pkg/app/state"
 )
@@ -9,15 +10,43 @@ import (
 type Window struct {
 	*giu.WindowWidget
 	Visible bool
+
+	title string
+	// placement is a position and size to force on the window (see Place)
+	placement *state.WindowState
 }
 
 // New creates new window
 func New(title string, x, y float32) *Window {
 	return (&Window{
 		WindowWidget: giu.Window(title),
+		title:        title,
 	}).Pos(x, y)
 }
 
+// Place moves and resizes the window. Unlike Pos and Size, which are used only
+// when the window is shown for the first time, it works on windows already shown.
+// The placement is applied by ApplyPlacement.
+func (t *Window) Place(x, y, w, h float32) {
+	t.WindowWidget.Pos(x, y).Size(w, h)
+	t.placement = &state.WindowState{PosX: x, PosY: y, Width: w, Height: h}
+}
+
+// ApplyPlacement applies the placement requested by Place once. It has to be called during a frame.
+func (t *Window) ApplyPlacement() {
+	if t.placement == nil || !t.Visible {
+		return
+	}
+
+	imgui.SetWindowPosStrV(t.title, imgui.Vec2{X: t.placement.PosX, Y: t.placement.PosY}, imgui.CondAlways)
+
+	if t.placement.Width > 0 && t.placement.Height > 0 {
+		imgui.SetWindowSizeStrV(t.title, imgui.Vec2{X: t.placement.Width, Y: t.placement.Height}, imgui.CondAlways)
+	}
+
+	t.placement = nil
+}
+
 // State returns window's state
 func (t *Window) State() state.WindowState {
 	x, y := t.CurrentPosition()
@@ -72,6 +101,16 @@ func (t *Window) Cleanup() {
 	t.Visible = false
 }
 
+// SetTitle changes window's title (and so its ID), keeping window's position and size
+func (t *Window) SetTitle(title string) {
+	x, y := t.CurrentPosition()
+	w, h := t.CurrentSize()
+
+	t.WindowWidget = giu.Window(title)
+	t.WindowWidget.Pos(x, y).Size(w, h)
+	t.title = title
+}
+
 func (t *Window) Pos(x, y float32) *Window {
 	t.WindowWidget.Pos(x, y)
 	return t